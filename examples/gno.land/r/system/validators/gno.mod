@@ -1 +1,6 @@
 module gno.land/r/system/validators
+
+require (
+	gno.land/p/demo/avl v0.0.0-latest
+	gno.land/p/demo/ufmt v0.0.0-latest
+)
@@ -9,6 +9,7 @@ import (
 	"io"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/gnolang/gno/gno.land/pkg/gnoland"
 	"github.com/gnolang/gno/tm2/pkg/amino"
@@ -24,6 +25,8 @@ var (
 	errNoBalanceSource       = errors.New("at least one balance source must be set")
 	errBalanceParsingAborted = errors.New("balance parsing aborted")
 	errInvalidAddress        = errors.New("invalid address encountered")
+	errVestingStartNoEnd     = errors.New("vesting start time set without a vesting end time")
+	errVestingStartAfterEnd  = errors.New("vesting start time is not before the vesting end time")
 )
 
 type balancesAddCfg struct {
@@ -32,6 +35,8 @@ type balancesAddCfg struct {
 	balanceSheet  string
 	singleEntries commands.StringArr
 	parseExport   string
+	vestingStart  string
+	vestingEnd    string
 }
 
 // newBalancesAddCmd creates the genesis balances add subcommand
@@ -73,6 +78,20 @@ func (c *balancesAddCfg) RegisterFlags(fs *flag.FlagSet) {
 		"",
 		"the path to the transaction export containing a list of transactions (JSONL)",
 	)
+
+	fs.StringVar(
+		&c.vestingStart,
+		"vesting-start",
+		"",
+		"the time (RFC3339) the added balances start vesting linearly at, until --vesting-end",
+	)
+
+	fs.StringVar(
+		&c.vestingEnd,
+		"vesting-end",
+		"",
+		"the time (RFC3339) the added balances are locked until, if they vest",
+	)
 }
 
 func execBalancesAdd(ctx context.Context, cfg *balancesAddCfg, io commands.IO) error {
@@ -93,6 +112,12 @@ func execBalancesAdd(ctx context.Context, cfg *balancesAddCfg, io commands.IO) e
 		return errNoBalanceSource
 	}
 
+	// Parse the vesting schedule of the added balances, if any
+	vesting, err := parseVestingSchedule(cfg.vestingStart, cfg.vestingEnd)
+	if err != nil {
+		return err
+	}
+
 	finalBalances := make(accountBalances)
 
 	// Get the balance sheet from the source
@@ -147,6 +172,9 @@ func execBalancesAdd(ctx context.Context, cfg *balancesAddCfg, io commands.IO) e
 		return err
 	}
 
+	// Replace the vesting of the added balances
+	state.Vesting = mergeVesting(state.Vesting, finalBalances, vesting)
+
 	// Merge the two balance sheets, with the input
 	// having precedence over the genesis balances
 	finalBalances.leftMerge(genesisBalances)
@@ -171,9 +199,86 @@ func execBalancesAdd(ctx context.Context, cfg *balancesAddCfg, io commands.IO) e
 		io.Printfln("%s:%dugnot", address.String(), balance)
 	}
 
+	if vesting != nil {
+		io.Println()
+		io.Printfln(
+			"The added balances are locked until %s",
+			time.Unix(vesting.EndTime, 0).UTC().Format(time.RFC3339),
+		)
+	}
+
 	return nil
 }
 
+// parseVestingSchedule parses the vesting schedule of the added balances from
+// the passed in start and end times, if set
+func parseVestingSchedule(start, end string) (*gnoland.Vesting, error) {
+	if end == "" {
+		if start != "" {
+			return nil, errVestingStartNoEnd
+		}
+
+		return nil, nil
+	}
+
+	var vesting gnoland.Vesting
+
+	endTime, err := time.Parse(time.RFC3339, end)
+	if err != nil {
+		return nil, fmt.Errorf("invalid vesting end time, %w", err)
+	}
+	vesting.EndTime = endTime.Unix()
+
+	if start != "" {
+		startTime, err := time.Parse(time.RFC3339, start)
+		if err != nil {
+			return nil, fmt.Errorf("invalid vesting start time, %w", err)
+		}
+		vesting.StartTime = startTime.Unix()
+	}
+
+	if vesting.StartTime >= vesting.EndTime {
+		return nil, errVestingStartAfterEnd
+	}
+
+	return &vesting, nil
+}
+
+// mergeVesting drops the vesting of the added balances from the genesis
+// vesting, and locks the added balances following the vesting schedule, if set
+func mergeVesting(
+	genesisVesting []gnoland.Vesting,
+	balances accountBalances,
+	vesting *gnoland.Vesting,
+) []gnoland.Vesting {
+	merged := make([]gnoland.Vesting, 0, len(genesisVesting))
+
+	for _, v := range genesisVesting {
+		if _, added := balances[v.Address]; !added {
+			merged = append(merged, v)
+		}
+	}
+
+	if vesting == nil {
+		return merged
+	}
+
+	for address, balance := range balances {
+		if balance.Amount.IsZero() {
+			continue
+		}
+
+		merged = append(merged, gnoland.Vesting{
+			Address:   address,
+			Amount:    balance.Amount,
+			StartTime: vesting.StartTime,
+			EndTime:   vesting.EndTime,
+		})
+	}
+
+	return merged
+}
+
 // getBalancesFromEntries extracts the balance entries
 // from the array of balance
 func getBalancesFromEntries(entries []string) (accountBalances, error) {
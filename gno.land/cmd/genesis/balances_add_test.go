@@ -406,6 +406,135 @@ func TestGenesis_Balances_Add(t *testing.T) {
 			}
 		}
 	})
+
+	t.Run("balances with vesting", func(t *testing.T) {
+		t.Parallel()
+
+		dummyKeys := getDummyKeys(t, 2)
+
+		tempGenesis, cleanup := testutils.NewTestFile(t)
+		t.Cleanup(cleanup)
+
+		genesis := getDefaultGenesis()
+		state := gnoland.GnoGenesisState{
+			// Set an initial vesting, replaced by the added balance
+			Balances: []gnoland.Balance{
+				{
+					Address: dummyKeys[0].Address(),
+					Amount:  std.NewCoins(std.NewCoin("ugnot", 100)),
+				},
+			},
+			Vesting: []gnoland.Vesting{
+				{
+					Address: dummyKeys[0].Address(),
+					Amount:  std.NewCoins(std.NewCoin("ugnot", 100)),
+					EndTime: 1000,
+				},
+			},
+		}
+		genesis.AppState = state
+		require.NoError(t, genesis.SaveAs(tempGenesis.Name()))
+
+		// Create the command
+		cmd := newRootCmd(commands.NewTestIO())
+		args := []string{
+			"balances",
+			"add",
+			"--genesis-path",
+			tempGenesis.Name(),
+			"--vesting-start",
+			"2025-01-01T00:00:00Z",
+			"--vesting-end",
+			"2026-01-01T00:00:00Z",
+		}
+
+		amount := std.NewCoins(std.NewCoin("ugnot", 10))
+
+		for _, dummyKey := range dummyKeys {
+			args = append(args, "--single")
+			args = append(
+				args,
+				fmt.Sprintf(
+					"%s=%dugnot",
+					dummyKey.Address().String(),
+					amount.AmountOf("ugnot"),
+				),
+			)
+		}
+
+		// Run the command
+		cmdErr := cmd.ParseAndRun(context.Background(), args)
+		require.NoError(t, cmdErr)
+
+		// Validate the genesis was updated
+		genesis, loadErr := types.GenesisDocFromFile(tempGenesis.Name())
+		require.NoError(t, loadErr)
+
+		require.NotNil(t, genesis.AppState)
+
+		state, ok := genesis.AppState.(gnoland.GnoGenesisState)
+		require.True(t, ok)
+
+		require.Equal(t, len(dummyKeys), len(state.Vesting))
+
+		for _, vesting := range state.Vesting {
+			assert.Equal(t, amount, vesting.Amount)
+			assert.Equal(t, int64(1735689600), vesting.StartTime)
+			assert.Equal(t, int64(1767225600), vesting.EndTime)
+		}
+	})
+
+	t.Run("invalid vesting", func(t *testing.T) {
+		t.Parallel()
+
+		dummyKey := getDummyKey(t)
+
+		tempGenesis, cleanup := testutils.NewTestFile(t)
+		t.Cleanup(cleanup)
+
+		genesis := getDefaultGenesis()
+		require.NoError(t, genesis.SaveAs(tempGenesis.Name()))
+
+		testTable := []struct {
+			name        string
+			vestingArgs []string
+			expectedErr error
+		}{
+			{
+				"start without end",
+				[]string{"--vesting-start", "2025-01-01T00:00:00Z"},
+				errVestingStartNoEnd,
+			},
+			{
+				"start after end",
+				[]string{"--vesting-start", "2026-01-01T00:00:00Z", "--vesting-end", "2025-01-01T00:00:00Z"},
+				errVestingStartAfterEnd,
+			},
+		}
+
+		for _, testCase := range testTable {
+			testCase := testCase
+
+			t.Run(testCase.name, func(t *testing.T) {
+				t.Parallel()
+
+				// Create the command
+				cmd := newRootCmd(commands.NewTestIO())
+				args := append([]string{
+					"balances",
+					"add",
+					"--genesis-path",
+					tempGenesis.Name(),
+					"--single",
+					fmt.Sprintf("%s=10ugnot", dummyKey.Address().String()),
+				}, testCase.vestingArgs...)
+
+				// Run the command
+				cmdErr := cmd.ParseAndRun(context.Background(), args)
+				assert.ErrorIs(t, cmdErr, testCase.expectedErr)
+			})
+		}
+	})
 }
 
 func TestBalances_GetBalancesFromEntries(t *testing.T) {
@@ -85,8 +85,9 @@ func execBalancesRemove(cfg *balancesRemoveCfg, io commands.IO) error {
 	// Drop the account pre-mine
 	delete(genesisBalances, address)
 
-	// Save the balances
+	// Save the balances, and drop the account vesting
 	state.Balances = genesisBalances.toList()
+	state.Vesting = mergeVesting(state.Vesting, accountBalances{address: {}}, nil)
 	genesis.AppState = state
 
 	// Save the updated genesis
@@ -76,6 +76,13 @@ func TestGenesis_Balances_Remove(t *testing.T) {
 					Amount:  std.NewCoins(std.NewCoin("ugnot", 100)),
 				},
 			},
+			Vesting: []gnoland.Vesting{
+				{
+					Address: dummyKey.Address(),
+					Amount:  std.NewCoins(std.NewCoin("ugnot", 100)),
+					EndTime: 1000,
+				},
+			},
 		}
 		genesis.AppState = state
 		require.NoError(t, genesis.SaveAs(tempGenesis.Name()))
@@ -105,6 +112,7 @@ func TestGenesis_Balances_Remove(t *testing.T) {
 		require.True(t, ok)
 
 		assert.Len(t, state.Balances, 0)
+		assert.Len(t, state.Vesting, 0)
 	})
 
 	t.Run("address not present", func(t *testing.T) {
@@ -11,7 +11,10 @@ import (
 	"github.com/gnolang/gno/tm2/pkg/commands"
 )
 
-var errInvalidGenesisState = errors.New("invalid genesis state type")
+var (
+	errInvalidGenesisState = errors.New("invalid genesis state type")
+	errVestingNoBalance    = errors.New("vesting account has no balance")
+)
 
 type verifyCfg struct {
 	commonCfg
@@ -66,10 +69,22 @@ func execVerify(cfg *verifyCfg, io commands.IO) error {
 		}
 
 		// Validate the initial balances
+		balances := make(map[types.Address]struct{}, len(state.Balances))
 		for _, balance := range state.Balances {
 			if err := balance.Verify(); err != nil {
 				return fmt.Errorf("invalid balance: %w", err)
 			}
+			balances[balance.Address] = struct{}{}
+		}
+
+		// Validate the vesting of the initial balances
+		for _, vesting := range state.Vesting {
+			if err := vesting.Verify(); err != nil {
+				return fmt.Errorf("invalid vesting: %w", err)
+			}
+			if _, ok := balances[vesting.Address]; !ok {
+				return fmt.Errorf("%w: %s", errVestingNoBalance, vesting.Address)
+			}
 		}
 	}
 
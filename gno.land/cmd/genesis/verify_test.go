@@ -95,6 +95,42 @@ func TestGenesis_Verify(t *testing.T) {
 		require.Error(t, cmdErr)
 	})
 
+	t.Run("invalid vesting", func(t *testing.T) {
+		t.Parallel()
+
+		tempFile, cleanup := testutils.NewTestFile(t)
+		t.Cleanup(cleanup)
+
+		g := getValidTestGenesis()
+
+		// The vesting account has no balance
+		g.AppState = gnoland.GnoGenesisState{
+			Balances: []gnoland.Balance{},
+			Txs:      []std.Tx{},
+			Vesting: []gnoland.Vesting{
+				{
+					Address: mock.GenPrivKey().PubKey().Address(),
+					Amount:  std.NewCoins(std.NewCoin("ugnot", 100)),
+					EndTime: 1000,
+				},
+			},
+		}
+
+		require.NoError(t, g.SaveAs(tempFile.Name()))
+
+		// Create the command
+		cmd := newRootCmd(commands.NewTestIO())
+		args := []string{
+			"verify",
+			"--genesis-path",
+			tempFile.Name(),
+		}
+
+		// Run the command
+		cmdErr := cmd.ParseAndRun(context.Background(), args)
+		require.ErrorIs(t, cmdErr, errVestingNoBalance)
+	})
+
 	t.Run("valid genesis", func(t *testing.T) {
 		t.Parallel()
 
@@ -644,6 +644,27 @@ func TestConfig_Get_Mempool(t *testing.T) {
 				assert.Equal(t, value, fmt.Sprintf("%d", loadedCfg.Mempool.CacheSize))
 			},
 		},
+		{
+			"sequence queue size updated",
+			"mempool.sequence_queue_size",
+			func(loadedCfg *config.Config, value string) {
+				assert.Equal(t, value, fmt.Sprintf("%d", loadedCfg.Mempool.SequenceQueueSize))
+			},
+		},
+		{
+			"sequence queue TTL updated",
+			"mempool.sequence_queue_ttl",
+			func(loadedCfg *config.Config, value string) {
+				assert.Equal(t, value, fmt.Sprintf("%d", loadedCfg.Mempool.SequenceQueueTTL))
+			},
+		},
+		{
+			"type updated",
+			"mempool.type",
+			func(loadedCfg *config.Config, value string) {
+				assert.Equal(t, value, loadedCfg.Mempool.Type)
+			},
+		},
 	}
 
 	verifyGetTestTableCommon(t, testTable)
@@ -894,6 +894,36 @@ func TestConfig_Set_Mempool(t *testing.T) {
 				assert.Equal(t, value, fmt.Sprintf("%d", loadedCfg.Mempool.CacheSize))
 			},
 		},
+		{
+			"sequence queue size updated",
+			[]string{
+				"mempool.sequence_queue_size",
+				"100",
+			},
+			func(loadedCfg *config.Config, value string) {
+				assert.Equal(t, value, fmt.Sprintf("%d", loadedCfg.Mempool.SequenceQueueSize))
+			},
+		},
+		{
+			"sequence queue TTL updated",
+			[]string{
+				"mempool.sequence_queue_ttl",
+				"100",
+			},
+			func(loadedCfg *config.Config, value string) {
+				assert.Equal(t, value, fmt.Sprintf("%d", loadedCfg.Mempool.SequenceQueueTTL))
+			},
+		},
+		{
+			"type updated",
+			[]string{
+				"mempool.type",
+				"priority",
+			},
+			func(loadedCfg *config.Config, value string) {
+				assert.Equal(t, value, loadedCfg.Mempool.Type)
+			},
+		},
 	}
 
 	verifySetTestTableCommon(t, testTable)
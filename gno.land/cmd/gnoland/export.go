@@ -0,0 +1,185 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"github.com/gnolang/gno/gno.land/pkg/gnoland"
+	"github.com/gnolang/gno/tm2/pkg/bft/config"
+	sm "github.com/gnolang/gno/tm2/pkg/bft/state"
+	bft "github.com/gnolang/gno/tm2/pkg/bft/types"
+	"github.com/gnolang/gno/tm2/pkg/commands"
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+	dbm "github.com/gnolang/gno/tm2/pkg/db"
+	osm "github.com/gnolang/gno/tm2/pkg/os"
+)
+
+var errInvalidExportOutputPath = errors.New("invalid export output path provided")
+
+type exportCfg struct {
+	dataDir        string
+	height         int64
+	chainID        string
+	includeVMState bool
+	defaultCreator string
+}
+
+// newExportCmd creates the export command
+func newExportCmd(io commands.IO) *commands.Command {
+	cfg := &exportCfg{}
+
+	return commands.NewCommand(
+		commands.Metadata{
+			Name:       "export",
+			ShortUsage: "export [flags] <output-path>",
+			ShortHelp:  "exports the state of a node as a new genesis.json",
+			LongHelp: "Exports the balances, params, packages and validators of a stopped node at a given height" +
+				" as a new genesis.json, to fork the chain or to restart it after an upgrade. By default, the" +
+				" packages are redeployed by genesis transactions, which resets the state of the realms",
+		},
+		cfg,
+		func(_ context.Context, args []string) error {
+			return execExport(cfg, args, io)
+		},
+	)
+}
+
+func (c *exportCfg) RegisterFlags(fs *flag.FlagSet) {
+	fs.StringVar(
+		&c.dataDir,
+		"data-dir",
+		"testdir",
+		"the data directory of the node",
+	)
+
+	fs.Int64Var(
+		&c.height,
+		"height",
+		0,
+		"the height to export, 0 for the latest height",
+	)
+
+	fs.StringVar(
+		&c.chainID,
+		"chainid",
+		"",
+		"the ID of the new chain (defaults to the ID of the exported chain)",
+	)
+
+	fs.BoolVar(
+		&c.includeVMState,
+		"include-vm-state",
+		false,
+		"export the state of the realms as is, instead of redeploying the packages (latest height only)",
+	)
+
+	fs.StringVar(
+		&c.defaultCreator,
+		"default-creator",
+		"",
+		"the address adding the packages whose creator has no balance",
+	)
+}
+
+func execExport(c *exportCfg, args []string, io commands.IO) error {
+	// Check the output path
+	if len(args) != 1 || args[0] == "" {
+		return errInvalidExportOutputPath
+	}
+
+	// Check the data dir
+	if !osm.DirExists(c.dataDir) {
+		return errInvalidDataDir
+	}
+
+	opts := gnoland.NewExportOptions()
+	opts.Height = c.height
+	opts.IncludeVMState = c.includeVMState
+
+	if c.defaultCreator != "" {
+		creator, err := crypto.AddressFromBech32(c.defaultCreator)
+		if err != nil {
+			return fmt.Errorf("invalid default creator, %w", err)
+		}
+
+		opts.DefaultCreator = creator
+	}
+
+	// Load the node configuration and genesis
+	cfg, err := config.LoadOrMakeConfigWithOptions(c.dataDir)
+	if err != nil {
+		return fmt.Errorf("unable to load node configuration, %w", err)
+	}
+
+	genesis, err := bft.GenesisDocFromFile(filepath.Join(c.dataDir, cfg.Genesis))
+	if err != nil {
+		return fmt.Errorf("unable to load genesis, %w", err)
+	}
+
+	// Export the app state, from the same database as gnoland.NewApp
+	appDB, err := dbm.NewDB("gnolang", dbm.GoLevelDBBackend, filepath.Join(c.dataDir, "data"))
+	if err != nil {
+		return fmt.Errorf("unable to open the app database, %w", err)
+	}
+	defer appDB.Close()
+
+	opts.DB = appDB
+
+	state, height, err := gnoland.ExportGenesisState(opts)
+	if err != nil {
+		return fmt.Errorf("unable to export state, %w", err)
+	}
+
+	// Export the validators of the next height
+	stateDB, err := dbm.NewDB("state", dbm.BackendType(cfg.DBBackend), cfg.DBDir())
+	if err != nil {
+		return fmt.Errorf("unable to open the state database, %w", err)
+	}
+	defer stateDB.Close()
+
+	valSet, err := sm.LoadValidators(stateDB, height+1)
+	if err != nil {
+		return fmt.Errorf("unable to load validators, %w", err)
+	}
+
+	// Keep the names of the genesis validators
+	names := make(map[crypto.Address]string, len(genesis.Validators))
+	for _, val := range genesis.Validators {
+		names[val.Address] = val.Name
+	}
+
+	validators := make([]bft.GenesisValidator, 0, valSet.Size())
+	for _, val := range valSet.Validators {
+		validators = append(validators, bft.GenesisValidator{
+			Address: val.Address,
+			PubKey:  val.PubKey,
+			Power:   val.VotingPower,
+			Name:    names[val.Address],
+		})
+	}
+
+	// Save the new genesis
+	newGenesis := &bft.GenesisDoc{
+		GenesisTime:     time.Now(),
+		ChainID:         genesis.ChainID,
+		ConsensusParams: genesis.ConsensusParams,
+		Validators:      validators,
+		AppState:        state,
+	}
+
+	if c.chainID != "" {
+		newGenesis.ChainID = c.chainID
+	}
+
+	if err := newGenesis.SaveAs(args[0]); err != nil {
+		return fmt.Errorf("unable to save genesis, %w", err)
+	}
+
+	io.Printfln("Exported the state at height %d to %s", height, args[0])
+
+	return nil
+}
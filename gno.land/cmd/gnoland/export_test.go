@@ -0,0 +1,146 @@
+package main
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/gnolang/gno/gno.land/pkg/gnoland"
+	abci "github.com/gnolang/gno/tm2/pkg/bft/abci/types"
+	"github.com/gnolang/gno/tm2/pkg/bft/config"
+	sm "github.com/gnolang/gno/tm2/pkg/bft/state"
+	bft "github.com/gnolang/gno/tm2/pkg/bft/types"
+	"github.com/gnolang/gno/tm2/pkg/commands"
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+	"github.com/gnolang/gno/tm2/pkg/crypto/ed25519"
+	dbm "github.com/gnolang/gno/tm2/pkg/db"
+	"github.com/gnolang/gno/tm2/pkg/std"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// initExportTestNode writes the config, genesis and databases of a node
+// which committed its first block.
+func initExportTestNode(t *testing.T, dataDir string, genesis *bft.GenesisDoc) {
+	t.Helper()
+
+	cfg, err := config.LoadOrMakeConfigWithOptions(dataDir)
+	require.NoError(t, err)
+	require.NoError(t, genesis.SaveAs(filepath.Join(dataDir, cfg.Genesis)))
+
+	// Commit the first block of the app
+	appDB, err := dbm.NewDB("gnolang", dbm.GoLevelDBBackend, filepath.Join(dataDir, "data"))
+	require.NoError(t, err)
+	defer appDB.Close()
+
+	opts := gnoland.NewAppOptions()
+	opts.DB = appDB
+	app, err := gnoland.NewAppWithOptions(opts)
+	require.NoError(t, err)
+
+	app.InitChain(abci.RequestInitChain{
+		ChainID:         genesis.ChainID,
+		ConsensusParams: &genesis.ConsensusParams,
+		AppState:        genesis.AppState,
+	})
+	app.BeginBlock(abci.RequestBeginBlock{Header: &bft.Header{ChainID: genesis.ChainID, Height: 1, Time: time.Now()}})
+	app.EndBlock(abci.RequestEndBlock{})
+	app.Commit()
+
+	// Save the consensus state after the first block
+	stateDB, err := dbm.NewDB("state", dbm.BackendType(cfg.DBBackend), cfg.DBDir())
+	require.NoError(t, err)
+	defer stateDB.Close()
+
+	state, err := sm.MakeGenesisState(genesis)
+	require.NoError(t, err)
+	sm.SaveState(stateDB, state)
+	state.LastBlockHeight = 1
+	sm.SaveState(stateDB, state)
+}
+
+func TestExport_InvalidArgs(t *testing.T) {
+	t.Parallel()
+
+	testTable := []struct {
+		name        string
+		args        []string
+		expectedErr error
+	}{
+		{
+			"no output path",
+			[]string{"export", "--data-dir", t.TempDir()},
+			errInvalidExportOutputPath,
+		},
+		{
+			"missing data dir",
+			[]string{"export", "--data-dir", filepath.Join(t.TempDir(), "missing"), "genesis.json"},
+			errInvalidDataDir,
+		},
+	}
+
+	for _, testCase := range testTable {
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			cmd := newRootCmd(commands.NewTestIO())
+			assert.ErrorIs(t, cmd.ParseAndRun(context.Background(), testCase.args), testCase.expectedErr)
+		})
+	}
+}
+
+func TestExport_Genesis(t *testing.T) {
+	t.Parallel()
+
+	var (
+		dataDir    = t.TempDir()
+		outputPath = filepath.Join(t.TempDir(), "genesis.json")
+
+		pubKey  = ed25519.GenPrivKey().PubKey()
+		address = crypto.AddressFromPreimage([]byte("address"))
+	)
+
+	genesis := &bft.GenesisDoc{
+		GenesisTime: time.Now(),
+		ChainID:     "dev",
+		ConsensusParams: abci.ConsensusParams{
+			Block: &abci.BlockParams{
+				MaxTxBytes:   1_000_000,
+				MaxDataBytes: 2_000_000,
+				MaxGas:       100_000_000,
+				TimeIotaMS:   100,
+			},
+		},
+		Validators: []bft.GenesisValidator{
+			{Address: pubKey.Address(), PubKey: pubKey, Power: 10, Name: "validator"},
+		},
+		AppState: gnoland.GnoGenesisState{
+			Balances: []gnoland.Balance{{Address: address, Amount: std.MustParseCoins("100ugnot")}},
+			Txs:      []std.Tx{},
+		},
+	}
+	initExportTestNode(t, dataDir, genesis)
+
+	args := []string{
+		"export",
+		"--data-dir", dataDir,
+		"--chainid", "fork",
+		outputPath,
+	}
+
+	cmd := newRootCmd(commands.NewTestIO())
+	require.NoError(t, cmd.ParseAndRun(context.Background(), args))
+
+	exported, err := bft.GenesisDocFromFile(outputPath)
+	require.NoError(t, err)
+
+	assert.Equal(t, "fork", exported.ChainID)
+	assert.Equal(t, genesis.ConsensusParams, exported.ConsensusParams)
+	assert.Equal(t, genesis.Validators, exported.Validators)
+
+	state := exported.AppState.(gnoland.GnoGenesisState)
+	assert.Equal(t, genesis.AppState.(gnoland.GnoGenesisState).Balances, state.Balances)
+}
@@ -35,6 +35,7 @@ func newRootCmd(io commands.IO) *commands.Command {
 		newStartCmd(io),
 		newSecretsCmd(io),
 		newConfigCmd(io),
+		newExportCmd(io),
 	)
 
 	return cmd
@@ -19,6 +19,7 @@ import (
 	"github.com/gnolang/gno/tm2/pkg/bft/node"
 	"github.com/gnolang/gno/tm2/pkg/bft/privval"
 	"github.com/gnolang/gno/tm2/pkg/bft/state/eventstore/file"
+	"github.com/gnolang/gno/tm2/pkg/bft/state/eventstore/kv"
 	"github.com/gnolang/gno/tm2/pkg/bft/state/eventstore/null"
 	eventstorecfg "github.com/gnolang/gno/tm2/pkg/bft/state/eventstore/types"
 	bft "github.com/gnolang/gno/tm2/pkg/bft/types"
@@ -167,6 +168,7 @@ func (c *startCfg) RegisterFlags(fs *flag.FlagSet) {
 				[]string{
 					null.EventStoreType,
 					file.EventStoreType,
+					kv.EventStoreType,
 				},
 				", ",
 			),
@@ -271,7 +273,9 @@ func execStart(c *startCfg, io commands.IO) error {
 	cfg.TxEventStore = txEventStoreCfg
 
 	// Create application and node.
-	gnoApp, err := gnoland.NewApp(dataDir, c.skipFailingGenesisTxs, logger, c.genesisMaxVMCycles)
+	gnoApp, err := gnoland.NewApp(
+		dataDir, c.skipFailingGenesisTxs, logger, c.genesisMaxVMCycles, cfg.Mempool.SequenceQueueSize > 0,
+	)
 	if err != nil {
 		return fmt.Errorf("error in creating new app: %w", err)
 	}
@@ -385,6 +389,12 @@ func getTxEventStoreConfig(c *startCfg) (*eventstorecfg.Config, error) {
 				file.Path: c.txEventStorePath,
 			},
 		}
+	case kv.EventStoreType:
+		// The kv event store is backed by the node's database
+		cfg = &eventstorecfg.Config{
+			EventStoreType: kv.EventStoreType,
+			Params:         make(eventstorecfg.EventStoreParams),
+		}
 	default:
 		cfg = eventstorecfg.DefaultEventStoreConfig()
 	}
@@ -0,0 +1,77 @@
+package gnoclient
+
+import (
+	"context"
+
+	rpcclient "github.com/gnolang/gno/tm2/pkg/bft/rpc/client"
+	ctypes "github.com/gnolang/gno/tm2/pkg/bft/rpc/core/types"
+	"github.com/gnolang/gno/tm2/pkg/bft/state/eventstore/query"
+	"github.com/gnolang/gno/tm2/pkg/errors"
+)
+
+var ErrEventsNotSupported = errors.New("RPCClient does not support event subscriptions")
+
+// Subscribe subscribes to the node events matching the given query,
+// ex. "tm.event = 'Tx' AND tx.signer = 'g1...'".
+// The RPCClient must be a rpcclient.EventsClient, like rpcclient.HTTP
+func (c *Client) Subscribe(ctx context.Context, query string) (<-chan ctypes.ResultEvent, error) {
+	ec, err := c.eventsClient()
+	if err != nil {
+		return nil, err
+	}
+
+	return ec.Subscribe(ctx, query)
+}
+
+// SubscribeRealmEvents subscribes to the transactions containing events
+// of the given type, emitted with std.Emit by the realm at pkgPath
+func (c *Client) SubscribeRealmEvents(ctx context.Context, pkgPath, eventType string) (<-chan ctypes.ResultEvent, error) {
+	return c.Subscribe(ctx, RealmEventsQuery(pkgPath, eventType))
+}
+
+// Unsubscribe cancels the subscription with the given query
+func (c *Client) Unsubscribe(ctx context.Context, query string) error {
+	ec, err := c.eventsClient()
+	if err != nil {
+		return err
+	}
+
+	return ec.Unsubscribe(ctx, query)
+}
+
+// UnsubscribeAll cancels all the active subscriptions
+func (c *Client) UnsubscribeAll(ctx context.Context) error {
+	ec, err := c.eventsClient()
+	if err != nil {
+		return err
+	}
+
+	return ec.UnsubscribeAll(ctx)
+}
+
+// RealmEventsQuery returns the subscription query matching the transactions
+// containing events of the given type, emitted by the realm at pkgPath
+func RealmEventsQuery(pkgPath, eventType string) string {
+	// the key and the operand are escaped by the condition
+	realm := query.Condition{
+		Key:     eventType + ".pkg_path",
+		Op:      query.OpEqual,
+		Operand: pkgPath,
+	}
+
+	return "tm.event = 'Tx' AND " + realm.String()
+}
+
+// eventsClient returns the RPCClient as an events client, if supported
+func (c *Client) eventsClient() (rpcclient.EventsClient, error) {
+	if err := c.validateRPCClient(); err != nil {
+		return nil, err
+	}
+
+	ec, ok := c.RPCClient.(rpcclient.EventsClient)
+	if !ok {
+		return nil, ErrEventsNotSupported
+	}
+
+	return ec, nil
+}
@@ -0,0 +1,81 @@
+package gnoclient
+
+import (
+	"context"
+	"testing"
+
+	ctypes "github.com/gnolang/gno/tm2/pkg/bft/rpc/core/types"
+	"github.com/gnolang/gno/tm2/pkg/bft/state/eventstore/query"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSubscribe(t *testing.T) {
+	t.Parallel()
+
+	t.Run("missing RPC client", func(t *testing.T) {
+		t.Parallel()
+
+		client := Client{}
+
+		_, err := client.Subscribe(context.Background(), "tm.event = 'Tx'")
+		assert.ErrorIs(t, err, ErrMissingRPCClient)
+	})
+
+	t.Run("events not supported", func(t *testing.T) {
+		t.Parallel()
+
+		client := Client{
+			RPCClient: &mockRPCClient{},
+		}
+
+		_, err := client.Subscribe(context.Background(), "tm.event = 'Tx'")
+		assert.ErrorIs(t, err, ErrEventsNotSupported)
+
+		assert.ErrorIs(t, client.Unsubscribe(context.Background(), "tm.event = 'Tx'"), ErrEventsNotSupported)
+		assert.ErrorIs(t, client.UnsubscribeAll(context.Background()), ErrEventsNotSupported)
+	})
+
+	t.Run("realm events", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			expectedQuery = "tm.event = 'Tx' AND Transfer.pkg_path = 'gno.land/r/demo/foo20'"
+			events        = make(chan ctypes.ResultEvent)
+			unsubscribed  string
+		)
+
+		client := Client{
+			RPCClient: &mockEventsRPCClient{
+				subscribe: func(_ context.Context, query string) (<-chan ctypes.ResultEvent, error) {
+					assert.Equal(t, expectedQuery, query)
+
+					return events, nil
+				},
+				unsubscribe: func(_ context.Context, query string) error {
+					unsubscribed = query
+
+					return nil
+				},
+			},
+		}
+
+		ch, err := client.SubscribeRealmEvents(context.Background(), "gno.land/r/demo/foo20", "Transfer")
+		require.NoError(t, err)
+		assert.Equal(t, (<-chan ctypes.ResultEvent)(events), ch)
+
+		require.NoError(t, client.Unsubscribe(context.Background(), expectedQuery))
+		assert.Equal(t, expectedQuery, unsubscribed)
+	})
+}
+
+func TestRealmEventsQuery(t *testing.T) {
+	t.Parallel()
+
+	q, err := query.New(RealmEventsQuery("gno.land/r/demo/x' AND tx.height = '1", "My Event"))
+	require.NoError(t, err)
+
+	require.Len(t, q.Conditions(), 2)
+	assert.Equal(t, "My Event.pkg_path", q.Conditions()[1].Key)
+	assert.Equal(t, "gno.land/r/demo/x' AND tx.height = '1", q.Conditions()[1].Operand)
+}
@@ -1,13 +1,16 @@
 package gnoclient
 
 import (
+	"encoding/json"
 	"fmt"
 
+	gno "github.com/gnolang/gno/gnovm/pkg/gnolang"
 	"github.com/gnolang/gno/tm2/pkg/amino"
 	rpcclient "github.com/gnolang/gno/tm2/pkg/bft/rpc/client"
 	ctypes "github.com/gnolang/gno/tm2/pkg/bft/rpc/core/types"
 	"github.com/gnolang/gno/tm2/pkg/crypto"
 	"github.com/gnolang/gno/tm2/pkg/errors"
+	"github.com/gnolang/gno/tm2/pkg/sdk/auth"
 	"github.com/gnolang/gno/tm2/pkg/std"
 )
 
@@ -79,6 +82,35 @@ func (c *Client) QueryAppVersion() (string, *ctypes.ResultABCIQuery, error) {
 	return version, qres, nil
 }
 
+// QueryMinGasPrices retrieves the min gas prices set on chain, if any.
+func (c *Client) QueryMinGasPrices() ([]std.GasPrice, *ctypes.ResultABCIQuery, error) {
+	if err := c.validateRPCClient(); err != nil {
+		return nil, nil, err
+	}
+
+	path := "params/" + auth.MinGasPricesKey
+	data := []byte{}
+
+	qres, err := c.RPCClient.ABCIQuery(path, data)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "query min gas prices")
+	}
+	if qres.Response.Error != nil {
+		return nil, nil, errors.Wrap(qres.Response.Error, "QueryMinGasPrices failed: log:%s", qres.Response.Log)
+	}
+	if len(qres.Response.Data) == 0 {
+		// not set on chain.
+		return nil, qres, nil
+	}
+
+	var gasPrices []std.GasPrice
+	if err := amino.UnmarshalJSON(qres.Response.Data, &gasPrices); err != nil {
+		return nil, nil, err
+	}
+
+	return gasPrices, qres, nil
+}
+
 // Render calls the Render function for pkgPath with optional args. The pkgPath should
 // include the prefix like "gno.land/". This is similar to using a browser URL
 // <testnet>/<pkgPath>:<args> where <pkgPath> doesn't have the prefix like "gno.land/".
@@ -123,3 +155,30 @@ func (c *Client) QEval(pkgPath string, expression string) (string, *ctypes.Resul
 
 	return string(qres.Response.Data), qres, nil
 }
+
+// QEvalJSON evaluates the given expression with the realm code at pkgPath, like QEval.
+// The return values are decoded from their JSON representation (see gno.JSONTypedValue),
+// for instance [{"T":"gno.land/r/demo/boards.BoardID","V":"1"},{"T":"bool","V":true}].
+func (c *Client) QEvalJSON(pkgPath string, expression string) ([]gno.JSONTypedValue, *ctypes.ResultABCIQuery, error) {
+	if err := c.validateRPCClient(); err != nil {
+		return nil, nil, err
+	}
+
+	path := "vm/qevaljson"
+	data := []byte(fmt.Sprintf("%s\n%s", pkgPath, expression))
+
+	qres, err := c.RPCClient.ABCIQuery(path, data)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "query qevaljson")
+	}
+	if qres.Response.Error != nil {
+		return nil, nil, errors.Wrap(qres.Response.Error, "QEvalJSON failed: log:%s", qres.Response.Log)
+	}
+
+	var results []gno.JSONTypedValue
+	if err := json.Unmarshal(qres.Response.Data, &results); err != nil {
+		return nil, nil, errors.Wrap(err, "unmarshaling qevaljson result")
+	}
+
+	return results, qres, nil
+}
@@ -6,6 +6,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/gnolang/gno/tm2/pkg/amino"
 	abci "github.com/gnolang/gno/tm2/pkg/bft/abci/types"
 	ctypes "github.com/gnolang/gno/tm2/pkg/bft/rpc/core/types"
 	"github.com/gnolang/gno/tm2/pkg/bft/types"
@@ -54,6 +55,39 @@ func TestRender(t *testing.T) {
 	assert.Equal(t, data.Response.Data, expectedRender)
 }
 
+func TestQEvalJSON(t *testing.T) {
+	t.Parallel()
+	testRealmPath := "gno.land/r/demo/boards"
+
+	client := Client{
+		Signer: &mockSigner{},
+		RPCClient: &mockRPCClient{
+			abciQuery: func(path string, data []byte) (*ctypes.ResultABCIQuery, error) {
+				assert.Equal(t, "vm/qevaljson", path)
+				assert.Equal(t, testRealmPath+"\nGetBoardIDFromName(\"testboard\")", string(data))
+
+				res := &ctypes.ResultABCIQuery{
+					Response: abci.ResponseQuery{
+						ResponseBase: abci.ResponseBase{
+							Data: []byte(`[{"T":"gno.land/r/demo/boards.BoardID","V":"1"},{"T":"bool","V":true}]`),
+						},
+					},
+				}
+				return res, nil
+			},
+		},
+	}
+
+	res, data, err := client.QEvalJSON(testRealmPath, `GetBoardIDFromName("testboard")`)
+	require.NoError(t, err)
+	assert.NotEmpty(t, data.Response.Data)
+	require.Len(t, res, 2)
+	assert.Equal(t, "gno.land/r/demo/boards.BoardID", res[0].T)
+	assert.Equal(t, "1", res[0].V)
+	assert.Equal(t, "bool", res[1].T)
+	assert.Equal(t, true, res[1].V)
+}
+
 // Call tests
 func TestCallSingle(t *testing.T) {
 	t.Parallel()
@@ -109,6 +143,65 @@ func TestCallSingle(t *testing.T) {
 	assert.Equal(t, string(res.DeliverTx.Data), "it works!")
 }
 
+func TestCallAutoGas(t *testing.T) {
+	t.Parallel()
+
+	var broadcastFee std.Fee
+	client := Client{
+		Signer: &mockSigner{
+			sign: func(cfg SignCfg) (*std.Tx, error) {
+				broadcastFee = cfg.UnsignedTX.Fee
+				return &cfg.UnsignedTX, nil
+			},
+			info: func() keys.Info {
+				return &mockKeysInfo{
+					getAddress: func() crypto.Address {
+						adr, _ := crypto.AddressFromBech32("g1jg8mtutu9khhfwc4nxmuhcpftf0pajdhfvsqf5")
+						return adr
+					},
+				}
+			},
+		},
+		RPCClient: &mockRPCClient{
+			abciQuery: func(path string, data []byte) (*ctypes.ResultABCIQuery, error) {
+				res := &ctypes.ResultABCIQuery{}
+				switch path {
+				case "params/auth.min_gas_prices":
+					res.Response.Data = []byte(`[{"gas":"1000","price":"1ugnot"}]`)
+				case ".app/simulate":
+					var tx std.Tx
+					require.NoError(t, amino.Unmarshal(data, &tx))
+					assert.Len(t, tx.Signatures, 1)
+					res.Response.Value = amino.MustMarshal(abci.ResponseDeliverTx{GasUsed: 100000})
+				default:
+					t.Errorf("unexpected query %q", path)
+				}
+				return res, nil
+			},
+			broadcastTxCommit: func(tx types.Tx) (*ctypes.ResultBroadcastTxCommit, error) {
+				return &ctypes.ResultBroadcastTxCommit{}, nil
+			},
+		},
+	}
+
+	cfg := BaseTxCfg{
+		GasAuto:        true,
+		AccountNumber:  1,
+		SequenceNumber: 1,
+	}
+
+	msg := MsgCall{
+		PkgPath:  "gno.land/r/demo/deep/very/deep",
+		FuncName: "Render",
+		Args:     []string{""},
+	}
+
+	_, err := client.Call(cfg, msg)
+	require.NoError(t, err)
+	// 100000 gas used * 1.3, at 1ugnot/1000gas.
+	assert.Equal(t, std.NewFee(130000, std.NewCoin("ugnot", 130)), broadcastFee)
+}
+
 func TestCallMultiple(t *testing.T) {
 	t.Parallel()
 
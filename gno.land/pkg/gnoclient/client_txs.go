@@ -6,6 +6,7 @@ import (
 	"github.com/gnolang/gno/tm2/pkg/amino"
 	ctypes "github.com/gnolang/gno/tm2/pkg/bft/rpc/core/types"
 	"github.com/gnolang/gno/tm2/pkg/crypto"
+	keysclient "github.com/gnolang/gno/tm2/pkg/crypto/keys/client"
 	"github.com/gnolang/gno/tm2/pkg/errors"
 	"github.com/gnolang/gno/tm2/pkg/sdk/bank"
 	"github.com/gnolang/gno/tm2/pkg/std"
@@ -25,19 +26,23 @@ var (
 
 // BaseTxCfg defines the base transaction configuration, shared by all message types
 type BaseTxCfg struct {
-	GasFee         string // Gas fee
-	GasWanted      int64  // Gas wanted
-	AccountNumber  uint64 // Account number
-	SequenceNumber uint64 // Sequence number
-	Memo           string // Memo
+	GasFee         string  // Gas fee, derived from the min gas prices if empty and GasAuto
+	GasWanted      int64   // Gas wanted, ignored if GasAuto
+	GasAuto        bool    // Estimate the gas wanted by simulating the transaction
+	GasAdjustment  float64 // Multiplier of the simulated gas used if GasAuto, or keysclient.DefaultGasAdjustment
+	AccountNumber  uint64  // Account number
+	SequenceNumber uint64  // Sequence number
+	Memo           string  // Memo
 }
 
 // MsgCall - syntax sugar for vm.MsgCall
 type MsgCall struct {
-	PkgPath  string   // Package path
-	FuncName string   // Function name
-	Args     []string // Function arguments
-	Send     string   // Send amount
+	PkgPath      string   // Package path
+	FuncName     string   // Function name
+	Args         []string // Function arguments
+	ArgsFormat   string   // Function arguments format (vm.ArgsFormatString or vm.ArgsFormatJSON)
+	ResultFormat string   // Function results format (vm.ResultFormatString or vm.ResultFormatJSON)
+	Send         string   // Send amount
 }
 
 // MsgSend - syntax sugar for bank.MsgSend
@@ -89,28 +94,30 @@ func (c *Client) Call(cfg BaseTxCfg, msgs ...MsgCall) (*ctypes.ResultBroadcastTx
 
 		// Unwrap syntax sugar to vm.MsgCall slice
 		vmMsgs = append(vmMsgs, std.Msg(vm.MsgCall{
-			Caller:  c.Signer.Info().GetAddress(),
-			PkgPath: msg.PkgPath,
-			Func:    msg.FuncName,
-			Args:    msg.Args,
-			Send:    send,
+			Caller:       c.Signer.Info().GetAddress(),
+			PkgPath:      msg.PkgPath,
+			Func:         msg.FuncName,
+			Args:         msg.Args,
+			ArgsFormat:   msg.ArgsFormat,
+			ResultFormat: msg.ResultFormat,
+			Send:         send,
 		}))
 	}
 
-	// Parse gas fee
-	gasFeeCoins, err := std.ParseCoin(cfg.GasFee)
-	if err != nil {
-		return nil, err
-	}
-
 	// Pack transaction
 	tx := std.Tx{
 		Msgs:       vmMsgs,
-		Fee:        std.NewFee(cfg.GasWanted, gasFeeCoins),
 		Signatures: nil,
 		Memo:       cfg.Memo,
 	}
 
+	// Parse or estimate gas fee
+	fee, err := c.parseFee(cfg, tx)
+	if err != nil {
+		return nil, err
+	}
+	tx.Fee = fee
+
 	return c.signAndBroadcastTxCommit(tx, cfg.AccountNumber, cfg.SequenceNumber)
 }
 
@@ -161,20 +168,20 @@ func (c *Client) Run(cfg BaseTxCfg, msgs ...MsgRun) (*ctypes.ResultBroadcastTxCo
 		}))
 	}
 
-	// Parse gas fee
-	gasFeeCoins, err := std.ParseCoin(cfg.GasFee)
-	if err != nil {
-		return nil, err
-	}
-
 	// Pack transaction
 	tx := std.Tx{
 		Msgs:       vmMsgs,
-		Fee:        std.NewFee(cfg.GasWanted, gasFeeCoins),
 		Signatures: nil,
 		Memo:       cfg.Memo,
 	}
 
+	// Parse or estimate gas fee
+	fee, err := c.parseFee(cfg, tx)
+	if err != nil {
+		return nil, err
+	}
+	tx.Fee = fee
+
 	return c.signAndBroadcastTxCommit(tx, cfg.AccountNumber, cfg.SequenceNumber)
 }
 
@@ -215,20 +222,20 @@ func (c *Client) Send(cfg BaseTxCfg, msgs ...MsgSend) (*ctypes.ResultBroadcastTx
 		}))
 	}
 
-	// Parse gas fee
-	gasFeeCoins, err := std.ParseCoin(cfg.GasFee)
-	if err != nil {
-		return nil, err
-	}
-
 	// Pack transaction
 	tx := std.Tx{
 		Msgs:       vmMsgs,
-		Fee:        std.NewFee(cfg.GasWanted, gasFeeCoins),
 		Signatures: nil,
 		Memo:       cfg.Memo,
 	}
 
+	// Parse or estimate gas fee
+	fee, err := c.parseFee(cfg, tx)
+	if err != nil {
+		return nil, err
+	}
+	tx.Fee = fee
+
 	return c.signAndBroadcastTxCommit(tx, cfg.AccountNumber, cfg.SequenceNumber)
 }
 
@@ -276,23 +283,67 @@ func (c *Client) AddPackage(cfg BaseTxCfg, msgs ...MsgAddPackage) (*ctypes.Resul
 		}))
 	}
 
-	// Parse gas fee
-	gasFeeCoins, err := std.ParseCoin(cfg.GasFee)
-	if err != nil {
-		return nil, err
-	}
-
 	// Pack transaction
 	tx := std.Tx{
 		Msgs:       vmMsgs,
-		Fee:        std.NewFee(cfg.GasWanted, gasFeeCoins),
 		Signatures: nil,
 		Memo:       cfg.Memo,
 	}
 
+	// Parse or estimate gas fee
+	fee, err := c.parseFee(cfg, tx)
+	if err != nil {
+		return nil, err
+	}
+	tx.Fee = fee
+
 	return c.signAndBroadcastTxCommit(tx, cfg.AccountNumber, cfg.SequenceNumber)
 }
 
+// parseFee returns the fee of tx from cfg, estimating the gas wanted and the
+// gas fee if requested.
+func (c *Client) parseFee(cfg BaseTxCfg, tx std.Tx) (std.Fee, error) {
+	var gasFee std.Coin
+	if cfg.GasFee != "" {
+		var err error
+		if gasFee, err = std.ParseCoin(cfg.GasFee); err != nil {
+			return std.Fee{}, err
+		}
+	}
+	if !cfg.GasAuto {
+		return std.NewFee(cfg.GasWanted, gasFee), nil
+	}
+
+	gasPrices, _, err := c.QueryMinGasPrices()
+	if err != nil {
+		return std.Fee{}, err
+	}
+
+	tx.Fee = std.NewFee(0, gasFee)
+	_, fee, err := c.estimateFee(tx, gasPrices, cfg.GasAdjustment)
+	return fee, err
+}
+
+// EstimateGas simulates the unsigned transaction tx, signed by the Signer, and
+// returns the gas it used.
+func (c *Client) EstimateGas(tx std.Tx) (int64, error) {
+	gasUsed, _, err := c.estimateFee(tx, nil, 0)
+	return gasUsed, err
+}
+
+// estimateFee simulates tx signed by the Signer, see keysclient.EstimateFee.
+func (c *Client) estimateFee(tx std.Tx, gasPrices []std.GasPrice, adjustment float64) (int64, std.Fee, error) {
+	// Validate required client fields.
+	if err := c.validateSigner(); err != nil {
+		return 0, std.Fee{}, err
+	}
+	if err := c.validateRPCClient(); err != nil {
+		return 0, std.Fee{}, err
+	}
+
+	return keysclient.EstimateFee(c.RPCClient, tx, c.Signer.Info(), gasPrices, adjustment)
+}
+
 // signAndBroadcastTxCommit signs a transaction and broadcasts it, returning the result
 func (c *Client) signAndBroadcastTxCommit(tx std.Tx, accountNumber, sequenceNumber uint64) (*ctypes.ResultBroadcastTxCommit, error) {
 	caller := c.Signer.Info().GetAddress()
@@ -8,6 +8,7 @@ import (
 	"github.com/gnolang/gno/tm2/pkg/std"
 
 	"github.com/gnolang/gno/gno.land/pkg/integration"
+	"github.com/gnolang/gno/gno.land/pkg/sdk/vm"
 	"github.com/gnolang/gno/gnovm/pkg/gnoenv"
 	rpcclient "github.com/gnolang/gno/tm2/pkg/bft/rpc/client"
 	"github.com/gnolang/gno/tm2/pkg/crypto"
@@ -60,6 +61,62 @@ func TestCallSingle_Integration(t *testing.T) {
 	assert.Equal(t, expected, got)
 }
 
+func TestCallAutoGas_Integration(t *testing.T) {
+	// Set up in-memory node
+	config, _ := integration.TestingNodeConfig(t, gnoenv.RootDir())
+	node, remoteAddr := integration.TestingInMemoryNode(t, log.NewNoopLogger(), config)
+	defer node.Stop()
+
+	// Init Signer & RPCClient
+	signer := newInMemorySigner(t, "tendermint_test")
+	rpcClient := rpcclient.NewHTTP(remoteAddr, "/websocket")
+
+	// Setup Client
+	client := Client{
+		Signer:    signer,
+		RPCClient: rpcClient,
+	}
+
+	// Make Tx config, estimating the gas wanted
+	baseCfg := BaseTxCfg{
+		GasFee:  "10000ugnot",
+		GasAuto: true,
+	}
+
+	// Make Msg config
+	msg := MsgCall{
+		PkgPath:  "gno.land/r/demo/deep/very/deep",
+		FuncName: "Render",
+		Args:     []string{"test argument"},
+		Send:     "",
+	}
+
+	// Estimate the gas of the call
+	tx := std.Tx{
+		Msgs: []std.Msg{vm.MsgCall{
+			Caller:  signer.Info().GetAddress(),
+			PkgPath: msg.PkgPath,
+			Func:    msg.FuncName,
+			Args:    msg.Args,
+		}},
+		Fee: std.NewFee(0, std.MustParseCoin("10000ugnot")),
+	}
+	gasUsed, err := client.EstimateGas(tx)
+	require.NoError(t, err)
+	assert.Greater(t, gasUsed, int64(0))
+
+	// Execute call
+	res, err := client.Call(baseCfg, msg)
+	require.NoError(t, err)
+
+	expected := "(\"hi test argument\" string)"
+	got := string(res.DeliverTx.Data)
+
+	assert.Equal(t, expected, got)
+	assert.GreaterOrEqual(t, res.DeliverTx.GasWanted, gasUsed)
+	assert.LessOrEqual(t, res.DeliverTx.GasUsed, res.DeliverTx.GasWanted)
+}
+
 func TestCallMultiple_Integration(t *testing.T) {
 	// Set up in-memory node
 	config, _ := integration.TestingNodeConfig(t, gnoenv.RootDir())
@@ -385,7 +442,7 @@ func Echo(str string) string {
 }`
 
 	fileName := "echo.gno"
-	deploymentPath := "gno.land/p/demo/integration/test/echo"
+	deploymentPath := "gno.land/p/test1/integration/test/echo"
 	deposit := "100ugnot"
 
 	// Make Msg config
@@ -447,7 +504,7 @@ func TestAddPackageMultiple_Integration(t *testing.T) {
 	}
 
 	deposit := "100ugnot"
-	deploymentPath1 := "gno.land/p/demo/integration/test/echo"
+	deploymentPath1 := "gno.land/p/test1/integration/test/echo"
 
 	body1 := `package echo
 
@@ -455,7 +512,7 @@ func Echo(str string) string {
 	return str
 }`
 
-	deploymentPath2 := "gno.land/p/demo/integration/test/hello"
+	deploymentPath2 := "gno.land/p/test1/integration/test/hello"
 	body2 := `package hello
 
 func Hello(str string) string {
@@ -483,7 +540,7 @@ func Hello(str string) string {
 			Files: []*std.MemFile{
 				{
 					Name: "gno.mod",
-					Body: "module gno.land/p/demo/integration/test/hello",
+					Body: "module gno.land/p/test1/integration/test/hello",
 				},
 				{
 					Name: "hello.gno",
@@ -1,6 +1,8 @@
 package gnoclient
 
 import (
+	"context"
+
 	"github.com/gnolang/gno/tm2/pkg/bft/rpc/client"
 	ctypes "github.com/gnolang/gno/tm2/pkg/bft/rpc/core/types"
 	"github.com/gnolang/gno/tm2/pkg/bft/types"
@@ -115,6 +117,8 @@ type (
 	mockBlockResults         func(height *int64) (*ctypes.ResultBlockResults, error)
 	mockCommit               func(height *int64) (*ctypes.ResultCommit, error)
 	mockValidators           func(height *int64) (*ctypes.ResultValidators, error)
+	mockTx                   func(hash []byte, prove bool) (*ctypes.ResultTx, error)
+	mockTxSearch             func(query string, prove bool, page, perPage int) (*ctypes.ResultTxSearch, error)
 	mockStatus               func() (*ctypes.ResultStatus, error)
 	mockUnconfirmedTxs       func(limit int) (*ctypes.ResultUnconfirmedTxs, error)
 	mockNumUnconfirmedTxs    func() (*ctypes.ResultUnconfirmedTxs, error)
@@ -138,6 +142,8 @@ type mockRPCClient struct {
 	blockResults         mockBlockResults
 	commit               mockCommit
 	validators           mockValidators
+	tx                   mockTx
+	txSearch             mockTxSearch
 	status               mockStatus
 	unconfirmedTxs       mockUnconfirmedTxs
 	numUnconfirmedTxs    mockNumUnconfirmedTxs
@@ -262,6 +268,20 @@ func (m *mockRPCClient) Validators(height *int64) (*ctypes.ResultValidators, err
 	return nil, nil
 }
 
+func (m *mockRPCClient) Tx(hash []byte, prove bool) (*ctypes.ResultTx, error) {
+	if m.tx != nil {
+		return m.tx(hash, prove)
+	}
+	return nil, nil
+}
+
+func (m *mockRPCClient) TxSearch(query string, prove bool, page, perPage int) (*ctypes.ResultTxSearch, error) {
+	if m.txSearch != nil {
+		return m.txSearch(query, prove, page, perPage)
+	}
+	return nil, nil
+}
+
 func (m *mockRPCClient) Status() (*ctypes.ResultStatus, error) {
 	if m.status != nil {
 		return m.status()
@@ -282,3 +302,39 @@ func (m *mockRPCClient) NumUnconfirmedTxs() (*ctypes.ResultUnconfirmedTxs, error
 	}
 	return nil, nil
 }
+
+// Events RPC Client mock
+type (
+	mockSubscribe      func(ctx context.Context, query string) (<-chan ctypes.ResultEvent, error)
+	mockUnsubscribe    func(ctx context.Context, query string) error
+	mockUnsubscribeAll func(ctx context.Context) error
+)
+
+type mockEventsRPCClient struct {
+	mockRPCClient
+
+	subscribe      mockSubscribe
+	unsubscribe    mockUnsubscribe
+	unsubscribeAll mockUnsubscribeAll
+}
+
+func (m *mockEventsRPCClient) Subscribe(ctx context.Context, query string) (<-chan ctypes.ResultEvent, error) {
+	if m.subscribe != nil {
+		return m.subscribe(ctx, query)
+	}
+	return nil, nil
+}
+
+func (m *mockEventsRPCClient) Unsubscribe(ctx context.Context, query string) error {
+	if m.unsubscribe != nil {
+		return m.unsubscribe(ctx, query)
+	}
+	return nil
+}
+
+func (m *mockEventsRPCClient) UnsubscribeAll(ctx context.Context) error {
+	if m.unsubscribeAll != nil {
+		return m.unsubscribeAll(ctx)
+	}
+	return nil
+}
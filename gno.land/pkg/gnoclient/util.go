@@ -3,6 +3,10 @@ package gnoclient
 import "github.com/gnolang/gno/tm2/pkg/std"
 
 func (cfg BaseTxCfg) validateBaseTxConfig() error {
+	if cfg.GasAuto {
+		// gas wanted and gas fee are estimated.
+		return nil
+	}
 	if cfg.GasWanted <= 0 {
 		return ErrInvalidGasWanted
 	}
@@ -1,18 +1,25 @@
 package gnoland
 
 import (
+	"errors"
 	"fmt"
 	"log/slog"
 	"path/filepath"
+	"strconv"
+	"strings"
 
 	"github.com/gnolang/gno/gno.land/pkg/sdk/vm"
 	"github.com/gnolang/gno/gnovm/pkg/gnoenv"
+	"github.com/gnolang/gno/tm2/pkg/amino"
 	abci "github.com/gnolang/gno/tm2/pkg/bft/abci/types"
+	"github.com/gnolang/gno/tm2/pkg/crypto"
 	dbm "github.com/gnolang/gno/tm2/pkg/db"
 	"github.com/gnolang/gno/tm2/pkg/log"
 	"github.com/gnolang/gno/tm2/pkg/sdk"
 	"github.com/gnolang/gno/tm2/pkg/sdk/auth"
 	"github.com/gnolang/gno/tm2/pkg/sdk/bank"
+	"github.com/gnolang/gno/tm2/pkg/sdk/distribution"
+	"github.com/gnolang/gno/tm2/pkg/sdk/params"
 	"github.com/gnolang/gno/tm2/pkg/std"
 	"github.com/gnolang/gno/tm2/pkg/store"
 	"github.com/gnolang/gno/tm2/pkg/store/dbadapter"
@@ -31,6 +38,9 @@ type AppOptions struct {
 	GenesisTxHandler GenesisTxHandler
 	Logger           *slog.Logger
 	MaxCycles        int64
+	// QueueFutureSequences lets the mempool queue the txs signed ahead of
+	// their account sequence, see auth.AnteOptions.
+	QueueFutureSequences bool
 }
 
 func NewAppOptions() *AppOptions {
@@ -75,17 +85,22 @@ func NewAppWithOptions(cfg *AppOptions) (abci.Application, error) {
 	// Construct keepers.
 	acctKpr := auth.NewAccountKeeper(mainKey, ProtoGnoAccount)
 	bankKpr := bank.NewBankKeeper(acctKpr)
+	prmKpr := newParamsKeeper(mainKey)
+	distrKpr := distribution.NewDistributionKeeper(mainKey, bankKpr, prmKpr)
+	vals := validatorSet{key: mainKey}
 
 	// XXX: Embed this ?
 	stdlibsDir := filepath.Join(cfg.GnoRootDir, "gnovm", "stdlibs")
-	vmKpr := vm.NewVMKeeper(baseKey, mainKey, acctKpr, bankKpr, stdlibsDir, cfg.MaxCycles)
+	// cfg.MaxCycles is the default, unless set in the vm params.
+	vmKpr := vm.NewVMKeeper(baseKey, mainKey, acctKpr, bankKpr, prmKpr, stdlibsDir, cfg.MaxCycles)
 
 	// Set InitChainer
-	baseApp.SetInitChainer(InitChainer(baseApp, acctKpr, bankKpr, cfg.GenesisTxHandler))
+	baseApp.SetInitChainer(InitChainer(baseApp, acctKpr, bankKpr, prmKpr, vmKpr, vals, cfg.GenesisTxHandler))
 
 	// Set AnteHandler
 	authOptions := auth.AnteOptions{
 		VerifyGenesisSignatures: false, // for development
+		QueueFutureSequences:    cfg.QueueFutureSequences,
 	}
 	authAnteHandler := auth.NewAnteHandler(
 		acctKpr, bankKpr, auth.DefaultSigVerificationGasConsumer, authOptions)
@@ -94,21 +109,35 @@ func NewAppWithOptions(cfg *AppOptions) (abci.Application, error) {
 		func(ctx sdk.Context, tx std.Tx, simulate bool) (
 			newCtx sdk.Context, res sdk.Result, abort bool,
 		) {
-			// Override auth params.
+			// Override auth params with the ones set on chain, if any.
+			authParams := auth.DefaultParams()
+			prmKpr.GetParam(ctx, auth.ParamsKey, &authParams)
 			ctx = ctx.WithValue(
-				auth.AuthParamsContextKey{}, auth.DefaultParams())
+				auth.AuthParamsContextKey{}, authParams)
+			// Chain-wide min gas prices take precedence over the node's.
+			var minGasPrices auth.MinGasPrices
+			if prmKpr.GetParam(ctx, auth.MinGasPricesKey, &minGasPrices) {
+				ctx = ctx.WithMinGasPrices(minGasPrices)
+			}
 			// Continue on with default auth ante handler.
 			newCtx, res, abort = authAnteHandler(ctx, tx, simulate)
 			return
 		},
 	)
 
+	// Run the messages of every tx with a gno transaction store, so that
+	// the types and nodes cached by failed or simulated txs are discarded.
+	baseApp.SetBeginTxHook(vmKpr.MakeGnoTransactionStore)
+	baseApp.SetEndTxHook(vmKpr.CommitGnoTransactionStore)
+
 	// Set EndBlocker
-	baseApp.SetEndBlocker(EndBlocker(vmKpr))
+	baseApp.SetEndBlocker(EndBlocker(vmKpr, distrKpr, vals))
 
 	// Set a handler Route.
 	baseApp.Router().AddRoute("auth", auth.NewHandler(acctKpr))
 	baseApp.Router().AddRoute("bank", bank.NewHandler(bankKpr))
+	baseApp.Router().AddRoute("params", params.NewHandler(prmKpr))
+	baseApp.Router().AddRoute("distribution", distribution.NewHandler(distrKpr))
 	baseApp.Router().AddRoute("vm", vm.NewHandler(vmKpr))
 
 	// Load latest version.
@@ -122,8 +151,19 @@ func NewAppWithOptions(cfg *AppOptions) (abci.Application, error) {
 	return baseApp, nil
 }
 
+// newParamsKeeper returns a ParamsKeeper with the params of the GnoLand
+// application registered.
+func newParamsKeeper(key store.StoreKey) params.ParamsKeeper {
+	prmKpr := params.NewParamsKeeper(key, params.StoreKeyPrefix)
+	prmKpr.Register(auth.ParamsKey, auth.Params{})
+	prmKpr.Register(auth.MinGasPricesKey, auth.MinGasPrices{})
+	prmKpr.Register(vm.ParamsKey, vm.Params{})
+	prmKpr.Register(distribution.ParamsKey, distribution.Params{})
+	return prmKpr
+}
+
 // NewApp creates the GnoLand application.
-func NewApp(dataRootDir string, skipFailingGenesisTxs bool, logger *slog.Logger, maxCycles int64) (abci.Application, error) {
+func NewApp(dataRootDir string, skipFailingGenesisTxs bool, logger *slog.Logger, maxCycles int64, queueFutureSequences bool) (abci.Application, error) {
 	var err error
 
 	cfg := NewAppOptions()
@@ -138,6 +178,7 @@ func NewApp(dataRootDir string, skipFailingGenesisTxs bool, logger *slog.Logger,
 	}
 
 	cfg.Logger = logger
+	cfg.QueueFutureSequences = queueFutureSequences
 
 	return NewAppWithOptions(cfg)
 }
@@ -153,10 +194,18 @@ func PanicOnFailingTxHandler(ctx sdk.Context, tx std.Tx, res sdk.Result) {
 }
 
 // InitChainer returns a function that can initialize the chain with genesis.
-func InitChainer(baseApp *sdk.BaseApp, acctKpr auth.AccountKeeperI, bankKpr bank.BankKeeperI, resHandler GenesisTxHandler) func(sdk.Context, abci.RequestInitChain) abci.ResponseInitChain {
+func InitChainer(baseApp *sdk.BaseApp, acctKpr auth.AccountKeeperI, bankKpr bank.BankKeeperI, prmKpr params.ParamsKeeper, vmKpr *vm.VMKeeper, vals validatorSet, resHandler GenesisTxHandler) func(sdk.Context, abci.RequestInitChain) abci.ResponseInitChain {
 	return func(ctx sdk.Context, req abci.RequestInitChain) abci.ResponseInitChain {
 		// Get genesis state.
 		genState := req.AppState.(GnoGenesisState)
+		// Track the genesis validators.
+		vals.update(ctx, req.Validators)
+		// Parse and set genesis state params.
+		for _, prm := range genState.Params {
+			if err := prmKpr.SetParamJSON(ctx, prm.Key, []byte(prm.Value)); err != nil {
+				panic(err)
+			}
+		}
 		// Parse and set genesis state balances.
 		for _, bal := range genState.Balances {
 			acc := acctKpr.NewAccountWithAddress(ctx, bal.Address)
@@ -166,6 +215,24 @@ func InitChainer(baseApp *sdk.BaseApp, acctKpr auth.AccountKeeperI, bankKpr bank
 				panic(err)
 			}
 		}
+		// Lock the vesting part of the genesis balances.
+		for _, vest := range genState.Vesting {
+			acc := acctKpr.GetAccount(ctx, vest.Address)
+			if acc == nil {
+				panic(fmt.Errorf("vesting account %s has no genesis balance", vest.Address))
+			}
+			acctKpr.SetAccount(ctx, vest.Account(acc))
+		}
+		// Import the realm state or the packages exported from another
+		// chain, if any.
+		if len(genState.VMState) > 0 {
+			vmKpr.ImportStore(ctx, genState.VMState)
+		}
+		for _, msg := range genState.Packages {
+			if err := vmKpr.AddPackage(ctx, msg); err != nil {
+				panic(fmt.Errorf("unable to import package %s: %w", msg.Package.Path, err))
+			}
+		}
 
 		// Run genesis txs.
 		for _, tx := range genState.Txs {
@@ -189,9 +256,103 @@ func InitChainer(baseApp *sdk.BaseApp, acctKpr auth.AccountKeeperI, bankKpr bank
 	}
 }
 
-// XXX not used yet.
-func EndBlocker(vmk vm.VMKeeperI) func(ctx sdk.Context, req abci.RequestEndBlock) abci.ResponseEndBlock {
+// valRealm is the realm managing the validator set of the chain.
+const valRealm = "gno.land/r/system/validators"
+
+// EndBlocker returns a function that distributes the fees collected during
+// the current block, and applies the validator set changes made in valRealm.
+func EndBlocker(vmk vm.VMKeeperI, dk distribution.DistributionKeeperI, vals validatorSet) func(ctx sdk.Context, req abci.RequestEndBlock) abci.ResponseEndBlock {
 	return func(ctx sdk.Context, req abci.RequestEndBlock) abci.ResponseEndBlock {
-		return abci.ResponseEndBlock{}
+		// Reward the validators of the current block, before the set changes.
+		dk.DistributeFees(ctx)
+
+		res, err := vmk.QueryEvalString(ctx, valRealm, fmt.Sprintf("GetChanges(%d)", ctx.BlockHeight()))
+		if err != nil {
+			// the realm is optional.
+			if !errors.Is(err, vm.InvalidPkgPathError{}) {
+				ctx.Logger().Error("unable to get validator changes", "error", err)
+			}
+			return abci.ResponseEndBlock{}
+		}
+		if res == "" {
+			return abci.ResponseEndBlock{}
+		}
+
+		var valParams *abci.ValidatorParams
+		if cp := ctx.ConsensusParams(); cp != nil {
+			valParams = cp.Validator
+		}
+		updates := []abci.ValidatorUpdate{}
+		operators := []crypto.Address{}
+		indexes := map[crypto.Address]int{}
+		for _, line := range strings.Split(res, "\n") {
+			update, operator, err := parseValidatorUpdate(line, valParams)
+			if err != nil {
+				// an invalid update would halt the chain, skip it.
+				ctx.Logger().Error("invalid validator change", "change", line, "error", err)
+				continue
+			}
+			// only the last change of a validator is applied.
+			if i, ok := indexes[update.Address]; ok {
+				updates[i], operators[i] = update, operator
+				continue
+			}
+			indexes[update.Address] = len(updates)
+			updates = append(updates, update)
+			operators = append(operators, operator)
+		}
+
+		applied := updates[:0]
+		for i, update := range updates {
+			if update.Power == 0 && !vals.has(ctx, update.Address) {
+				// removing an unknown validator would halt the chain.
+				ctx.Logger().Error("removal of unknown validator", "address", update.Address)
+				continue
+			}
+			if update.Power > 0 {
+				// the consensus address has no account to withdraw the rewards.
+				dk.SetWithdrawAddress(ctx, update.Address, operators[i])
+			}
+			applied = append(applied, update)
+		}
+		vals.update(ctx, applied)
+		return abci.ResponseEndBlock{ValidatorUpdates: applied}
+	}
+}
+
+// parseValidatorUpdate parses a validator change returned by valRealm,
+// formatted as "<address>:<pubkey>:<power>:<operator>", and returns the
+// update along with the operator, withdrawing the rewards of the validator.
+func parseValidatorUpdate(change string, params *abci.ValidatorParams) (abci.ValidatorUpdate, crypto.Address, error) {
+	parts := strings.Split(change, ":")
+	if len(parts) != 4 {
+		return abci.ValidatorUpdate{}, crypto.Address{}, fmt.Errorf("expected <address>:<pubkey>:<power>:<operator>")
+	}
+	address, err := crypto.AddressFromBech32(parts[0])
+	if err != nil {
+		return abci.ValidatorUpdate{}, crypto.Address{}, fmt.Errorf("invalid address: %w", err)
+	}
+	pubKey, err := crypto.PubKeyFromBech32(parts[1])
+	if err != nil {
+		return abci.ValidatorUpdate{}, crypto.Address{}, fmt.Errorf("invalid public key: %w", err)
+	}
+	if pubKey.Address() != address {
+		return abci.ValidatorUpdate{}, crypto.Address{}, fmt.Errorf("public key does not match address")
+	}
+	if params != nil && !params.IsValidPubKeyTypeURL(amino.GetTypeURL(pubKey)) {
+		return abci.ValidatorUpdate{}, crypto.Address{}, fmt.Errorf("unsupported public key type %s", amino.GetTypeURL(pubKey))
+	}
+	power, err := strconv.ParseInt(parts[2], 10, 64)
+	if err != nil || power < 0 {
+		return abci.ValidatorUpdate{}, crypto.Address{}, fmt.Errorf("invalid voting power %q", parts[2])
+	}
+	operator, err := crypto.AddressFromBech32(parts[3])
+	if err != nil {
+		return abci.ValidatorUpdate{}, crypto.Address{}, fmt.Errorf("invalid operator: %w", err)
 	}
+	return abci.ValidatorUpdate{
+		Address: address,
+		PubKey:  pubKey,
+		Power:   power,
+	}, operator, nil
 }
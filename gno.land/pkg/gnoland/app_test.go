@@ -0,0 +1,155 @@
+package gnoland
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/gnolang/gno/gno.land/pkg/sdk/vm"
+	"github.com/gnolang/gno/tm2/pkg/amino"
+	abci "github.com/gnolang/gno/tm2/pkg/bft/abci/types"
+	bft "github.com/gnolang/gno/tm2/pkg/bft/types"
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+	"github.com/gnolang/gno/tm2/pkg/crypto/ed25519"
+	"github.com/gnolang/gno/tm2/pkg/crypto/secp256k1"
+	"github.com/gnolang/gno/tm2/pkg/db/memdb"
+	"github.com/gnolang/gno/tm2/pkg/log"
+	"github.com/gnolang/gno/tm2/pkg/sdk"
+	"github.com/gnolang/gno/tm2/pkg/sdk/distribution"
+	"github.com/gnolang/gno/tm2/pkg/store"
+	"github.com/gnolang/gno/tm2/pkg/store/iavl"
+	"github.com/jaekwon/testify/assert"
+	"github.com/jaekwon/testify/require"
+)
+
+// mockVMKeeper returns the result of queryEvalString for QueryEvalString.
+type mockVMKeeper struct {
+	vm.VMKeeperI
+	queryEvalString func(pkgPath, expr string) (string, error)
+}
+
+func (m *mockVMKeeper) QueryEvalString(_ sdk.Context, pkgPath, expr string) (string, error) {
+	return m.queryEvalString(pkgPath, expr)
+}
+
+// mockDistributionKeeper counts the calls to DistributeFees, and records the
+// withdraw addresses.
+type mockDistributionKeeper struct {
+	distribution.DistributionKeeperI
+	distributed   int
+	withdrawAddrs map[crypto.Address]crypto.Address
+}
+
+func (m *mockDistributionKeeper) DistributeFees(sdk.Context) {
+	m.distributed++
+}
+
+func (m *mockDistributionKeeper) SetWithdrawAddress(_ sdk.Context, addr, withdrawAddr crypto.Address) {
+	m.withdrawAddrs[addr] = withdrawAddr
+}
+
+func TestEndBlocker(t *testing.T) {
+	pub1 := ed25519.GenPrivKey().PubKey()
+	pub2 := ed25519.GenPrivKey().PubKey()
+	pubSecp := secp256k1.GenPrivKey().PubKey()
+	operator := crypto.AddressFromPreimage([]byte("operator"))
+	change := func(pub crypto.PubKey, power int) string {
+		return fmt.Sprintf("%s:%s:%d:%s", pub.Address(), crypto.PubKeyToBech32(pub), power, operator)
+	}
+
+	// newContext returns a context whose validator set is pub2.
+	mainKey := store.NewStoreKey("main")
+	vals := validatorSet{key: mainKey}
+	newContext := func() sdk.Context {
+		db := memdb.NewMemDB()
+		ms := store.NewCommitMultiStore(db)
+		ms.MountStoreWithDB(mainKey, iavl.StoreConstructor, db)
+		require.NoError(t, ms.LoadLatestVersion())
+		ctx := sdk.NewContext(sdk.RunTxModeDeliver, ms, &bft.Header{ChainID: "dev", Height: 42}, log.NewNoopLogger()).
+			WithConsensusParams(&abci.ConsensusParams{
+				Validator: &abci.ValidatorParams{
+					PubKeyTypeURLs: []string{amino.GetTypeURL(ed25519.PubKeyEd25519{})},
+				},
+			})
+		vals.update(ctx, []abci.ValidatorUpdate{{PubKey: pub2, Power: 10}})
+		return ctx
+	}
+
+	tests := []struct {
+		name     string
+		res      string
+		err      error
+		expected []abci.ValidatorUpdate
+	}{
+		{
+			name: "realm not found",
+			err:  vm.ErrInvalidPkgPath("package not found: " + valRealm),
+		},
+		{
+			name: "no changes",
+			res:  "",
+		},
+		{
+			name: "add and remove",
+			res:  change(pub1, 10) + "\n" + change(pub2, 0),
+			expected: []abci.ValidatorUpdate{
+				{Address: pub1.Address(), PubKey: pub1, Power: 10},
+				{Address: pub2.Address(), PubKey: pub2, Power: 0},
+			},
+		},
+		{
+			name: "invalid changes are skipped",
+			res: change(pub1, 10) + "\n" +
+				change(pubSecp, 10) + "\n" + // unsupported pubkey type
+				pub2.Address().String() + ":" + crypto.PubKeyToBech32(pub1) + ":10:" + operator.String() + "\n" + // mismatching address
+				change(pub2, -1) + "\n" +
+				change(pub2, 10) + "invalid\n" + // invalid operator
+				fmt.Sprintf("%s:%s:10", pub2.Address(), crypto.PubKeyToBech32(pub2)) + "\n" + // missing operator
+				"garbage",
+			expected: []abci.ValidatorUpdate{
+				{Address: pub1.Address(), PubKey: pub1, Power: 10},
+			},
+		},
+		{
+			name: "last change wins",
+			res:  change(pub1, 10) + "\n" + change(pub2, 0) + "\n" + change(pub1, 20),
+			expected: []abci.ValidatorUpdate{
+				{Address: pub1.Address(), PubKey: pub1, Power: 20},
+				{Address: pub2.Address(), PubKey: pub2, Power: 0},
+			},
+		},
+		{
+			name:     "unknown validators are not removed",
+			res:      change(pub1, 10) + "\n" + change(pub1, 0),
+			expected: []abci.ValidatorUpdate{},
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			vmk := &mockVMKeeper{
+				queryEvalString: func(pkgPath, expr string) (string, error) {
+					assert.Equal(t, valRealm, pkgPath)
+					assert.Equal(t, "GetChanges(42)", expr)
+					return tc.res, tc.err
+				},
+			}
+
+			dk := &mockDistributionKeeper{withdrawAddrs: map[crypto.Address]crypto.Address{}}
+
+			ctx := newContext()
+			res := EndBlocker(vmk, dk, vals)(ctx, abci.RequestEndBlock{})
+			assert.Equal(t, 1, dk.distributed)
+			require.Equal(t, len(tc.expected), len(res.ValidatorUpdates))
+			for i, update := range tc.expected {
+				assert.Equal(t, update.Address, res.ValidatorUpdates[i].Address)
+				assert.True(t, update.PubKey.Equals(res.ValidatorUpdates[i].PubKey))
+				assert.Equal(t, update.Power, res.ValidatorUpdates[i].Power)
+				assert.Equal(t, update.Power > 0, vals.has(ctx, update.Address))
+				if update.Power > 0 {
+					assert.Equal(t, operator, dk.withdrawAddrs[update.Address])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,141 @@
+package gnoland
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/gnolang/gno/gno.land/pkg/sdk/vm"
+	bft "github.com/gnolang/gno/tm2/pkg/bft/types"
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+	dbm "github.com/gnolang/gno/tm2/pkg/db"
+	"github.com/gnolang/gno/tm2/pkg/log"
+	"github.com/gnolang/gno/tm2/pkg/sdk"
+	"github.com/gnolang/gno/tm2/pkg/sdk/auth"
+	"github.com/gnolang/gno/tm2/pkg/sdk/bank"
+	"github.com/gnolang/gno/tm2/pkg/sdk/params"
+	"github.com/gnolang/gno/tm2/pkg/std"
+	"github.com/gnolang/gno/tm2/pkg/store"
+	"github.com/gnolang/gno/tm2/pkg/store/dbadapter"
+	"github.com/gnolang/gno/tm2/pkg/store/iavl"
+)
+
+var (
+	ErrExportNoState       = errors.New("no committed state to export")
+	ErrExportVMStateHeight = errors.New("the realm state is not versioned, it can only be exported at the latest height")
+	ErrExportNoCreator     = errors.New("package creator has no balance, and no default creator is set")
+)
+
+type ExportOptions struct {
+	// DB is the database of the application. The node must be stopped.
+	DB     dbm.DB
+	Logger *slog.Logger
+	// Height is the height of the exported state, or 0 for the latest
+	// height.
+	Height int64
+	// IncludeVMState exports the state of the realms as is. Otherwise, the
+	// packages are redeployed from the genesis, and their state is reset.
+	IncludeVMState bool
+	// DefaultCreator adds the packages whose creator is unknown, or has no
+	// balance in the exported state.
+	DefaultCreator crypto.Address
+}
+
+func NewExportOptions() *ExportOptions {
+	return &ExportOptions{
+		Logger: log.NewNoopLogger(),
+	}
+}
+
+// ExportGenesisState returns the state of the application at a given height
+// as the genesis state of a new chain, along with the exported height.
+// Accounts are exported as balances, along with the vesting schedule of vesting
+// accounts, and lose their sequence and public key.
+func ExportGenesisState(opts *ExportOptions) (GnoGenesisState, int64, error) {
+	var state GnoGenesisState
+
+	// Mount the stores as NewAppWithOptions does.
+	mainKey := store.NewStoreKey("main")
+	baseKey := store.NewStoreKey("base")
+	cms := store.NewCommitMultiStore(opts.DB)
+	cms.MountStoreWithDB(mainKey, iavl.StoreConstructor, opts.DB)
+	cms.MountStoreWithDB(baseKey, dbadapter.StoreConstructor, opts.DB)
+	if err := cms.LoadLatestVersion(); err != nil {
+		return state, 0, fmt.Errorf("unable to load the latest version: %w", err)
+	}
+
+	latest := cms.LastCommitID().Version
+	height := opts.Height
+	switch {
+	case latest == 0:
+		return state, 0, ErrExportNoState
+	case height == 0:
+		height = latest
+	case height > latest:
+		return state, 0, fmt.Errorf("height %d is greater than the latest height %d", height, latest)
+	case height < latest && opts.IncludeVMState:
+		return state, 0, ErrExportVMStateHeight
+	}
+
+	ms, err := cms.MultiImmutableCacheWrapWithVersion(height)
+	if err != nil {
+		return state, 0, fmt.Errorf("unable to load height %d: %w", height, err)
+	}
+	header := &bft.Header{ChainID: "export", Height: height}
+	ctx := sdk.NewContext(sdk.RunTxModeDeliver, ms, header, opts.Logger)
+
+	acctKpr := auth.NewAccountKeeper(mainKey, ProtoGnoAccount)
+	bankKpr := bank.NewBankKeeper(acctKpr)
+	prmKpr := newParamsKeeper(mainKey)
+	vmKpr := vm.NewVMKeeper(baseKey, mainKey, acctKpr, bankKpr, prmKpr, "", 0)
+
+	// Export params.
+	state.Params = []params.Param{}
+	for _, key := range prmKpr.Keys() {
+		if bz := prmKpr.GetParamJSON(ctx, key); bz != nil {
+			state.Params = append(state.Params, params.Param{Key: key, Value: string(bz)})
+		}
+	}
+
+	// Export balances.
+	state.Balances = []Balance{}
+	funded := map[crypto.Address]bool{}
+	acctKpr.IterateAccounts(ctx, func(acc std.Account) bool {
+		if coins := acc.GetCoins(); !coins.IsZero() {
+			state.Balances = append(state.Balances, Balance{
+				Address: acc.GetAddress(),
+				Amount:  coins,
+			})
+			funded[acc.GetAddress()] = true
+		}
+		// A genesis vesting account must have a balance, so the schedule
+		// of an account which spent all of its coins is dropped.
+		if vacc, ok := acc.(auth.VestingAccount); ok && funded[vacc.GetAddress()] {
+			state.Vesting = append(state.Vesting, Vesting{
+				Address:   vacc.GetAddress(),
+				Amount:    vacc.GetOriginalVesting(),
+				StartTime: vacc.GetStartTime(),
+				EndTime:   vacc.GetEndTime(),
+			})
+		}
+		return false
+	})
+
+	// Export realms, or packages.
+	state.Txs = []std.Tx{}
+	if opts.IncludeVMState {
+		state.VMState = vmKpr.ExportStore(ctx)
+		return state, height, nil
+	}
+	for _, msg := range vmKpr.ExportPackages(ctx) {
+		if !funded[msg.Creator] {
+			if opts.DefaultCreator.IsZero() {
+				return state, 0, fmt.Errorf("%w: %s", ErrExportNoCreator, msg.Package.Path)
+			}
+			msg.Creator = opts.DefaultCreator
+		}
+		state.Packages = append(state.Packages, msg)
+	}
+
+	return state, height, nil
+}
@@ -0,0 +1,211 @@
+package gnoland
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/gnolang/gno/gno.land/pkg/sdk/vm"
+	"github.com/gnolang/gno/tm2/pkg/amino"
+	abci "github.com/gnolang/gno/tm2/pkg/bft/abci/types"
+	bft "github.com/gnolang/gno/tm2/pkg/bft/types"
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+	"github.com/gnolang/gno/tm2/pkg/db/memdb"
+	"github.com/gnolang/gno/tm2/pkg/sdk/auth"
+	"github.com/gnolang/gno/tm2/pkg/sdk/bank"
+	"github.com/gnolang/gno/tm2/pkg/sdk/distribution"
+	"github.com/gnolang/gno/tm2/pkg/std"
+	"github.com/jaekwon/testify/assert"
+	"github.com/jaekwon/testify/require"
+)
+
+// startTestApp creates an app on db, initializes it with state and commits
+// its first block.
+func startTestApp(t *testing.T, db *memdb.MemDB, state GnoGenesisState) abci.Application {
+	t.Helper()
+
+	opts := NewAppOptions()
+	opts.DB = db
+	app, err := NewAppWithOptions(opts)
+	require.NoError(t, err)
+
+	now := time.Now()
+	app.InitChain(abci.RequestInitChain{
+		Time:    now,
+		ChainID: "dev",
+		ConsensusParams: &abci.ConsensusParams{
+			Block: &abci.BlockParams{MaxTxBytes: 1_000_000, MaxDataBytes: 2_000_000, MaxGas: 100_000_000},
+		},
+		AppState: state,
+	})
+	app.BeginBlock(abci.RequestBeginBlock{Header: &bft.Header{ChainID: "dev", Height: 1, Time: now}})
+	app.EndBlock(abci.RequestEndBlock{})
+	app.Commit()
+	return app
+}
+
+func TestExportGenesisState(t *testing.T) {
+	creator := crypto.AddressFromPreimage([]byte("creator"))
+	pkgPath := "gno.land/r/test/counter"
+	files := []*std.MemFile{
+		{Name: "counter.gno", Body: `
+package counter
+
+import "strconv"
+
+var counter int
+
+func Incr() { counter++ }
+
+func Render(path string) string { return strconv.Itoa(counter) }`},
+	}
+	genesisTx := func(msg std.Msg) std.Tx {
+		tx := std.Tx{Msgs: []std.Msg{msg}, Fee: std.NewFee(50000, std.NewCoin("ugnot", 0))}
+		tx.Signatures = make([]std.Signature, len(tx.GetSigners()))
+		return tx
+	}
+
+	addPkg := vm.NewMsgAddPackage(creator, pkgPath, files)
+	addPkg.Deposit = nil
+
+	db := memdb.NewMemDB()
+	startTestApp(t, db, GnoGenesisState{
+		Balances: []Balance{{Address: creator, Amount: std.MustParseCoins("10000000ugnot")}},
+		Txs: []std.Tx{
+			genesisTx(addPkg),
+			genesisTx(vm.NewMsgCall(creator, nil, pkgPath, "Incr", nil)),
+		},
+	})
+	render := func(app abci.Application) string {
+		res := app.Query(abci.RequestQuery{Path: "vm/qrender", Data: []byte(pkgPath + "\n")})
+		require.Nil(t, res.Error)
+		return string(res.Data)
+	}
+
+	t.Run("packages", func(t *testing.T) {
+		opts := NewExportOptions()
+		opts.DB = db
+		state, height, err := ExportGenesisState(opts)
+		require.NoError(t, err)
+
+		assert.Equal(t, int64(1), height)
+		// The package and the call cost 1 GNOT each, sent to the community
+		// pool as the first block has no signers.
+		assert.Equal(t, []Balance{
+			{Address: creator, Amount: std.MustParseCoins("8000000ugnot")},
+			{Address: distribution.CommunityPoolAddress(), Amount: std.MustParseCoins("2000000ugnot")},
+		}, state.Balances)
+		assert.Equal(t, 0, len(state.Txs))
+		require.Equal(t, 1, len(state.Packages))
+		msg := state.Packages[0]
+		assert.Equal(t, creator, msg.Creator)
+		assert.Equal(t, pkgPath, msg.Package.Path)
+		assert.Equal(t, 0, len(state.VMState))
+
+		// The realm state is reset.
+		importDB := memdb.NewMemDB()
+		app := startTestApp(t, importDB, state)
+		assert.Equal(t, "0", render(app))
+
+		// The packages are imported without fee.
+		opts.DB = importDB
+		imported, _, err := ExportGenesisState(opts)
+		require.NoError(t, err)
+		assert.Equal(t, state.Balances, imported.Balances)
+	})
+
+	t.Run("vm state", func(t *testing.T) {
+		opts := NewExportOptions()
+		opts.DB = db
+		opts.IncludeVMState = true
+		state, _, err := ExportGenesisState(opts)
+		require.NoError(t, err)
+
+		assert.Equal(t, 0, len(state.Packages))
+		assert.NotEqual(t, 0, len(state.VMState))
+
+		// The realm state is kept.
+		app := startTestApp(t, memdb.NewMemDB(), state)
+		assert.Equal(t, "1", render(app))
+	})
+
+	t.Run("invalid height", func(t *testing.T) {
+		opts := NewExportOptions()
+		opts.DB = db
+		opts.Height = 2
+		_, _, err := ExportGenesisState(opts)
+		assert.Error(t, err)
+	})
+
+	t.Run("no state", func(t *testing.T) {
+		opts := NewExportOptions()
+		opts.DB = memdb.NewMemDB()
+		_, _, err := ExportGenesisState(opts)
+		assert.True(t, errors.Is(err, ErrExportNoState))
+	})
+}
+
+func TestExportGenesisStateVesting(t *testing.T) {
+	team := crypto.AddressFromPreimage([]byte("team"))
+	investor := crypto.AddressFromPreimage([]byte("investor"))
+	spender := crypto.AddressFromPreimage([]byte("spender"))
+	vesting := []Vesting{
+		{Address: team, Amount: std.MustParseCoins("1000ugnot"), StartTime: 1000, EndTime: 2000},
+		{Address: investor, Amount: std.MustParseCoins("500ugnot"), EndTime: 2000},
+		{Address: spender, Amount: std.MustParseCoins("300ugnot"), EndTime: 2000},
+	}
+
+	// The spender spends its whole balance once vested.
+	spend := std.Tx{
+		Msgs: []std.Msg{bank.NewMsgSend(spender, team, std.MustParseCoins("300ugnot"))},
+		Fee:  std.NewFee(50000, std.NewCoin("ugnot", 0)),
+	}
+	spend.Signatures = make([]std.Signature, len(spend.GetSigners()))
+
+	db := memdb.NewMemDB()
+	app := startTestApp(t, db, GnoGenesisState{
+		Balances: []Balance{
+			{Address: team, Amount: std.MustParseCoins("1000ugnot")},
+			{Address: investor, Amount: std.MustParseCoins("600ugnot")},
+			{Address: spender, Amount: std.MustParseCoins("300ugnot")},
+		},
+		Vesting: vesting,
+		Txs:     []std.Tx{spend},
+	})
+
+	// The accounts are vesting accounts.
+	res := app.Query(abci.RequestQuery{Path: "auth/accounts/" + investor.String()})
+	require.Nil(t, res.Error)
+	var acc auth.DelayedVestingAccount
+	require.NoError(t, amino.UnmarshalJSON(res.Data, &acc))
+	assert.Equal(t, investor, acc.GetAddress())
+	assert.Equal(t, std.MustParseCoins("500ugnot"), acc.OriginalVesting)
+
+	opts := NewExportOptions()
+	opts.DB = db
+	state, _, err := ExportGenesisState(opts)
+	require.NoError(t, err)
+	assert.Equal(t, std.MustParseCoins("1300ugnot"), balanceOf(state, team))
+
+	// The vesting schedule of an account without balance is not exported,
+	// and the exported state can be imported.
+	assert.ElementsMatch(t, vesting[:2], state.Vesting)
+	assert.NotPanics(t, func() {
+		startTestApp(t, memdb.NewMemDB(), state)
+	})
+
+	// A genesis vesting account must have a balance.
+	assert.Panics(t, func() {
+		startTestApp(t, memdb.NewMemDB(), GnoGenesisState{Vesting: vesting})
+	})
+}
+
+// balanceOf returns the exported balance of addr, if any.
+func balanceOf(state GnoGenesisState, addr crypto.Address) std.Coins {
+	for _, bal := range state.Balances {
+		if bal.Address == addr {
+			return bal.Amount
+		}
+	}
+	return nil
+}
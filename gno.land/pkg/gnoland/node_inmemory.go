@@ -122,6 +122,8 @@ func NewInMemoryNode(logger *slog.Logger, cfg *InMemoryNodeConfig) (*node.Node,
 		GenesisTxHandler: cfg.GenesisTxHandler,
 		MaxCycles:        cfg.GenesisMaxVMCycles,
 		DB:               memdb.NewMemDB(),
+
+		QueueFutureSequences: cfg.TMConfig.Mempool.SequenceQueueSize > 0,
 	})
 	if err != nil {
 		return nil, fmt.Errorf("error initializing new app: %w", err)
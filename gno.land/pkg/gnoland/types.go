@@ -5,14 +5,18 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/gnolang/gno/gno.land/pkg/sdk/vm"
 	bft "github.com/gnolang/gno/tm2/pkg/bft/types"
 	"github.com/gnolang/gno/tm2/pkg/crypto"
+	"github.com/gnolang/gno/tm2/pkg/sdk/auth"
+	"github.com/gnolang/gno/tm2/pkg/sdk/params"
 	"github.com/gnolang/gno/tm2/pkg/std"
 )
 
 var (
 	ErrBalanceEmptyAddress = errors.New("balance address is empty")
 	ErrBalanceEmptyAmount  = errors.New("balance amount is empty")
+	ErrVestingEmptyAddress = errors.New("vesting address is empty")
 )
 
 type GnoAccount struct {
@@ -24,8 +28,16 @@ func ProtoGnoAccount() std.Account {
 }
 
 type GnoGenesisState struct {
-	Balances []Balance `json:"balances"`
-	Txs      []std.Tx  `json:"txs"`
+	Balances []Balance      `json:"balances"`
+	Txs      []std.Tx       `json:"txs"`
+	Params   []params.Param `json:"params"`
+	// VMState holds the realm state exported from another chain.
+	VMState []vm.StoreEntry `json:"vm_state,omitempty"`
+	// Packages are the packages exported from another chain, added before
+	// the txs without paying the package fee.
+	Packages []vm.MsgAddPackage `json:"packages,omitempty"`
+	// Vesting locks part of the balances until they vest.
+	Vesting []Vesting `json:"vesting,omitempty"`
 }
 
 type Balance struct {
@@ -77,3 +89,34 @@ func (b Balance) MarshalAmino() (string, error) {
 func (b Balance) String() string {
 	return fmt.Sprintf("%s=%s", b.Address.String(), b.Amount.String())
 }
+
+// Vesting locks the Amount of the genesis balance of Address, which vests
+// linearly between StartTime and EndTime, or at EndTime if StartTime is 0.
+// The times are unix times, in seconds. The Amount may exceed the balance of an
+// exported account which spent some of its vested coins.
+type Vesting struct {
+	Address   bft.Address `json:"address"`
+	Amount    std.Coins   `json:"amount"`
+	StartTime int64       `json:"start_time,omitempty"`
+	EndTime   int64       `json:"end_time"`
+}
+
+func (v *Vesting) Verify() error {
+	if v.Address.IsZero() {
+		return ErrVestingEmptyAddress
+	}
+
+	return auth.ValidateVestingSchedule(v.Amount, v.StartTime, v.EndTime)
+}
+
+// Account returns the vesting account of acc following the vesting schedule:
+// a continuous vesting account, or a delayed one if StartTime is 0.
+func (v *Vesting) Account(acc std.Account) auth.VestingAccount {
+	base := *std.NewBaseAccount(
+		acc.GetAddress(), acc.GetCoins(), acc.GetPubKey(), acc.GetAccountNumber(), acc.GetSequence(),
+	)
+	if v.StartTime == 0 {
+		return auth.NewDelayedVestingAccount(base, v.Amount, v.EndTime)
+	}
+	return auth.NewContinuousVestingAccount(base, v.Amount, v.StartTime, v.EndTime)
+}
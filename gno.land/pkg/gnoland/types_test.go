@@ -96,3 +96,32 @@ func TestBalance_AminoMarshalJSON(t *testing.T) {
 	require.NoError(t, err)
 	require.JSONEq(t, expectedJSON, string(balancesJSON))
 }
+
+func TestVesting_Verify(t *testing.T) {
+	validAddress := crypto.MustAddressFromString("g1jg8mtutu9khhfwc4nxmuhcpftf0pajdhfvsqf5")
+	amount := std.NewCoins(std.NewCoin("test", 100))
+
+	tests := []struct {
+		name      string
+		vesting   Vesting
+		expectErr bool
+	}{
+		{"empty address", Vesting{Amount: amount, EndTime: 2000}, true},
+		{"empty amount", Vesting{Address: validAddress, Amount: std.Coins{}, EndTime: 2000}, true},
+		{"no end time", Vesting{Address: validAddress, Amount: amount}, true},
+		{"start after end", Vesting{Address: validAddress, Amount: amount, StartTime: 3000, EndTime: 2000}, true},
+		{"valid delayed", Vesting{Address: validAddress, Amount: amount, EndTime: 2000}, false},
+		{"valid continuous", Vesting{Address: validAddress, Amount: amount, StartTime: 1000, EndTime: 2000}, false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.vesting.Verify()
+			if tc.expectErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
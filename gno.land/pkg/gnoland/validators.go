@@ -0,0 +1,45 @@
+package gnoland
+
+import (
+	abci "github.com/gnolang/gno/tm2/pkg/bft/abci/types"
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+	"github.com/gnolang/gno/tm2/pkg/sdk"
+	"github.com/gnolang/gno/tm2/pkg/store"
+)
+
+// valsKeyPrefix is the prefix of the validators in the store.
+const valsKeyPrefix = "/vals/"
+
+// validatorSet tracks the addresses of the validators of the chain, from
+// the genesis and the updates returned by EndBlocker, so that EndBlocker
+// does not remove unknown validators, which would halt the chain.
+type validatorSet struct {
+	// The (unexposed) key used to access the store from the Context.
+	key store.StoreKey
+}
+
+// has returns true if addr is a validator.
+func (vs validatorSet) has(ctx sdk.Context, addr crypto.Address) bool {
+	return ctx.Store(vs.key).Has(valKey(addr))
+}
+
+// update adds the validators of updates with a positive power, and removes
+// the others.
+func (vs validatorSet) update(ctx sdk.Context, updates []abci.ValidatorUpdate) {
+	stor := ctx.Store(vs.key)
+	for _, update := range updates {
+		addr := update.Address
+		if addr.IsZero() {
+			addr = update.PubKey.Address()
+		}
+		if update.Power > 0 {
+			stor.Set(valKey(addr), []byte{1})
+		} else {
+			stor.Delete(valKey(addr))
+		}
+	}
+}
+
+func valKey(addr crypto.Address) []byte {
+	return append([]byte(valsKeyPrefix), addr.Bytes()...)
+}
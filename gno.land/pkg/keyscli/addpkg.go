@@ -8,8 +8,8 @@ import (
 	"github.com/gnolang/gno/gno.land/pkg/sdk/vm"
 	gno "github.com/gnolang/gno/gnovm/pkg/gnolang"
 	"github.com/gnolang/gno/gnovm/pkg/transpiler"
-	"github.com/gnolang/gno/tm2/pkg/amino"
 	"github.com/gnolang/gno/tm2/pkg/commands"
+	"github.com/gnolang/gno/tm2/pkg/crypto"
 	"github.com/gnolang/gno/tm2/pkg/crypto/keys"
 	"github.com/gnolang/gno/tm2/pkg/crypto/keys/client"
 	"github.com/gnolang/gno/tm2/pkg/errors"
@@ -66,6 +66,20 @@ func (c *MakeAddPkgCfg) RegisterFlags(fs *flag.FlagSet) {
 }
 
 func execMakeAddPkg(cfg *MakeAddPkgCfg, args []string, io commands.IO) error {
+	return execMakePkgTx(cfg, args, io, func(creator crypto.Address, memPkg *std.MemPackage, deposit std.Coins) std.Msg {
+		return vm.MsgAddPackage{
+			Creator: creator,
+			Package: memPkg,
+			Deposit: deposit,
+		}
+	})
+}
+
+// execMakePkgTx makes a transaction with the message returned by makeMsg for
+// the package in cfg.PkgDir.
+func execMakePkgTx(cfg *MakeAddPkgCfg, args []string, io commands.IO,
+	makeMsg func(creator crypto.Address, memPkg *std.MemPackage, deposit std.Coins) std.Msg,
+) error {
 	if cfg.PkgPath == "" {
 		return errors.New("pkgpath not specified")
 	}
@@ -109,31 +123,18 @@ func execMakeAddPkg(cfg *MakeAddPkgCfg, args []string, io commands.IO) error {
 	}
 
 	// parse gas wanted & fee.
-	gaswanted := cfg.RootCfg.GasWanted
-	gasfee, err := std.ParseCoin(cfg.RootCfg.GasFee)
+	fee, err := cfg.RootCfg.ParseFee()
 	if err != nil {
-		panic(err)
+		return err
 	}
 	// construct msg & tx and marshal.
-	msg := vm.MsgAddPackage{
-		Creator: creator,
-		Package: memPkg,
-		Deposit: deposit,
-	}
+	msg := makeMsg(creator, memPkg, deposit)
 	tx := std.Tx{
 		Msgs:       []std.Msg{msg},
-		Fee:        std.NewFee(gaswanted, gasfee),
+		Fee:        fee,
 		Signatures: nil,
 		Memo:       cfg.RootCfg.Memo,
 	}
 
-	if cfg.RootCfg.Broadcast {
-		err := client.ExecSignAndBroadcast(cfg.RootCfg, args, tx, io)
-		if err != nil {
-			return err
-		}
-	} else {
-		fmt.Println(string(amino.MustMarshalJSON(tx)))
-	}
-	return nil
+	return client.ExecMakeTx(cfg.RootCfg, args, tx, io)
 }
@@ -3,10 +3,8 @@ package keyscli
 import (
 	"context"
 	"flag"
-	"fmt"
 
 	"github.com/gnolang/gno/gno.land/pkg/sdk/vm"
-	"github.com/gnolang/gno/tm2/pkg/amino"
 	"github.com/gnolang/gno/tm2/pkg/commands"
 	"github.com/gnolang/gno/tm2/pkg/crypto/keys"
 	"github.com/gnolang/gno/tm2/pkg/crypto/keys/client"
@@ -17,10 +15,12 @@ import (
 type MakeCallCfg struct {
 	RootCfg *client.MakeTxCfg
 
-	Send     string
-	PkgPath  string
-	FuncName string
-	Args     commands.StringArr
+	Send         string
+	PkgPath      string
+	FuncName     string
+	Args         commands.StringArr
+	ArgsFormat   string
+	ResultFormat string
 }
 
 func NewMakeCallCmd(rootCfg *client.MakeTxCfg, io commands.IO) *commands.Command {
@@ -68,6 +68,20 @@ func (c *MakeCallCfg) RegisterFlags(fs *flag.FlagSet) {
 		"args",
 		"arguments to contract",
 	)
+
+	fs.StringVar(
+		&c.ArgsFormat,
+		"args-format",
+		vm.ArgsFormatString,
+		"format of the arguments to contract (\"\" for primitive values, or \"json\")",
+	)
+
+	fs.StringVar(
+		&c.ResultFormat,
+		"result-format",
+		vm.ResultFormatString,
+		"format of the contract results (\"\" for one result per line, or \"json\")",
+	)
 }
 
 func execMakeCall(cfg *MakeCallCfg, args []string, io commands.IO) error {
@@ -80,12 +94,6 @@ func execMakeCall(cfg *MakeCallCfg, args []string, io commands.IO) error {
 	if len(args) != 1 {
 		return flag.ErrHelp
 	}
-	if cfg.RootCfg.GasWanted == 0 {
-		return errors.New("gas-wanted not specified")
-	}
-	if cfg.RootCfg.GasFee == "" {
-		return errors.New("gas-fee not specified")
-	}
 
 	// read statement.
 	fnc := cfg.FuncName
@@ -110,34 +118,27 @@ func execMakeCall(cfg *MakeCallCfg, args []string, io commands.IO) error {
 	}
 
 	// parse gas wanted & fee.
-	gaswanted := cfg.RootCfg.GasWanted
-	gasfee, err := std.ParseCoin(cfg.RootCfg.GasFee)
+	fee, err := cfg.RootCfg.ParseFee()
 	if err != nil {
-		return errors.Wrap(err, "parsing gas fee coin")
+		return err
 	}
 
 	// construct msg & tx and marshal.
 	msg := vm.MsgCall{
-		Caller:  caller,
-		Send:    send,
-		PkgPath: cfg.PkgPath,
-		Func:    fnc,
-		Args:    cfg.Args,
+		Caller:       caller,
+		Send:         send,
+		PkgPath:      cfg.PkgPath,
+		Func:         fnc,
+		Args:         cfg.Args,
+		ArgsFormat:   cfg.ArgsFormat,
+		ResultFormat: cfg.ResultFormat,
 	}
 	tx := std.Tx{
 		Msgs:       []std.Msg{msg},
-		Fee:        std.NewFee(gaswanted, gasfee),
+		Fee:        fee,
 		Signatures: nil,
 		Memo:       cfg.RootCfg.Memo,
 	}
 
-	if cfg.RootCfg.Broadcast {
-		err := client.ExecSignAndBroadcast(cfg.RootCfg, args, tx, io)
-		if err != nil {
-			return err
-		}
-	} else {
-		fmt.Println(string(amino.MustMarshalJSON(tx)))
-	}
-	return nil
+	return client.ExecMakeTx(cfg.RootCfg, args, tx, io)
 }
@@ -35,9 +35,12 @@ func NewMakeTxCmd(rootCfg *client.BaseCfg, io commands.IO) *commands.Command {
 
 	cmd.AddSubCommands(
 		client.NewMakeSendCmd(cfg, io),
+		client.NewMakeSetParamCmd(cfg, io),
+		client.NewMakeWithdrawCmd(cfg, io),
 
 		// custom commands
 		NewMakeAddPkgCmd(cfg, io),
+		NewMakeUpgradePkgCmd(cfg, io),
 		NewMakeCallCmd(cfg, io),
 		NewMakeRunCmd(cfg, io),
 	)
@@ -0,0 +1,65 @@
+package keyscli
+
+import (
+	"context"
+	"flag"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gnolang/gno/tm2/pkg/commands"
+	"github.com/gnolang/gno/tm2/pkg/crypto/bip39"
+	"github.com/gnolang/gno/tm2/pkg/crypto/keys"
+	"github.com/gnolang/gno/tm2/pkg/crypto/keys/client"
+	"github.com/gnolang/gno/tm2/pkg/crypto/multisig"
+)
+
+func TestRootCmd_Help(t *testing.T) {
+	t.Parallel()
+
+	for _, args := range [][]string{
+		{"--help"},
+		{"add", "--help"},
+		{"add", "multisig", "--help"},
+		{"maketx", "call", "--help"},
+	} {
+		cmd := NewRootCmd(commands.NewTestIO(), client.DefaultBaseOptions)
+		err := cmd.ParseAndRun(context.Background(), args)
+		assert.ErrorIs(t, err, flag.ErrHelp, args)
+	}
+}
+
+func TestRootCmd_AddMultisig(t *testing.T) {
+	t.Parallel()
+
+	home := t.TempDir()
+	kb, err := keys.NewKeyBaseFromDir(home)
+	require.NoError(t, err)
+
+	for i, name := range []string{"member1", "member2"} {
+		entropy, err := bip39.NewEntropy(256)
+		require.NoError(t, err)
+		mnemonic, err := bip39.NewMnemonic(entropy)
+		require.NoError(t, err)
+		_, err = kb.CreateAccount(name, mnemonic, "", "", 0, uint32(i))
+		require.NoError(t, err)
+	}
+
+	for name, args := range map[string][]string{
+		"multi":       {"add", "multisig", "--multisig", "member1", "--multisig", "member2", "--threshold", "2"},
+		"multi-alias": {"add", "--multisig", "member1", "--multisig", "member2", "--threshold", "2"},
+	} {
+		base := client.DefaultBaseOptions
+		base.Home = home
+		cmd := NewRootCmd(commands.NewTestIO(), base)
+		require.NoError(t, cmd.ParseAndRun(context.Background(), append(args, name)))
+
+		info, err := kb.GetByName(name)
+		require.NoError(t, err)
+		pub, ok := info.GetPubKey().(multisig.PubKeyMultisigThreshold)
+		require.True(t, ok)
+		assert.EqualValues(t, 2, pub.K)
+		assert.Len(t, pub.PubKeys, 2)
+	}
+}
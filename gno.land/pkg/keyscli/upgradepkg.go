@@ -0,0 +1,39 @@
+package keyscli
+
+import (
+	"context"
+
+	"github.com/gnolang/gno/gno.land/pkg/sdk/vm"
+	"github.com/gnolang/gno/tm2/pkg/commands"
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+	"github.com/gnolang/gno/tm2/pkg/crypto/keys/client"
+	"github.com/gnolang/gno/tm2/pkg/std"
+)
+
+func NewMakeUpgradePkgCmd(rootCfg *client.MakeTxCfg, io commands.IO) *commands.Command {
+	cfg := &MakeAddPkgCfg{
+		RootCfg: rootCfg,
+	}
+
+	return commands.NewCommand(
+		commands.Metadata{
+			Name:       "upgradepkg",
+			ShortUsage: "upgradepkg [flags] <key-name>",
+			ShortHelp:  "uploads a new version of an existing package",
+		},
+		cfg,
+		func(_ context.Context, args []string) error {
+			return execMakeUpgradePkg(cfg, args, io)
+		},
+	)
+}
+
+func execMakeUpgradePkg(cfg *MakeAddPkgCfg, args []string, io commands.IO) error {
+	return execMakePkgTx(cfg, args, io, func(creator crypto.Address, memPkg *std.MemPackage, deposit std.Coins) std.Msg {
+		return vm.MsgUpgradePackage{
+			Creator: creator,
+			Package: memPkg,
+			Deposit: deposit,
+		}
+	})
+}
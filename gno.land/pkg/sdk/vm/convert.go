@@ -191,6 +191,30 @@ func convertArgToGno(arg string, argT gno.Type) (tv gno.TypedValue) {
 	}
 }
 
+// convertVargsToGno converts the trailing arguments of a variadic call
+// with convertArg, to a slice of the element type of vargT, the variadic
+// parameter type.
+func convertVargsToGno(args []string, vargT gno.Type, convertArg func(string, gno.Type) gno.TypedValue) (tv gno.TypedValue) {
+	st, ok := gno.BaseOf(vargT).(*gno.SliceType)
+	if !ok {
+		panic(fmt.Sprintf("unexpected variadic type in contract arg: %v", vargT))
+	}
+	list := make([]gno.TypedValue, len(args))
+	for i, arg := range args {
+		list[i] = convertArg(arg, st.Elt)
+	}
+	tv.T = &gno.SliceType{Elt: st.Elt}
+	tv.V = &gno.SliceValue{
+		Base: &gno.ArrayValue{
+			List: list,
+		},
+		Offset: 0,
+		Length: len(list),
+		Maxcap: len(list),
+	}
+	return
+}
+
 func convertFloat(value string, precision int) float64 {
 	assertCharNotPlus(value[0])
 	dec, _, err := apd.NewFromString(value)
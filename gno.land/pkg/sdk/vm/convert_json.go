@@ -0,0 +1,218 @@
+package vm
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"unicode"
+
+	gno "github.com/gnolang/gno/gnovm/pkg/gnolang"
+)
+
+// convertJSONArgToGno converts the JSON representation of a public-facing
+// argument to a GNO value of type argT. On top of the primitive types
+// supported by convertArgToGno(), it supports structs (as JSON objects keyed
+// by exported field name), arrays, slices, maps (keyed by the string
+// representation of primitive keys) and pointers to structs.
+//   - numbers are JSON numbers, parsed as by convertArgToGno()
+//   - []byte and [N]byte are base64 encoded JSON strings
+//   - null is only accepted for pointers, slices and maps
+//   - missing struct fields are set to their zero value
+func convertJSONArgToGno(arg string, argT gno.Type, store gno.Store) gno.TypedValue {
+	dec := json.NewDecoder(bytes.NewReader([]byte(arg)))
+	dec.UseNumber()
+
+	var v interface{}
+	if err := dec.Decode(&v); err != nil {
+		panic(fmt.Sprintf(
+			"error parsing JSON arg %q: %v",
+			arg, err))
+	}
+	if dec.More() {
+		panic(fmt.Sprintf(
+			"unexpected data after JSON arg %q",
+			arg))
+	}
+
+	return convertJSONValueToGno(v, argT, store)
+}
+
+func convertJSONValueToGno(v interface{}, t gno.Type, store gno.Store) (tv gno.TypedValue) {
+	tv.T = t
+	if v == nil {
+		switch gno.BaseOf(t).(type) {
+		case *gno.PointerType, *gno.SliceType, *gno.MapType:
+			return
+		default:
+			panic(fmt.Sprintf("unexpected null value for type %s", t.String()))
+		}
+	}
+	switch bt := gno.BaseOf(t).(type) {
+	case gno.PrimitiveType:
+		switch {
+		case bt == gno.BoolType:
+			b := assertJSONType[bool](v, t)
+			tv.SetBool(b)
+			return
+		case bt.Kind() == gno.StringKind:
+			s := assertJSONType[string](v, t)
+			return convertArgToGno(s, t)
+		default:
+			n := assertJSONType[json.Number](v, t)
+			return convertArgToGno(n.String(), t)
+		}
+	case *gno.ArrayType:
+		if bt.Elt == gno.Uint8Type {
+			s := assertJSONType[string](v, t)
+			tv = convertArgToGno(s, t)
+			if av := tv.V.(*gno.ArrayValue); len(av.Data) != bt.Len {
+				panic(fmt.Sprintf(
+					"wrong byte array length for %s: got %d",
+					t.String(), len(av.Data)))
+			}
+			return
+		}
+		list := assertJSONType[[]interface{}](v, t)
+		if len(list) != bt.Len {
+			panic(fmt.Sprintf(
+				"wrong number of elements for %s: got %d",
+				t.String(), len(list)))
+		}
+		tv.V = &gno.ArrayValue{
+			List: convertJSONListToGno(list, bt.Elt, store),
+		}
+		return
+	case *gno.SliceType:
+		if bt.Elt == gno.Uint8Type {
+			s := assertJSONType[string](v, t)
+			return convertArgToGno(s, t)
+		}
+		list := assertJSONType[[]interface{}](v, t)
+		tv.V = &gno.SliceValue{
+			Base: &gno.ArrayValue{
+				List: convertJSONListToGno(list, bt.Elt, store),
+			},
+			Offset: 0,
+			Length: len(list),
+			Maxcap: len(list),
+		}
+		return
+	case *gno.StructType:
+		obj := assertJSONType[map[string]interface{}](v, t)
+		fields := make([]gno.TypedValue, len(bt.Fields))
+		found := 0
+		for i, ft := range bt.Fields {
+			fv, ok := obj[string(ft.Name)]
+			if !ok {
+				fields[i] = zeroGnoValue(ft.Type)
+				continue
+			}
+			if !isExportedName(ft.Name) {
+				panic(fmt.Sprintf(
+					"cannot set unexported field %s of %s",
+					ft.Name, t.String()))
+			}
+			fields[i] = convertJSONValueToGno(fv, ft.Type, store)
+			found++
+		}
+		if found != len(obj) {
+			panic(fmt.Sprintf("unknown fields for %s in %v", t.String(), obj))
+		}
+		tv.V = &gno.StructValue{
+			Fields: fields,
+		}
+		return
+	case *gno.MapType:
+		if _, ok := gno.BaseOf(bt.Key).(gno.PrimitiveType); !ok {
+			panic(fmt.Sprintf("unexpected map key type in contract arg: %s", bt.Key.String()))
+		}
+		obj := assertJSONType[map[string]interface{}](v, t)
+		mv := &gno.MapValue{}
+		mv.MakeMap(len(obj))
+		// NOTE: keys are sorted, so that
+		// the map order is deterministic.
+		keys := make([]string, 0, len(obj))
+		for k := range obj {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			ktv := convertArgToGno(k, bt.Key)
+			ptr := mv.GetPointerForKey(nil, store, &ktv)
+			*ptr.TV = convertJSONValueToGno(obj[k], bt.Value, store)
+		}
+		tv.V = mv
+		return
+	case *gno.PointerType:
+		if _, ok := gno.BaseOf(bt.Elt).(*gno.StructType); !ok {
+			panic(fmt.Sprintf("unexpected pointer type in contract arg: %s", t.String()))
+		}
+		etv := convertJSONValueToGno(v, bt.Elt, store)
+		tv.V = gno.PointerValue{
+			TV:   &etv, // heap alloc
+			Base: nil,
+		}
+		return
+	default:
+		panic(fmt.Sprintf("unexpected type in contract arg: %v", t))
+	}
+}
+
+func convertJSONListToGno(list []interface{}, elt gno.Type, store gno.Store) []gno.TypedValue {
+	tvs := make([]gno.TypedValue, len(list))
+	for i, ev := range list {
+		tvs[i] = convertJSONValueToGno(ev, elt, store)
+	}
+	return tvs
+}
+
+// assertJSONType asserts that the decoded JSON value v
+// is of type T, which is expected for the GNO type t.
+func assertJSONType[T any](v interface{}, t gno.Type) T {
+	tv, ok := v.(T)
+	if !ok {
+		panic(fmt.Sprintf(
+			"unexpected JSON value %v for type %s",
+			v, t.String()))
+	}
+	return tv
+}
+
+// zeroGnoValue returns the zero value of type t,
+// for struct fields missing from a JSON object.
+func zeroGnoValue(t gno.Type) (tv gno.TypedValue) {
+	if t.Kind() == gno.InterfaceKind {
+		return
+	}
+	tv.T = t
+	switch bt := gno.BaseOf(t).(type) {
+	case *gno.ArrayType:
+		if bt.Elt == gno.Uint8Type {
+			tv.V = &gno.ArrayValue{
+				Data: make([]byte, bt.Len),
+			}
+			return
+		}
+		list := make([]gno.TypedValue, bt.Len)
+		for i := range list {
+			list[i] = zeroGnoValue(bt.Elt)
+		}
+		tv.V = &gno.ArrayValue{
+			List: list,
+		}
+	case *gno.StructType:
+		fields := make([]gno.TypedValue, len(bt.Fields))
+		for i, ft := range bt.Fields {
+			fields[i] = zeroGnoValue(ft.Type)
+		}
+		tv.V = &gno.StructValue{
+			Fields: fields,
+		}
+	}
+	return
+}
+
+func isExportedName(n gno.Name) bool {
+	return n != "" && unicode.IsUpper(rune(n[0]))
+}
@@ -10,14 +10,20 @@ func (abciError) AssertABCIError() {}
 // declare all script errors.
 // NOTE: these are meant to be used in conjunction with pkgs/errors.
 type (
-	InvalidPkgPathError struct{ abciError }
-	InvalidStmtError    struct{ abciError }
-	InvalidExprError    struct{ abciError }
+	InvalidPkgPathError        struct{ abciError }
+	InvalidStmtError           struct{ abciError }
+	InvalidExprError           struct{ abciError }
+	InvalidStoreKeyError       struct{ abciError }
+	UnauthorizedNamespaceError struct{ abciError }
+	UnauthorizedUpgradeError   struct{ abciError }
 )
 
-func (e InvalidPkgPathError) Error() string { return "invalid package path" }
-func (e InvalidStmtError) Error() string    { return "invalid statement" }
-func (e InvalidExprError) Error() string    { return "invalid expression" }
+func (e InvalidPkgPathError) Error() string        { return "invalid package path" }
+func (e InvalidStmtError) Error() string           { return "invalid statement" }
+func (e InvalidExprError) Error() string           { return "invalid expression" }
+func (e InvalidStoreKeyError) Error() string       { return "invalid store key" }
+func (e UnauthorizedNamespaceError) Error() string { return "unauthorized namespace" }
+func (e UnauthorizedUpgradeError) Error() string   { return "unauthorized upgrade" }
 
 func ErrInvalidPkgPath(msg string) error {
 	return errors.Wrap(InvalidPkgPathError{}, msg)
@@ -30,3 +36,15 @@ func ErrInvalidStmt(msg string) error {
 func ErrInvalidExpr(msg string) error {
 	return errors.Wrap(InvalidExprError{}, msg)
 }
+
+func ErrInvalidStoreKey(msg string) error {
+	return errors.Wrap(InvalidStoreKeyError{}, msg)
+}
+
+func ErrUnauthorizedNamespace(msg string) error {
+	return errors.Wrap(UnauthorizedNamespaceError{}, msg)
+}
+
+func ErrUnauthorizedUpgrade(msg string) error {
+	return errors.Wrap(UnauthorizedUpgradeError{}, msg)
+}
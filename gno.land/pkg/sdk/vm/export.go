@@ -0,0 +1,147 @@
+package vm
+
+import (
+	"bytes"
+	"go/parser"
+	"go/token"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gnolang/gno/tm2/pkg/amino"
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+	"github.com/gnolang/gno/tm2/pkg/sdk"
+	"github.com/gnolang/gno/tm2/pkg/std"
+	"github.com/gnolang/gno/tm2/pkg/store"
+)
+
+// Prefixes of the keys written by the VM, see the backend keys of the gno
+// store and packageCreatorKey.
+var (
+	baseStorePrefixes = []string{"oid:", "tid:", "node:", "pkgidx:", "pkgnames:", "pkgcreator:"}
+	iavlStorePrefixes = []string{"oid:", "pkg:"}
+)
+
+// StoreEntry is a raw key/value pair of the VM stores. Exported entries hold
+// the complete state of the realms, and can be imported into a new chain.
+type StoreEntry struct {
+	IAVL  bool   `json:"iavl,omitempty"` // false for the base store.
+	Key   []byte `json:"key"`
+	Value []byte `json:"value"`
+}
+
+// ExportPackages returns a MsgAddPackage for every package added to the
+// chain, standard libraries excluded. Packages come after the packages they
+// import, and are sent by their creator, or by the zero address if unknown.
+func (vm *VMKeeper) ExportPackages(ctx sdk.Context) []MsgAddPackage {
+	baseStore := ctx.Store(vm.baseKey)
+	iavlStore := ctx.Store(vm.iavlKey)
+
+	pkgs := map[string]*std.MemPackage{}
+	paths := []string{}
+	iter := store.PrefixIterator(iavlStore, []byte("pkg:"))
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		var memPkg *std.MemPackage
+		amino.MustUnmarshal(iter.Value(), &memPkg)
+		if isStdlib(memPkg.Path) {
+			continue
+		}
+		pkgs[memPkg.Path] = memPkg
+		paths = append(paths, memPkg.Path)
+	}
+	sort.Strings(paths)
+
+	msgs := make([]MsgAddPackage, 0, len(paths))
+	added := map[string]bool{}
+	var add func(path string)
+	add = func(path string) {
+		memPkg, ok := pkgs[path]
+		if !ok || added[path] {
+			return
+		}
+		added[path] = true
+		for _, imp := range packageImports(memPkg) {
+			add(imp)
+		}
+		var creator crypto.Address
+		if bz := baseStore.Get(packageCreatorKey(path)); bz != nil {
+			creator = crypto.AddressFromBytes(bz)
+		}
+		msgs = append(msgs, MsgAddPackage{
+			Creator: creator,
+			Package: memPkg,
+		})
+	}
+	for _, path := range paths {
+		add(path)
+	}
+	return msgs
+}
+
+// ExportStore returns all the entries written by the VM to its stores.
+func (vm *VMKeeper) ExportStore(ctx sdk.Context) []StoreEntry {
+	entries := []StoreEntry{}
+	export := func(stor store.Store, prefixes []string, iavl bool) {
+		for _, prefix := range prefixes {
+			iter := store.PrefixIterator(stor, []byte(prefix))
+			for ; iter.Valid(); iter.Next() {
+				entries = append(entries, StoreEntry{
+					IAVL:  iavl,
+					Key:   bytes.Clone(iter.Key()),
+					Value: bytes.Clone(iter.Value()),
+				})
+			}
+			iter.Close()
+		}
+	}
+	export(ctx.Store(vm.baseKey), baseStorePrefixes, false)
+	export(ctx.Store(vm.iavlKey), iavlStorePrefixes, true)
+	return entries
+}
+
+// ImportStore writes entries returned by ExportStore to the VM stores. It is
+// meant to be called from the genesis, before any package is added.
+func (vm *VMKeeper) ImportStore(ctx sdk.Context, entries []StoreEntry) {
+	baseStore := ctx.Store(vm.baseKey)
+	iavlStore := ctx.Store(vm.iavlKey)
+	for _, entry := range entries {
+		if entry.IAVL {
+			iavlStore.Set(entry.Key, entry.Value)
+		} else {
+			baseStore.Set(entry.Key, entry.Value)
+		}
+	}
+	// block nodes are not persisted, re-create them as on restart.
+	preprocessAll(vm.getGnoStore(ctx))
+}
+
+// isStdlib returns true if pkgPath is the path of a standard library, whose
+// first element, unlike a domain, has no dot.
+func isStdlib(pkgPath string) bool {
+	first, _, _ := strings.Cut(pkgPath, "/")
+	return !strings.Contains(first, ".")
+}
+
+// packageImports returns the paths imported by the non-test files of memPkg.
+func packageImports(memPkg *std.MemPackage) []string {
+	imports := []string{}
+	fset := token.NewFileSet()
+	for _, mfile := range memPkg.Files {
+		if !strings.HasSuffix(mfile.Name, ".gno") ||
+			strings.HasSuffix(mfile.Name, "_test.gno") ||
+			strings.HasSuffix(mfile.Name, "_filetest.gno") {
+			continue
+		}
+		f, err := parser.ParseFile(fset, mfile.Name, mfile.Body, parser.ImportsOnly)
+		if err != nil {
+			continue // the package was valid when added.
+		}
+		for _, imp := range f.Imports {
+			if path, err := strconv.Unquote(imp.Path.Value); err == nil {
+				imports = append(imports, path)
+			}
+		}
+	}
+	return imports
+}
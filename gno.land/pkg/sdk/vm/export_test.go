@@ -0,0 +1,82 @@
+package vm
+
+import (
+	"testing"
+
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+	"github.com/gnolang/gno/tm2/pkg/std"
+	"github.com/jaekwon/testify/assert"
+	"github.com/jaekwon/testify/require"
+)
+
+func TestVMKeeperExport(t *testing.T) {
+	env := setupTestEnv()
+	ctx := env.ctx
+
+	// Give "addr1" some gnots.
+	addr := crypto.AddressFromPreimage([]byte("addr1"))
+	acc := env.acck.NewAccountWithAddress(ctx, addr)
+	env.acck.SetAccount(ctx, acc)
+	env.bank.SetCoins(ctx, addr, std.MustParseCoins("10000000ugnot"))
+
+	// zlib is sorted after counter, but is imported by it.
+	libPath := "gno.land/r/test/zlib"
+	err := env.vmk.AddPackage(ctx, NewMsgAddPackage(addr, libPath, []*std.MemFile{
+		{Name: "zlib.gno", Body: `
+package zlib
+
+func Double(n int) int { return n * 2 }`},
+	}))
+	require.NoError(t, err)
+	counterPath := "gno.land/r/test/counter"
+	err = env.vmk.AddPackage(ctx, NewMsgAddPackage(addr, counterPath, []*std.MemFile{
+		{Name: "counter.gno", Body: `
+package counter
+
+import (
+	"strconv"
+
+	"gno.land/r/test/zlib"
+)
+
+var counter int
+
+func Incr() { counter = zlib.Double(counter + 1) }
+
+func Render(path string) string { return strconv.Itoa(counter) }`},
+	}))
+	require.NoError(t, err)
+	_, err = env.vmk.Call(ctx, NewMsgCall(addr, nil, counterPath, "Incr", nil))
+	require.NoError(t, err)
+	_, err = env.vmk.Call(ctx, NewMsgCall(addr, nil, counterPath, "Incr", nil))
+	require.NoError(t, err)
+
+	// Packages are exported after their imports, without stdlibs.
+	msgs := env.vmk.ExportPackages(ctx)
+	require.Equal(t, 2, len(msgs))
+	assert.Equal(t, libPath, msgs[0].Package.Path)
+	assert.Equal(t, counterPath, msgs[1].Package.Path)
+	assert.Equal(t, addr, msgs[1].Creator)
+
+	// Redeploying the packages resets the realm state.
+	env2 := setupTestEnv()
+	env2.acck.SetAccount(env2.ctx, env2.acck.NewAccountWithAddress(env2.ctx, addr))
+	for _, msg := range msgs {
+		require.NoError(t, env2.vmk.AddPackage(env2.ctx, msg))
+	}
+	res, err := env2.vmk.QueryEvalString(env2.ctx, counterPath, `Render("")`)
+	require.NoError(t, err)
+	assert.Equal(t, "0", res)
+
+	// Importing the store keeps it.
+	env3 := setupTestEnv()
+	env3.vmk.ImportStore(env3.ctx, env.vmk.ExportStore(ctx))
+	res, err = env3.vmk.QueryEvalString(env3.ctx, counterPath, `Render("")`)
+	require.NoError(t, err)
+	assert.Equal(t, "6", res)
+	_, err = env3.vmk.Call(env3.ctx, NewMsgCall(addr, nil, counterPath, "Incr", nil))
+	require.NoError(t, err)
+	res, err = env3.vmk.QueryEvalString(env3.ctx, counterPath, `Render("")`)
+	require.NoError(t, err)
+	assert.Equal(t, "14", res)
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/gnolang/gno/tm2/pkg/amino"
 	abci "github.com/gnolang/gno/tm2/pkg/bft/abci/types"
 	"github.com/gnolang/gno/tm2/pkg/sdk"
 	"github.com/gnolang/gno/tm2/pkg/sdk/auth"
@@ -25,6 +26,8 @@ func (vh vmHandler) Process(ctx sdk.Context, msg std.Msg) sdk.Result {
 	switch msg := msg.(type) {
 	case MsgAddPackage:
 		return vh.handleMsgAddPackage(ctx, msg)
+	case MsgUpgradePackage:
+		return vh.handleMsgUpgradePackage(ctx, msg)
 	case MsgCall:
 		return vh.handleMsgCall(ctx, msg)
 	case MsgRun:
@@ -49,7 +52,28 @@ func (vh vmHandler) handleMsgAddPackage(ctx sdk.Context, msg MsgAddPackage) sdk.
 	if err != nil {
 		return abciResult(err)
 	}
-	return sdk.Result{}
+	res := sdk.Result{}
+	res.Events = ctx.EventLogger().Events()
+	return res
+}
+
+// Handle MsgUpgradePackage.
+func (vh vmHandler) handleMsgUpgradePackage(ctx sdk.Context, msg MsgUpgradePackage) sdk.Result {
+	amount, err := std.ParseCoins("1000000ugnot") // XXX calculate
+	if err != nil {
+		return abciResult(err)
+	}
+	err = vh.vm.bank.SendCoins(ctx, msg.Creator, auth.FeeCollectorAddress(), amount)
+	if err != nil {
+		return abciResult(err)
+	}
+	err = vh.vm.UpgradePackage(ctx, msg)
+	if err != nil {
+		return abciResult(err)
+	}
+	res := sdk.Result{}
+	res.Events = ctx.EventLogger().Events()
+	return res
 }
 
 // Handle MsgCall.
@@ -68,15 +92,8 @@ func (vh vmHandler) handleMsgCall(ctx sdk.Context, msg MsgCall) (res sdk.Result)
 		return abciResult(err)
 	}
 	res.Data = []byte(resstr)
+	res.Events = ctx.EventLogger().Events()
 	return
-	/* TODO handle events.
-	ctx.EventManager().EmitEvent(
-		sdk.NewEvent(
-			sdk.EventTypeMessage,
-			sdk.NewAttribute(sdk.AttributeKeyXXX, types.AttributeValueXXX),
-		),
-	)
-	*/
 }
 
 // Handle MsgRun.
@@ -95,6 +112,7 @@ func (vh vmHandler) handleMsgRun(ctx sdk.Context, msg MsgRun) (res sdk.Result) {
 		return abciResult(err)
 	}
 	res.Data = []byte(resstr)
+	res.Events = ctx.EventLogger().Events()
 	return
 }
 
@@ -103,12 +121,13 @@ func (vh vmHandler) handleMsgRun(ctx sdk.Context, msg MsgRun) (res sdk.Result) {
 
 // query paths
 const (
-	QueryPackage = "package"
-	QueryStore   = "store"
-	QueryRender  = "qrender"
-	QueryFuncs   = "qfuncs"
-	QueryEval    = "qeval"
-	QueryFile    = "qfile"
+	QueryPackage  = "package"
+	QueryStore    = "store"
+	QueryRender   = "qrender"
+	QueryFuncs    = "qfuncs"
+	QueryEval     = "qeval"
+	QueryEvalJSON = "qevaljson"
+	QueryFile     = "qfile"
 )
 
 func (vh vmHandler) Query(ctx sdk.Context, req abci.RequestQuery) (res abci.ResponseQuery) {
@@ -122,7 +141,9 @@ func (vh vmHandler) Query(ctx sdk.Context, req abci.RequestQuery) (res abci.Resp
 	case QueryFuncs:
 		return vh.queryFuncs(ctx, req)
 	case QueryEval:
-		return vh.queryEval(ctx, req)
+		return vh.queryEval(ctx, req, ResultFormatString)
+	case QueryEvalJSON:
+		return vh.queryEval(ctx, req, ResultFormatJSON)
 	case QueryFile:
 		return vh.queryFile(ctx, req)
 	default:
@@ -134,15 +155,27 @@ func (vh vmHandler) Query(ctx sdk.Context, req abci.RequestQuery) (res abci.Resp
 	}
 }
 
-// queryPackage fetch a package's files.
+// queryPackage fetches a package's MemPackage (name, path and files) as JSON.
 func (vh vmHandler) queryPackage(ctx sdk.Context, req abci.RequestQuery) (res abci.ResponseQuery) {
-	res.Data = []byte(fmt.Sprintf("TODO: parse parts get or make fileset..."))
+	pkgPath := string(req.Data)
+	memPkg, err := vh.vm.QueryPackage(ctx, pkgPath)
+	if err != nil {
+		res = sdk.ABCIResponseQueryFromError(err)
+		return
+	}
+	res.Data = amino.MustMarshalJSON(memPkg)
 	return
 }
 
-// queryPackage fetch items from the store.
+// queryStore fetches an object, type or node from the store by key, as JSON.
 func (vh vmHandler) queryStore(ctx sdk.Context, req abci.RequestQuery) (res abci.ResponseQuery) {
-	res.Data = []byte(fmt.Sprintf("TODO: fetch from store"))
+	key := string(req.Data)
+	result, err := vh.vm.QueryStore(ctx, key)
+	if err != nil {
+		res = sdk.ABCIResponseQueryFromError(err)
+		return
+	}
+	res.Data = result
 	return
 }
 
@@ -182,8 +215,9 @@ func (vh vmHandler) queryFuncs(ctx sdk.Context, req abci.RequestQuery) (res abci
 	return
 }
 
-// queryEval evaluates any expression in readonly mode and returns the results.
-func (vh vmHandler) queryEval(ctx sdk.Context, req abci.RequestQuery) (res abci.ResponseQuery) {
+// queryEval evaluates any expression in readonly mode and returns the results,
+// in the given format (see ResultFormatString and ResultFormatJSON).
+func (vh vmHandler) queryEval(ctx sdk.Context, req abci.RequestQuery, format string) (res abci.ResponseQuery) {
 	reqData := string(req.Data)
 	reqParts := strings.Split(reqData, "\n")
 	if len(reqParts) != 2 {
@@ -191,7 +225,7 @@ func (vh vmHandler) queryEval(ctx sdk.Context, req abci.RequestQuery) (res abci.
 	}
 	pkgPath := reqParts[0]
 	expr := reqParts[1]
-	result, err := vh.vm.QueryEval(ctx, pkgPath, expr)
+	result, err := vh.vm.queryEval(ctx, pkgPath, expr, format)
 	if err != nil {
 		res = sdk.ABCIResponseQueryFromError(err)
 		return
@@ -11,10 +11,13 @@ import (
 
 	gno "github.com/gnolang/gno/gnovm/pkg/gnolang"
 	"github.com/gnolang/gno/gnovm/stdlibs"
+	"github.com/gnolang/gno/tm2/pkg/amino"
+	"github.com/gnolang/gno/tm2/pkg/crypto"
 	"github.com/gnolang/gno/tm2/pkg/errors"
 	"github.com/gnolang/gno/tm2/pkg/sdk"
 	"github.com/gnolang/gno/tm2/pkg/sdk/auth"
 	"github.com/gnolang/gno/tm2/pkg/sdk/bank"
+	"github.com/gnolang/gno/tm2/pkg/sdk/params"
 	"github.com/gnolang/gno/tm2/pkg/std"
 	"github.com/gnolang/gno/tm2/pkg/store"
 )
@@ -28,8 +31,10 @@ const (
 // smart contracts programming (scripting).
 type VMKeeperI interface {
 	AddPackage(ctx sdk.Context, msg MsgAddPackage) error
+	UpgradePackage(ctx sdk.Context, msg MsgUpgradePackage) error
 	Call(ctx sdk.Context, msg MsgCall) (res string, err error)
 	Run(ctx sdk.Context, msg MsgRun) (res string, err error)
+	QueryEvalString(ctx sdk.Context, pkgPath string, expr string) (res string, err error)
 }
 
 var _ VMKeeperI = &VMKeeper{}
@@ -40,12 +45,13 @@ type VMKeeper struct {
 	iavlKey    store.StoreKey
 	acck       auth.AccountKeeper
 	bank       bank.BankKeeper
+	prmk       params.ParamsKeeperI
 	stdlibsDir string
 
 	// cached, the DeliverTx persistent state.
 	gnoStore gno.Store
 
-	maxCycles int64 // max allowed cylces on VM executions
+	maxCycles int64 // default max allowed cylces on VM executions, see Params
 }
 
 // NewVMKeeper returns a new VMKeeper.
@@ -54,6 +60,7 @@ func NewVMKeeper(
 	iavlKey store.StoreKey,
 	acck auth.AccountKeeper,
 	bank bank.BankKeeper,
+	prmk params.ParamsKeeperI,
 	stdlibsDir string,
 	maxCycles int64,
 ) *VMKeeper {
@@ -63,6 +70,7 @@ func NewVMKeeper(
 		iavlKey:    iavlKey,
 		acck:       acck,
 		bank:       bank,
+		prmk:       prmk,
 		stdlibsDir: stdlibsDir,
 		maxCycles:  maxCycles,
 	}
@@ -82,16 +90,46 @@ func (vm *VMKeeper) Initialize(ms store.MultiStore) {
 		// for now, all mem packages must be re-run after reboot.
 		// TODO remove this, and generally solve for in-mem garbage collection
 		// and memory management across many objects/types/nodes/packages.
-		m2 := gno.NewMachineWithOptions(
-			gno.MachineOptions{
-				PkgPath: "",
-				Output:  os.Stdout, // XXX
-				Store:   vm.gnoStore,
-			})
-		defer m2.Release()
-		gno.DisableDebug()
-		m2.PreprocessAllFilesAndSaveBlockNodes()
-		gno.EnableDebug()
+		preprocessAll(vm.gnoStore)
+	}
+}
+
+// preprocessAll preprocesses all the mem packages of store, and saves their
+// block nodes.
+func preprocessAll(store gno.Store) {
+	m2 := gno.NewMachineWithOptions(
+		gno.MachineOptions{
+			PkgPath: "",
+			Output:  os.Stdout, // XXX
+			Store:   store,
+		})
+	defer m2.Release()
+	gno.DisableDebug()
+	m2.PreprocessAllFilesAndSaveBlockNodes()
+	gno.EnableDebug()
+}
+
+// gnoStoreContextKey is the context key of the gno transaction store.
+type gnoStoreContextKey struct{}
+
+// MakeGnoTransactionStore returns ctx with a gno store for the messages of a
+// transaction. The types and nodes it caches are shared with the other
+// transactions only once committed, by CommitGnoTransactionStore.
+func (vm *VMKeeper) MakeGnoTransactionStore(ctx sdk.Context) sdk.Context {
+	baseSDKStore := ctx.Store(vm.baseKey)
+	iavlSDKStore := ctx.Store(vm.iavlKey)
+	txStore := vm.gnoStore.BeginTransaction(baseSDKStore, iavlSDKStore)
+	return ctx.WithValue(gnoStoreContextKey{}, txStore)
+}
+
+// CommitGnoTransactionStore commits the gno transaction store of ctx if the
+// state changes of its transaction are committed, and discards it otherwise.
+func (vm *VMKeeper) CommitGnoTransactionStore(ctx sdk.Context, committed bool) {
+	txStore := ctx.Value(gnoStoreContextKey{}).(gno.TransactionStore)
+	if committed {
+		txStore.Write()
+	} else {
+		txStore.Discard()
 	}
 }
 
@@ -100,6 +138,11 @@ func (vm *VMKeeper) getGnoStore(ctx sdk.Context) gno.Store {
 	if vm.gnoStore == nil {
 		panic("VMKeeper must first be initialized")
 	}
+	if txStore, ok := ctx.Value(gnoStoreContextKey{}).(gno.TransactionStore); ok {
+		// clear object cache for every message, as below.
+		txStore.ClearObjectCache()
+		return txStore
+	}
 	switch ctx.Mode() {
 	case sdk.RunTxModeDeliver:
 		// swap sdk store of existing gnoStore.
@@ -133,6 +176,100 @@ func (vm *VMKeeper) getGnoStore(ctx sdk.Context) gno.Store {
 
 var reRunPath = regexp.MustCompile(`gno\.land/r/g[a-z0-9]+/run`)
 
+// sysNamesPkgPath is the realm managing the namespaces of packages.
+const sysNamesPkgPath = "gno.land/r/system/names"
+
+var reNamespace = regexp.MustCompile(`^gno\.land/[rp]/([^/]+)`)
+
+// sysNamesRoleExpr is evaluated in sysNamesPkgPath with the namespace and an
+// address, and returns the role of the address in the namespace, see GetRole
+// in r/system/names.
+const sysNamesRoleExpr = `GetRole(%q, %q)`
+
+// getNamespaceRole returns the namespace of pkgPath, and the role of addr in
+// it according to the r/system/names realm, as in sysNamesRoleExpr. The role
+// is "open" if the realm is not deployed, or pkgPath has no namespace.
+func (vm *VMKeeper) getNamespaceRole(ctx sdk.Context, addr crypto.Address, pkgPath string) (namespace, role string) {
+	match := reNamespace.FindStringSubmatch(pkgPath)
+	if match == nil {
+		return "", "open"
+	}
+	namespace = match[1]
+	store := vm.getGnoStore(ctx)
+	if store.GetPackage(sysNamesPkgPath, false) == nil {
+		return namespace, "open"
+	}
+	xx, err := gno.ParseExpr(fmt.Sprintf(sysNamesRoleExpr, namespace, addr.String()))
+	if err != nil {
+		panic(err) // should not happen.
+	}
+	msgCtx := stdlibs.ExecContext{
+		ChainID:     ctx.ChainID(),
+		Height:      ctx.BlockHeight(),
+		Timestamp:   ctx.BlockTime().Unix(),
+		OrigPkgAddr: gno.DerivePkgAddr(sysNamesPkgPath).Bech32(),
+		Banker:      NewSDKBanker(vm, ctx),
+	}
+	m := gno.NewMachineWithOptions(
+		gno.MachineOptions{
+			PkgPath:   sysNamesPkgPath,
+			Output:    os.Stdout, // XXX
+			Store:     store,
+			Context:   msgCtx,
+			Alloc:     store.GetAllocator(),
+			MaxCycles: vm.GetParams(ctx).MaxCycles,
+			GasMeter:  ctx.GasMeter(),
+		})
+	defer m.Release()
+	return namespace, m.Eval(xx)[0].GetString()
+}
+
+// checkNamespacePermission checks that creator is an admin or an editor of
+// the namespace of pkgPath, and that the namespace is not paused, according
+// to the r/system/names realm. The check is skipped if the realm is not
+// deployed, and for the genesis transactions, which are trusted as they
+// populate the namespaces.
+func (vm *VMKeeper) checkNamespacePermission(ctx sdk.Context, creator crypto.Address, pkgPath string) error {
+	if ctx.IsGenesis() {
+		return nil
+	}
+	switch namespace, role := vm.getNamespaceRole(ctx, creator, pkgPath); role {
+	case "open", "admin", "editor":
+		return nil
+	case "paused":
+		return ErrUnauthorizedNamespace(fmt.Sprintf(
+			"namespace %q is paused", namespace))
+	default:
+		return ErrUnauthorizedNamespace(fmt.Sprintf(
+			"%s is not an admin or editor of namespace %q", creator, namespace))
+	}
+}
+
+// checkUpgradePermission checks that creator is the creator of the package
+// at pkgPath, or an admin of its namespace. Packages may not be upgraded
+// while their namespace is paused.
+func (vm *VMKeeper) checkUpgradePermission(ctx sdk.Context, creator crypto.Address, pkgPath string) error {
+	namespace, role := vm.getNamespaceRole(ctx, creator, pkgPath)
+	switch {
+	case role == "paused":
+		return ErrUnauthorizedNamespace(fmt.Sprintf(
+			"namespace %q is paused", namespace))
+	case role == "admin":
+		return nil
+	case bytes.Equal(ctx.Store(vm.baseKey).Get(packageCreatorKey(pkgPath)), creator.Bytes()):
+		return nil
+	default:
+		return ErrUnauthorizedUpgrade(fmt.Sprintf(
+			"%s is not the creator of %s", creator, pkgPath))
+	}
+}
+
+// packageCreatorKey is the key of the address of the creator of a package,
+// in the base store.
+func packageCreatorKey(pkgPath string) []byte {
+	return []byte("pkgcreator:" + pkgPath)
+}
+
 // AddPackage adds a package with given fileset.
 func (vm *VMKeeper) AddPackage(ctx sdk.Context, msg MsgAddPackage) error {
 	creator := msg.Creator
@@ -163,12 +300,16 @@ func (vm *VMKeeper) AddPackage(ctx sdk.Context, msg MsgAddPackage) error {
 	// Pay deposit from creator.
 	pkgAddr := gno.DerivePkgAddr(pkgPath)
 
-	// TODO: ACLs.
-	// - if r/system/names does not exists -> skip validation.
-	// - loads r/system/names data state.
-	// - lookup r/system/names.namespaces for `{r,p}/NAMES`.
-	// - check if caller is in Admins or Editors.
-	// - check if namespace is not in pause.
+	// Check the creator may publish to the namespace.
+	if err := vm.checkNamespacePermission(ctx, creator, pkgPath); err != nil {
+		return err
+	}
+
+	// Check the deposit; genesis packages are exempt.
+	if minDeposit := vm.GetParams(ctx).MinDeposit; !ctx.IsGenesis() && !deposit.IsAllGTE(minDeposit) {
+		return std.ErrInsufficientCoins(fmt.Sprintf(
+			"deposit %s is less than the minimum deposit %s", deposit, minDeposit))
+	}
 
 	err := vm.bank.SendCoins(ctx, creator, pkgAddr, deposit)
 	if err != nil {
@@ -186,6 +327,7 @@ func (vm *VMKeeper) AddPackage(ctx sdk.Context, msg MsgAddPackage) error {
 		OrigSendSpent: new(std.Coins),
 		OrigPkgAddr:   pkgAddr.Bech32(),
 		Banker:        NewSDKBanker(vm, ctx),
+		EventLogger:   ctx.EventLogger(),
 	}
 	// Parse and run the files, construct *PV.
 	m2 := gno.NewMachineWithOptions(
@@ -195,10 +337,83 @@ func (vm *VMKeeper) AddPackage(ctx sdk.Context, msg MsgAddPackage) error {
 			Store:     store,
 			Alloc:     store.GetAllocator(),
 			Context:   msgCtx,
-			MaxCycles: vm.maxCycles,
+			MaxCycles: vm.GetParams(ctx).MaxCycles,
+			GasMeter:  ctx.GasMeter(),
 		})
 	defer m2.Release()
 	m2.RunMemPackage(memPkg, true)
+	ctx.Store(vm.baseKey).Set(packageCreatorKey(pkgPath), creator.Bytes())
+
+	return nil
+}
+
+// UpgradePackage replaces the files of an existing package. Only the creator
+// of the package, or an admin of its namespace, may upgrade it.
+func (vm *VMKeeper) UpgradePackage(ctx sdk.Context, msg MsgUpgradePackage) error {
+	creator := msg.Creator
+	pkgPath := msg.Package.Path
+	memPkg := msg.Package
+	deposit := msg.Deposit
+
+	// Validate arguments.
+	if creator.IsZero() {
+		return std.ErrInvalidAddress("missing creator address")
+	}
+	creatorAcc := vm.acck.GetAccount(ctx, creator)
+	if creatorAcc == nil {
+		return std.ErrUnknownAddress(fmt.Sprintf("account %s does not exist", creator))
+	}
+	if err := msg.Package.Validate(); err != nil {
+		return ErrInvalidPkgPath(err.Error())
+	}
+	store := vm.getGnoStore(ctx)
+	if pv := store.GetPackage(pkgPath, false); pv == nil {
+		return ErrInvalidPkgPath("package does not exist: " + pkgPath)
+	}
+
+	// Check the creator may upgrade the package.
+	if err := vm.checkUpgradePermission(ctx, creator, pkgPath); err != nil {
+		return err
+	}
+
+	// Check the deposit; genesis packages are exempt.
+	if minDeposit := vm.GetParams(ctx).MinDeposit; !ctx.IsGenesis() && !deposit.IsAllGTE(minDeposit) {
+		return std.ErrInsufficientCoins(fmt.Sprintf(
+			"deposit %s is less than the minimum deposit %s", deposit, minDeposit))
+	}
+
+	// Pay deposit from creator.
+	pkgAddr := gno.DerivePkgAddr(pkgPath)
+	err := vm.bank.SendCoins(ctx, creator, pkgAddr, deposit)
+	if err != nil {
+		return err
+	}
+
+	// Parse and run the files, upgrade the *PV.
+	msgCtx := stdlibs.ExecContext{
+		ChainID:       ctx.ChainID(),
+		Height:        ctx.BlockHeight(),
+		Timestamp:     ctx.BlockTime().Unix(),
+		Msg:           msg,
+		OrigCaller:    creator.Bech32(),
+		OrigSend:      deposit,
+		OrigSendSpent: new(std.Coins),
+		OrigPkgAddr:   pkgAddr.Bech32(),
+		Banker:        NewSDKBanker(vm, ctx),
+		EventLogger:   ctx.EventLogger(),
+	}
+	m2 := gno.NewMachineWithOptions(
+		gno.MachineOptions{
+			PkgPath:   "",
+			Output:    os.Stdout, // XXX
+			Store:     store,
+			Alloc:     store.GetAllocator(),
+			Context:   msgCtx,
+			MaxCycles: vm.GetParams(ctx).MaxCycles,
+			GasMeter:  ctx.GasMeter(),
+		})
+	defer m2.Release()
+	m2.UpgradeMemPackage(memPkg)
 
 	return nil
 }
@@ -217,14 +432,29 @@ func (vm *VMKeeper) Call(ctx sdk.Context, msg MsgCall) (res string, err error) {
 	mpn := gno.NewPackageNode("main", "main", nil)
 	mpn.Define("pkg", gno.TypedValue{T: &gno.PackageType{}, V: pv})
 	mpv := mpn.NewPackage()
+	// Check the number of arguments.
+	// Trailing arguments of a variadic function
+	// are passed as a single slice argument.
+	numParams := len(ft.Params)
+	hasVarg := ft.HasVarg()
+	if hasVarg {
+		if len(msg.Args) < numParams-1 {
+			panic(fmt.Sprintf("wrong number of arguments in call to %s: want at least %d got %d", fnc, numParams-1, len(msg.Args)))
+		}
+	} else if len(msg.Args) != numParams {
+		panic(fmt.Sprintf("wrong number of arguments in call to %s: want %d got %d", fnc, numParams, len(msg.Args)))
+	}
 	// Parse expression.
 	argslist := ""
-	for i := range msg.Args {
+	for i := 0; i < numParams; i++ {
 		if i > 0 {
 			argslist += ","
 		}
 		argslist += fmt.Sprintf("arg%d", i)
 	}
+	if hasVarg {
+		argslist += "..."
+	}
 	expr := fmt.Sprintf(`pkg.%s(%s)`, fnc, argslist)
 	xn := gno.MustParseExpr(expr)
 	// Send send-coins to pkg from caller.
@@ -236,20 +466,29 @@ func (vm *VMKeeper) Call(ctx sdk.Context, msg MsgCall) (res string, err error) {
 		return "", err
 	}
 	// Convert Args to gno values.
-	cx := xn.(*gno.CallExpr)
-	if cx.Varg {
-		panic("variadic calls not yet supported")
-	}
-	if len(msg.Args) != len(ft.Params) {
-		panic(fmt.Sprintf("wrong number of arguments in call to %s: want %d got %d", fnc, len(ft.Params), len(msg.Args)))
+	convertArg := convertArgToGno
+	if msg.ArgsFormat == ArgsFormatJSON {
+		convertArg = func(arg string, argT gno.Type) gno.TypedValue {
+			return convertJSONArgToGno(arg, argT, store)
+		}
 	}
+	cx := xn.(*gno.CallExpr)
 	for i, arg := range msg.Args {
+		if hasVarg && i == numParams-1 {
+			break // variadic args are converted below
+		}
 		argType := ft.Params[i].Type
-		atv := convertArgToGno(arg, argType)
+		atv := convertArg(arg, argType)
 		cx.Args[i] = &gno.ConstExpr{
 			TypedValue: atv,
 		}
 	}
+	if hasVarg {
+		vargs := msg.Args[numParams-1:]
+		cx.Args[numParams-1] = &gno.ConstExpr{
+			TypedValue: convertVargsToGno(vargs, ft.Params[numParams-1].Type, convertArg),
+		}
+	}
 	// Make context.
 	// NOTE: if this is too expensive,
 	// could it be safely partially memoized?
@@ -263,6 +502,7 @@ func (vm *VMKeeper) Call(ctx sdk.Context, msg MsgCall) (res string, err error) {
 		OrigSendSpent: new(std.Coins),
 		OrigPkgAddr:   pkgAddr.Bech32(),
 		Banker:        NewSDKBanker(vm, ctx),
+		EventLogger:   ctx.EventLogger(),
 	}
 	// Construct machine and evaluate.
 	m := gno.NewMachineWithOptions(
@@ -272,11 +512,15 @@ func (vm *VMKeeper) Call(ctx sdk.Context, msg MsgCall) (res string, err error) {
 			Store:     store,
 			Context:   msgCtx,
 			Alloc:     store.GetAllocator(),
-			MaxCycles: vm.maxCycles,
+			MaxCycles: vm.GetParams(ctx).MaxCycles,
+			GasMeter:  ctx.GasMeter(),
 		})
 	m.SetActivePackage(mpv)
 	defer func() {
 		if r := recover(); r != nil {
+			if isOutOfGas(r) {
+				panic(r) // let the baseapp report it as out of gas.
+			}
 			err = errors.Wrap(fmt.Errorf("%v", r), "VM call panic: %v\n%s\n",
 				r, m.String())
 			return
@@ -285,6 +529,13 @@ func (vm *VMKeeper) Call(ctx sdk.Context, msg MsgCall) (res string, err error) {
 	}()
 	rtvs := m.Eval(xn)
 
+	if msg.ResultFormat == ResultFormatJSON {
+		bz, err := gno.MarshalJSONTypedValues(store, rtvs)
+		if err != nil {
+			return "", err
+		}
+		return string(bz), nil
+	}
 	for i, rtv := range rtvs {
 		res = res + rtv.String()
 		if i < len(rtvs)-1 {
@@ -292,7 +543,12 @@ func (vm *VMKeeper) Call(ctx sdk.Context, msg MsgCall) (res string, err error) {
 		}
 	}
 	return res, nil
-	// TODO pay for gas? TODO see context?
+}
+
+// isOutOfGas returns true if r was raised by a gas meter running out of gas.
+func isOutOfGas(r interface{}) bool {
+	_, ok := r.(store.OutOfGasException)
+	return ok
 }
 
 // Run executes arbitrary Gno code in the context of the caller's realm.
@@ -334,6 +590,7 @@ func (vm *VMKeeper) Run(ctx sdk.Context, msg MsgRun) (res string, err error) {
 		OrigSendSpent: new(std.Coins),
 		OrigPkgAddr:   pkgAddr.Bech32(),
 		Banker:        NewSDKBanker(vm, ctx),
+		EventLogger:   ctx.EventLogger(),
 	}
 	// Parse and run the files, construct *PV.
 	buf := new(bytes.Buffer)
@@ -344,7 +601,8 @@ func (vm *VMKeeper) Run(ctx sdk.Context, msg MsgRun) (res string, err error) {
 			Store:     store,
 			Alloc:     store.GetAllocator(),
 			Context:   msgCtx,
-			MaxCycles: vm.maxCycles,
+			MaxCycles: vm.GetParams(ctx).MaxCycles,
+			GasMeter:  ctx.GasMeter(),
 		})
 	defer m.Release()
 	_, pv := m.RunMemPackage(memPkg, false)
@@ -356,11 +614,15 @@ func (vm *VMKeeper) Run(ctx sdk.Context, msg MsgRun) (res string, err error) {
 			Store:     store,
 			Alloc:     store.GetAllocator(),
 			Context:   msgCtx,
-			MaxCycles: vm.maxCycles,
+			MaxCycles: vm.GetParams(ctx).MaxCycles,
+			GasMeter:  ctx.GasMeter(),
 		})
 	m2.SetActivePackage(pv)
 	defer func() {
 		if r := recover(); r != nil {
+			if isOutOfGas(r) {
+				panic(r) // let the baseapp report it as out of gas.
+			}
 			err = errors.Wrap(fmt.Errorf("%v", r), "VM call panic: %v\n%s\n",
 				r, m2.String())
 			return
@@ -437,6 +699,16 @@ func (vm *VMKeeper) QueryFuncs(ctx sdk.Context, pkgPath string) (fsigs FunctionS
 // TODO: modify query protocol to allow MsgEval.
 // TODO: then, rename to "Eval".
 func (vm *VMKeeper) QueryEval(ctx sdk.Context, pkgPath string, expr string) (res string, err error) {
+	return vm.queryEval(ctx, pkgPath, expr, ResultFormatString)
+}
+
+// QueryEvalJSON evaluates a gno expression (readonly, for ABCI queries),
+// and returns its results as a JSON array (see gno.JSONTypedValue).
+func (vm *VMKeeper) QueryEvalJSON(ctx sdk.Context, pkgPath string, expr string) (res string, err error) {
+	return vm.queryEval(ctx, pkgPath, expr, ResultFormatJSON)
+}
+
+func (vm *VMKeeper) queryEval(ctx sdk.Context, pkgPath string, expr string, format string) (res string, err error) {
 	alloc := gno.NewAllocator(maxAllocQuery)
 	store := vm.getGnoStore(ctx)
 	pkgAddr := gno.DerivePkgAddr(pkgPath)
@@ -471,7 +743,7 @@ func (vm *VMKeeper) QueryEval(ctx sdk.Context, pkgPath string, expr string) (res
 			Store:     store,
 			Context:   msgCtx,
 			Alloc:     alloc,
-			MaxCycles: vm.maxCycles,
+			MaxCycles: vm.GetParams(ctx).MaxCycles,
 		})
 	defer func() {
 		if r := recover(); r != nil {
@@ -482,6 +754,13 @@ func (vm *VMKeeper) QueryEval(ctx sdk.Context, pkgPath string, expr string) (res
 		m.Release()
 	}()
 	rtvs := m.Eval(xx)
+	if format == ResultFormatJSON {
+		bz, err := gno.MarshalJSONTypedValues(store, rtvs)
+		if err != nil {
+			return "", err
+		}
+		return string(bz), nil
+	}
 	res = ""
 	for i, rtv := range rtvs {
 		res += rtv.String()
@@ -531,7 +810,7 @@ func (vm *VMKeeper) QueryEvalString(ctx sdk.Context, pkgPath string, expr string
 			Store:     store,
 			Context:   msgCtx,
 			Alloc:     alloc,
-			MaxCycles: vm.maxCycles,
+			MaxCycles: vm.GetParams(ctx).MaxCycles,
 		})
 	defer func() {
 		if r := recover(); r != nil {
@@ -551,6 +830,68 @@ func (vm *VMKeeper) QueryEvalString(ctx sdk.Context, pkgPath string, expr string
 	return res, nil
 }
 
+// QueryPackage returns the MemPackage stored at pkgPath, with all of its files.
+func (vm *VMKeeper) QueryPackage(ctx sdk.Context, pkgPath string) (memPkg *std.MemPackage, err error) {
+	store := vm.getGnoStore(ctx)
+	// Get Package.
+	if pv := store.GetPackage(pkgPath, false); pv == nil {
+		err = ErrInvalidPkgPath(fmt.Sprintf(
+			"package not found: %s", pkgPath))
+		return nil, err
+	}
+	return store.GetMemPackage(pkgPath), nil
+}
+
+// QueryStore returns the raw object, realm, type or block node saved in the
+// backend under key, decoded and re-encoded as amino JSON.
+// Keys are of the form "oid:<object id>", "oid:<object id>#realm",
+// "tid:<type id>" or "node:<location>", as written by the gno store.
+func (vm *VMKeeper) QueryStore(ctx sdk.Context, key string) (res []byte, err error) {
+	var ptr interface{}
+	switch {
+	case strings.HasPrefix(key, "oid:") && strings.HasSuffix(key, "#realm"):
+		// realms are saved along with their package value.
+		ptr = new(gno.Realm)
+	case strings.HasPrefix(key, "oid:"):
+		ptr = new(gno.Object)
+	case strings.HasPrefix(key, "tid:"):
+		ptr = new(gno.Type)
+	case strings.HasPrefix(key, "node:"):
+		ptr = new(gno.BlockNode)
+	default:
+		return nil, ErrInvalidStoreKey(fmt.Sprintf(
+			"unknown store key prefix: %s", key))
+	}
+	bz := ctx.Store(vm.baseKey).Get([]byte(key))
+	if bz == nil {
+		return nil, ErrInvalidStoreKey(fmt.Sprintf(
+			"store key not found: %s", key))
+	}
+	if _, ok := ptr.(*gno.Object); ok {
+		// objects are prefixed with their hash.
+		if len(bz) < gno.HashSize {
+			return nil, ErrInvalidStoreKey(fmt.Sprintf(
+				"malformed object at key: %s", key))
+		}
+		bz = bz[gno.HashSize:]
+	}
+	if err := amino.Unmarshal(bz, ptr); err != nil {
+		return nil, err
+	}
+	switch ptr := ptr.(type) {
+	case *gno.Object:
+		return amino.MarshalJSONAny(*ptr)
+	case *gno.Realm:
+		return amino.MarshalJSON(ptr)
+	case *gno.Type:
+		return amino.MarshalJSONAny(*ptr)
+	case *gno.BlockNode:
+		return amino.MarshalJSONAny(*ptr)
+	default:
+		panic("should not happen")
+	}
+}
+
 func (vm *VMKeeper) QueryFile(ctx sdk.Context, filepath string) (res string, err error) {
 	store := vm.getGnoStore(ctx)
 	dirpath, filename := std.SplitFilepath(filepath)
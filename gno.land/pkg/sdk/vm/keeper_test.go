@@ -9,9 +9,15 @@ import (
 	"testing"
 
 	"github.com/jaekwon/testify/assert"
+	"github.com/jaekwon/testify/require"
 
+	gno "github.com/gnolang/gno/gnovm/pkg/gnolang"
+	"github.com/gnolang/gno/gnovm/stdlibs"
+	bft "github.com/gnolang/gno/tm2/pkg/bft/types"
 	"github.com/gnolang/gno/tm2/pkg/crypto"
+	"github.com/gnolang/gno/tm2/pkg/sdk"
 	"github.com/gnolang/gno/tm2/pkg/std"
+	"github.com/gnolang/gno/tm2/pkg/store"
 )
 
 func TestVMKeeperAddPackage(t *testing.T) {
@@ -67,7 +73,7 @@ func TestVMKeeperOrigSend1(t *testing.T) {
 
 	// Create test package.
 	files := []*std.MemFile{
-		{"init.gno", `
+		{Name: "init.gno", Body: `
 package test
 
 import "std"
@@ -112,7 +118,7 @@ func TestVMKeeperOrigSend2(t *testing.T) {
 
 	// Create test package.
 	files := []*std.MemFile{
-		{"init.gno", `
+		{Name: "init.gno", Body: `
 package test
 
 import "std"
@@ -166,7 +172,7 @@ func TestVMKeeperOrigSend3(t *testing.T) {
 
 	// Create test package.
 	files := []*std.MemFile{
-		{"init.gno", `
+		{Name: "init.gno", Body: `
 package test
 
 import "std"
@@ -210,7 +216,7 @@ func TestVMKeeperRealmSend1(t *testing.T) {
 
 	// Create test package.
 	files := []*std.MemFile{
-		{"init.gno", `
+		{Name: "init.gno", Body: `
 package test
 
 import "std"
@@ -254,7 +260,7 @@ func TestVMKeeperRealmSend2(t *testing.T) {
 
 	// Create test package.
 	files := []*std.MemFile{
-		{"init.gno", `
+		{Name: "init.gno", Body: `
 package test
 
 import "std"
@@ -298,7 +304,7 @@ func TestVMKeeperOrigCallerInit(t *testing.T) {
 
 	// Create test package.
 	files := []*std.MemFile{
-		{"init.gno", `
+		{Name: "init.gno", Body: `
 package test
 
 import "std"
@@ -349,7 +355,7 @@ func TestVMKeeperRunSimple(t *testing.T) {
 	env.acck.SetAccount(ctx, acc)
 
 	files := []*std.MemFile{
-		{"script.gno", `
+		{Name: "script.gno", Body: `
 package main
 
 func main() {
@@ -376,7 +382,7 @@ func TestVMKeeperRunImportStdlibs(t *testing.T) {
 	env.acck.SetAccount(ctx, acc)
 
 	files := []*std.MemFile{
-		{"script.gno", `
+		{Name: "script.gno", Body: `
 package main
 
 import "std"
@@ -436,3 +442,901 @@ func Echo(msg string) string {
 		"wrong number of arguments in call to Echo: want 1 got 2",
 	)
 }
+
+func TestVMKeeperCallVariadic(t *testing.T) {
+	env := setupTestEnv()
+	ctx := env.ctx
+
+	// Give "addr1" some gnots.
+	addr := crypto.AddressFromPreimage([]byte("addr1"))
+	acc := env.acck.NewAccountWithAddress(ctx, addr)
+	env.acck.SetAccount(ctx, acc)
+	env.bank.SetCoins(ctx, addr, std.MustParseCoins("10000000ugnot"))
+
+	// Create test package.
+	files := []*std.MemFile{
+		{
+			Name: "test.gno",
+			Body: `package test
+
+import "strings"
+
+func Join(sep string, parts ...string) string {
+	return strings.Join(parts, sep)
+}
+
+func Sum(nums ...int) int {
+	sum := 0
+	for _, n := range nums {
+		sum += n
+	}
+	return sum
+}`,
+		},
+	}
+	pkgPath := "gno.land/r/test"
+	msg1 := NewMsgAddPackage(addr, pkgPath, files)
+	err := env.vmk.AddPackage(ctx, msg1)
+	assert.NoError(t, err)
+
+	// Call with several variadic args.
+	msg2 := NewMsgCall(addr, nil, pkgPath, "Join", []string{"-", "a", "b", "c"})
+	res, err := env.vmk.Call(ctx, msg2)
+	assert.NoError(t, err)
+	assert.Equal(t, `("a-b-c" string)`, res)
+
+	// Call without variadic args.
+	msg3 := NewMsgCall(addr, nil, pkgPath, "Sum", []string{})
+	res, err = env.vmk.Call(ctx, msg3)
+	assert.NoError(t, err)
+	assert.Equal(t, `(0 int)`, res)
+
+	msg4 := NewMsgCall(addr, nil, pkgPath, "Sum", []string{"1", "2", "39"})
+	res, err = env.vmk.Call(ctx, msg4)
+	assert.NoError(t, err)
+	assert.Equal(t, `(42 int)`, res)
+
+	// Missing non-variadic args.
+	msg5 := NewMsgCall(addr, nil, pkgPath, "Join", []string{})
+	assert.PanicsWithValue(
+		t,
+		func() {
+			env.vmk.Call(ctx, msg5)
+		},
+		"wrong number of arguments in call to Join: want at least 1 got 0",
+	)
+
+	// Variadic params are reflected in function signatures.
+	fsigs, err := env.vmk.QueryFuncs(ctx, pkgPath)
+	assert.NoError(t, err)
+	assert.Equal(t, FunctionSignatures{
+		{
+			FuncName: "Join",
+			Params:   []NamedType{{Name: "sep", Type: "string"}, {Name: "parts", Type: "...string"}},
+			Results:  []NamedType{{Name: "_", Type: "string"}},
+		},
+		{
+			FuncName: "Sum",
+			Params:   []NamedType{{Name: "nums", Type: "...int"}},
+			Results:  []NamedType{{Name: "_", Type: "int"}},
+		},
+	}, fsigs)
+}
+
+func TestVMKeeperCallJSONArgs(t *testing.T) {
+	env := setupTestEnv()
+	ctx := env.ctx
+
+	// Give "addr1" some gnots.
+	addr := crypto.AddressFromPreimage([]byte("addr1"))
+	acc := env.acck.NewAccountWithAddress(ctx, addr)
+	env.acck.SetAccount(ctx, acc)
+	env.bank.SetCoins(ctx, addr, std.MustParseCoins("10000000ugnot"))
+
+	// Create test package.
+	files := []*std.MemFile{
+		{
+			Name: "test.gno",
+			Body: `package test
+
+import (
+	"std"
+	"strconv"
+)
+
+type Member struct {
+	Name    string
+	Address std.Address
+	Roles   []string
+	Weights map[string]int
+	Scores  [2]int
+	Parent  *Member
+	secret  string
+}
+
+func Describe(m *Member) string {
+	s := m.Name + "@" + string(m.Address)
+	for _, r := range m.Roles {
+		s += " " + r
+	}
+	if m.Weights != nil {
+		s += " " + strconv.Itoa(m.Weights["a"]) + "/" + strconv.Itoa(m.Weights["b"])
+	}
+	s += " " + strconv.Itoa(m.Scores[0]+m.Scores[1])
+	if m.Parent != nil {
+		s += " <" + m.Parent.Name
+	}
+	return s
+}
+
+func Count(ms ...Member) int {
+	return len(ms)
+}
+
+func Sum(nums []int) int {
+	sum := 0
+	for _, n := range nums {
+		sum += n
+	}
+	return sum
+}`,
+		},
+	}
+	pkgPath := "gno.land/r/test"
+	msg1 := NewMsgAddPackage(addr, pkgPath, files)
+	err := env.vmk.AddPackage(ctx, msg1)
+	assert.NoError(t, err)
+
+	call := func(fn string, args ...string) (string, error) {
+		msg := NewMsgCall(addr, nil, pkgPath, fn, args)
+		msg.ArgsFormat = ArgsFormatJSON
+		return env.vmk.Call(ctx, msg)
+	}
+
+	// Pointer to struct, with nested values.
+	res, err := call("Describe", `{
+		"Name": "alice",
+		"Address": "g1jg8mtutu9khhfwc4nxmuhcpftf0pajdhfvsqf5",
+		"Roles": ["admin", "dev"],
+		"Weights": {"b": 2, "a": 1},
+		"Scores": [3, 4],
+		"Parent": {"Name": "bob"}
+	}`)
+	assert.NoError(t, err)
+	assert.Equal(t, `("alice@g1jg8mtutu9khhfwc4nxmuhcpftf0pajdhfvsqf5 admin dev 1/2 7 <bob" string)`, res)
+
+	// Missing fields are zero values.
+	res, err = call("Describe", `{"Name": "carol"}`)
+	assert.NoError(t, err)
+	assert.Equal(t, `("carol@ 0" string)`, res)
+
+	// Slices and variadic args.
+	res, err = call("Sum", `[1, 2, 39]`)
+	assert.NoError(t, err)
+	assert.Equal(t, `(42 int)`, res)
+
+	res, err = call("Count", `{"Name": "a"}`, `{"Name": "b"}`)
+	assert.NoError(t, err)
+	assert.Equal(t, `(2 int)`, res)
+
+	// Invalid args.
+	for _, arg := range []string{
+		`{"Name": 1}`,
+		`{"Unknown": "x"}`,
+		`{"secret": "x"}`,
+		`{"Scores": [1]}`,
+		`{"Name": "x"} {}`,
+		`not json`,
+	} {
+		assert.Panics(t, func() {
+			call("Describe", arg)
+		}, arg)
+	}
+
+	// Unknown args format.
+	msg := NewMsgCall(addr, nil, pkgPath, "Sum", []string{"[]"})
+	msg.ArgsFormat = "xml"
+	assert.Error(t, msg.ValidateBasic())
+}
+
+func TestVMKeeperCallJSONResults(t *testing.T) {
+	env := setupTestEnv()
+	ctx := env.ctx
+
+	// Give "addr1" some gnots.
+	addr := crypto.AddressFromPreimage([]byte("addr1"))
+	acc := env.acck.NewAccountWithAddress(ctx, addr)
+	env.acck.SetAccount(ctx, acc)
+	env.bank.SetCoins(ctx, addr, std.MustParseCoins("10000000ugnot"))
+
+	// Create test package.
+	files := []*std.MemFile{
+		{
+			Name: "test.gno",
+			Body: `package test
+
+import "std"
+
+type Board struct {
+	ID    uint64
+	Title string
+	Posts []string
+}
+
+var boards = []*Board{{ID: 1, Title: "general", Posts: []string{"hello"}}}
+
+func GetBoard(id uint64) (*Board, bool) {
+	for _, b := range boards {
+		if b.ID == id {
+			return b, true
+		}
+	}
+	return nil, false
+}
+
+func Balance() std.Coins {
+	return std.Coins{{Denom: "ugnot", Amount: 100}}
+}`,
+		},
+	}
+	pkgPath := "gno.land/r/test"
+	msg1 := NewMsgAddPackage(addr, pkgPath, files)
+	err := env.vmk.AddPackage(ctx, msg1)
+	assert.NoError(t, err)
+
+	// Call with JSON results.
+	msg := NewMsgCall(addr, nil, pkgPath, "GetBoard", []string{"1"})
+	msg.ResultFormat = ResultFormatJSON
+	assert.NoError(t, msg.ValidateBasic())
+	res, err := env.vmk.Call(ctx, msg)
+	assert.NoError(t, err)
+	assert.Equal(t, `[{"T":"*gno.land/r/test.Board","V":{"ID":"1","Posts":["hello"],"Title":"general"}},{"T":"bool","V":true}]`, res)
+
+	msg = NewMsgCall(addr, nil, pkgPath, "GetBoard", []string{"2"})
+	msg.ResultFormat = ResultFormatJSON
+	res, err = env.vmk.Call(ctx, msg)
+	assert.NoError(t, err)
+	assert.Equal(t, `[{"T":"*gno.land/r/test.Board","V":null},{"T":"bool","V":false}]`, res)
+
+	// Query with JSON results.
+	res, err = env.vmk.QueryEvalJSON(ctx, pkgPath, "Balance()")
+	assert.NoError(t, err)
+	assert.Equal(t, `[{"T":"std.Coins","V":"100ugnot"}]`, res)
+
+	// The default format is unchanged.
+	res, err = env.vmk.QueryEval(ctx, pkgPath, "GetBoard(2)")
+	assert.NoError(t, err)
+	assert.Equal(t, "(nil *gno.land/r/test.Board)\n(false bool)", res)
+
+	// Unknown result format.
+	msg.ResultFormat = "xml"
+	assert.Error(t, msg.ValidateBasic())
+}
+
+func TestVMKeeperQueryPackageAndStore(t *testing.T) {
+	env := setupTestEnv()
+	ctx := env.ctx
+
+	// Give "addr1" some gnots.
+	addr := crypto.AddressFromPreimage([]byte("addr1"))
+	acc := env.acck.NewAccountWithAddress(ctx, addr)
+	env.acck.SetAccount(ctx, acc)
+	env.bank.SetCoins(ctx, addr, std.MustParseCoins("10000000ugnot"))
+
+	// Create test package.
+	files := []*std.MemFile{
+		{
+			Name: "test.gno",
+			Body: `package test
+
+var counter int
+
+func Inc() int {
+	counter++
+	return counter
+}`,
+		},
+	}
+	pkgPath := "gno.land/r/test"
+	msg1 := NewMsgAddPackage(addr, pkgPath, files)
+	err := env.vmk.AddPackage(ctx, msg1)
+	assert.NoError(t, err)
+
+	// Query the package.
+	memPkg, err := env.vmk.QueryPackage(ctx, pkgPath)
+	assert.NoError(t, err)
+	assert.Equal(t, "test", memPkg.Name)
+	assert.Equal(t, pkgPath, memPkg.Path)
+	assert.Equal(t, 1, len(memPkg.Files))
+	assert.Equal(t, files[0].Body, memPkg.Files[0].Body)
+
+	_, err = env.vmk.QueryPackage(ctx, "gno.land/r/missing")
+	assert.True(t, errors.Is(err, InvalidPkgPathError{}))
+
+	// Query the package value from the store.
+	oid := gno.ObjectIDFromPkgPath(pkgPath)
+	res, err := env.vmk.QueryStore(ctx, "oid:"+oid.String())
+	assert.NoError(t, err)
+	assert.Contains(t, string(res), pkgPath)
+
+	// Query the realm of the package value.
+	res, err = env.vmk.QueryStore(ctx, "oid:"+oid.String()+"#realm")
+	assert.NoError(t, err)
+	assert.Contains(t, string(res), `"Path":"`+pkgPath+`"`)
+
+	_, err = env.vmk.QueryStore(ctx, "tid:missing")
+	assert.True(t, errors.Is(err, InvalidStoreKeyError{}))
+	_, err = env.vmk.QueryStore(ctx, "foo:bar")
+	assert.True(t, errors.Is(err, InvalidStoreKeyError{}))
+}
+
+func TestVMKeeperEmitEvents(t *testing.T) {
+	env := setupTestEnv()
+	ctx := env.ctx
+
+	// Give "addr1" some gnots.
+	addr := crypto.AddressFromPreimage([]byte("addr1"))
+	acc := env.acck.NewAccountWithAddress(ctx, addr)
+	env.acck.SetAccount(ctx, acc)
+	env.bank.SetCoins(ctx, addr, std.MustParseCoins("10000000ugnot"))
+
+	// Create test package.
+	files := []*std.MemFile{
+		{
+			Name: "test.gno",
+			Body: `package test
+
+import "std"
+
+func Echo(msg string) string {
+	std.Emit("Echo", "msg", msg, "caller", std.GetOrigCaller().String())
+	return "echo:"+msg
+}`,
+		},
+	}
+	pkgPath := "gno.land/r/test"
+	msg1 := NewMsgAddPackage(addr, pkgPath, files)
+	err := env.vmk.AddPackage(ctx, msg1)
+	assert.NoError(t, err)
+
+	msg2 := NewMsgCall(addr, nil, pkgPath, "Echo", []string{"hello"})
+	_, err = env.vmk.Call(ctx, msg2)
+	assert.NoError(t, err)
+
+	events := ctx.EventLogger().Events()
+	assert.Equal(t, 1, len(events))
+	expected := stdlibs.GnoEvent{
+		Type:    "Echo",
+		PkgPath: pkgPath,
+		Attributes: []stdlibs.GnoEventAttribute{
+			{Key: "msg", Value: "hello"},
+			{Key: "caller", Value: addr.String()},
+		},
+	}
+	assert.Equal(t, expected, events[0])
+}
+
+func TestVMKeeperGenerics(t *testing.T) {
+	env := setupTestEnv()
+	ctx := env.ctx
+
+	// Give "addr1" some gnots.
+	addr := crypto.AddressFromPreimage([]byte("addr1"))
+	acc := env.acck.NewAccountWithAddress(ctx, addr)
+	env.acck.SetAccount(ctx, acc)
+	env.bank.SetCoins(ctx, addr, std.MustParseCoins("10000000ugnot"))
+
+	// Create a package with a generic type.
+	files := []*std.MemFile{
+		{
+			Name: "list.gno",
+			Body: `package list
+
+type List[T any] struct {
+	items []T
+}
+
+func (l *List[T]) Append(x T) {
+	l.items = append(l.items, x)
+}
+
+func (l *List[T]) Get(i int) T {
+	return l.items[i]
+}
+
+func (l *List[T]) Len() int {
+	return len(l.items)
+}
+
+func Last[T any](l *List[T]) T {
+	return l.Get(l.Len() - 1)
+}`,
+		},
+	}
+	msg1 := NewMsgAddPackage(addr, "gno.land/p/demo/list", files)
+	err := env.vmk.AddPackage(ctx, msg1)
+	assert.NoError(t, err)
+
+	// Create a realm using instances of the generic type.
+	files = []*std.MemFile{
+		{
+			Name: "test.gno",
+			Body: `package test
+
+import "gno.land/p/demo/list"
+
+var (
+	names list.List[string]
+	nums  list.List[int]
+)
+
+func Add(name string) int {
+	names.Append(name)
+	nums.Append(names.Len())
+	return nums.Len()
+}
+
+func LastName() string {
+	return list.Last(&names)
+}`,
+		},
+	}
+	pkgPath := "gno.land/r/test"
+	msg2 := NewMsgAddPackage(addr, pkgPath, files)
+	err = env.vmk.AddPackage(ctx, msg2)
+	assert.NoError(t, err)
+
+	msg3 := NewMsgCall(addr, nil, pkgPath, "Add", []string{"alice"})
+	res, err := env.vmk.Call(ctx, msg3)
+	assert.NoError(t, err)
+	assert.Equal(t, `(1 int)`, res)
+
+	msg4 := NewMsgCall(addr, nil, pkgPath, "Add", []string{"bob"})
+	res, err = env.vmk.Call(ctx, msg4)
+	assert.NoError(t, err)
+	assert.Equal(t, `(2 int)`, res)
+
+	msg5 := NewMsgCall(addr, nil, pkgPath, "LastName", []string{})
+	res, err = env.vmk.Call(ctx, msg5)
+	assert.NoError(t, err)
+	assert.Equal(t, `("bob" string)`, res)
+}
+
+func TestVMKeeperGenericsQueryBeforeDeliver(t *testing.T) {
+	env := setupTestEnv()
+	ctx := env.ctx
+
+	// Give "addr1" some gnots.
+	addr := crypto.AddressFromPreimage([]byte("addr1"))
+	acc := env.acck.NewAccountWithAddress(ctx, addr)
+	env.acck.SetAccount(ctx, acc)
+	env.bank.SetCoins(ctx, addr, std.MustParseCoins("10000000ugnot"))
+
+	// Create a package with a generic type.
+	files := []*std.MemFile{
+		{
+			Name: "box.gno",
+			Body: `package box
+
+type Box[T any] struct {
+	Value T
+}
+
+func (b Box[T]) Get() T {
+	return b.Value
+}`,
+		},
+	}
+	msg1 := NewMsgAddPackage(addr, "gno.land/p/demo/box", files)
+	err := env.vmk.AddPackage(ctx, msg1)
+	require.NoError(t, err)
+
+	// Instantiate Box[int] in a query, whose store is discarded.
+	qctx := ctx.WithMode(sdk.RunTxModeCheck).WithMultiStore(ctx.MultiStore().MultiCacheWrap())
+	res, err := env.vmk.QueryEval(qctx, "gno.land/p/demo/box", "Box[int]{Value: 1}.Get()")
+	require.NoError(t, err)
+	assert.Equal(t, "(1 int)", res)
+
+	// Then use Box[int] in a transaction.
+	files = []*std.MemFile{
+		{
+			Name: "test.gno",
+			Body: `package test
+
+import "gno.land/p/demo/box"
+
+var b = box.Box[int]{Value: 42}
+
+func Get() int {
+	return b.Get()
+}`,
+		},
+	}
+	msg2 := NewMsgAddPackage(addr, "gno.land/r/test", files)
+	err = env.vmk.AddPackage(ctx, msg2)
+	require.NoError(t, err)
+
+	// After a restart, the instantiated type is loaded from the store.
+	store := gno.NewStore(nil, ctx.Store(env.vmk.baseKey), ctx.Store(env.vmk.iavlKey))
+	tid := gno.DeclaredTypeID("gno.land/p/demo/box", "Box[int]")
+	assert.NotNil(t, store.GetTypeSafe(tid))
+
+	vmk := NewVMKeeper(env.vmk.baseKey, env.vmk.iavlKey, env.acck, env.bank, env.prmk, env.vmk.stdlibsDir, 10_000_000)
+	vmk.Initialize(ctx.MultiStore())
+
+	msg3 := NewMsgCall(addr, nil, "gno.land/r/test", "Get", []string{})
+	res, err = vmk.Call(ctx, msg3)
+	require.NoError(t, err)
+	assert.Equal(t, `(42 int)`, res)
+}
+
+func TestVMKeeperAddPackageNamespaces(t *testing.T) {
+	env := setupTestEnv()
+	ctx := env.ctx.WithBlockHeader(&bft.Header{ChainID: "test-chain-id", Height: 1})
+
+	// Give "addr1" and "addr2" some gnots.
+	addr1 := crypto.AddressFromPreimage([]byte("addr1"))
+	addr2 := crypto.AddressFromPreimage([]byte("addr2"))
+	for _, addr := range []crypto.Address{addr1, addr2} {
+		acc := env.acck.NewAccountWithAddress(ctx, addr)
+		env.acck.SetAccount(ctx, acc)
+		env.bank.SetCoins(ctx, addr, std.MustParseCoins("10000000ugnot"))
+	}
+
+	addPkg := func(creator crypto.Address, pkgPath string) error {
+		files := []*std.MemFile{
+			{Name: "foo.gno", Body: "package foo\n\nfunc Foo() string { return \"foo\" }"},
+		}
+		return env.vmk.AddPackage(ctx, NewMsgAddPackage(creator, pkgPath, files))
+	}
+
+	// Without r/system/names, anyone may publish anywhere.
+	assert.NoError(t, addPkg(addr2, "gno.land/r/ourteam/first"))
+
+	// Deploy a minimal r/system/names.
+	files := []*std.MemFile{
+		{
+			Name: "names.gno",
+			Body: fmt.Sprintf(`package names
+
+import "std"
+
+var (
+	admins  = map[string]std.Address{"ourteam": %q, "paused": %[1]q}
+	editors = map[string]std.Address{"shared": %q}
+)
+
+func GetRole(namespace string, addr std.Address) string {
+	switch {
+	case namespace == "paused":
+		return "paused"
+	case admins[namespace] == addr:
+		return "admin"
+	case editors[namespace] == addr:
+		return "editor"
+	case admins[namespace] == "" && editors[namespace] == "":
+		return "open"
+	}
+	return ""
+}`, addr1, addr2),
+		},
+	}
+	err := env.vmk.AddPackage(ctx, NewMsgAddPackage(addr1, "gno.land/r/system/names", files))
+	assert.NoError(t, err)
+
+	// Admins and editors may publish to their namespace.
+	assert.NoError(t, addPkg(addr1, "gno.land/r/ourteam/foo"))
+	assert.NoError(t, addPkg(addr1, "gno.land/p/ourteam/foo"))
+	assert.NoError(t, addPkg(addr2, "gno.land/r/shared/foo"))
+
+	// Others may not.
+	err = addPkg(addr2, "gno.land/r/ourteam/bar")
+	assert.True(t, errors.Is(err, UnauthorizedNamespaceError{}))
+	err = addPkg(addr1, "gno.land/p/shared/bar")
+	assert.True(t, errors.Is(err, UnauthorizedNamespaceError{}))
+
+	// Paused namespaces are closed, even to their admins.
+	err = addPkg(addr1, "gno.land/r/paused/foo")
+	assert.True(t, errors.Is(err, UnauthorizedNamespaceError{}))
+	assert.Contains(t, fmt.Sprintf("%#v", err), `namespace "paused" is paused`)
+
+	// Unregistered namespaces are open.
+	assert.NoError(t, addPkg(addr2, "gno.land/r/unregistered/foo"))
+}
+
+func TestVMKeeperUpgradePackage(t *testing.T) {
+	env := setupTestEnv()
+	ctx := env.ctx
+
+	// Give "addr1" and "addr2" some gnots.
+	addr1 := crypto.AddressFromPreimage([]byte("addr1"))
+	addr2 := crypto.AddressFromPreimage([]byte("addr2"))
+	for _, addr := range []crypto.Address{addr1, addr2} {
+		acc := env.acck.NewAccountWithAddress(ctx, addr)
+		env.acck.SetAccount(ctx, acc)
+		env.bank.SetCoins(ctx, addr, std.MustParseCoins("10000000ugnot"))
+	}
+
+	// Create a pure package, and a realm using it.
+	pFiles := []*std.MemFile{
+		{Name: "greet.gno", Body: `
+package greet
+
+func Hello() string { return "hello" }`},
+	}
+	err := env.vmk.AddPackage(ctx, NewMsgAddPackage(addr1, "gno.land/p/demo/greet", pFiles))
+	assert.NoError(t, err)
+	rFiles := []*std.MemFile{
+		{Name: "counter.gno", Body: `
+package counter
+
+import "gno.land/p/demo/greet"
+
+type Counter struct {
+	N int
+}
+
+func (c *Counter) Inc() { c.N++ }
+
+var c = &Counter{}
+
+func Inc() int {
+	c.Inc()
+	return c.N
+}
+
+func Hello() string { return greet.Hello() }`},
+	}
+	pkgPath := "gno.land/r/test/counter"
+	err = env.vmk.AddPackage(ctx, NewMsgAddPackage(addr1, pkgPath, rFiles))
+	assert.NoError(t, err)
+	call := func(fn string) string {
+		res, err := env.vmk.Call(ctx, NewMsgCall(addr1, nil, pkgPath, fn, nil))
+		require.NoError(t, err)
+		return res
+	}
+	assert.Equal(t, `(1 int)`, call("Inc"))
+	assert.Equal(t, `(2 int)`, call("Inc"))
+
+	// Only the creator may upgrade the realm.
+	rFiles[0].Body = strings.Replace(rFiles[0].Body, "c.N++", "c.N += 10", 1) + `
+
+var migrated bool
+
+func Migrated() bool { return migrated }
+
+func migrate() {
+	migrated = true
+	c.N *= 2
+}`
+	err = env.vmk.UpgradePackage(ctx, NewMsgUpgradePackage(addr2, pkgPath, rFiles))
+	assert.True(t, errors.Is(err, UnauthorizedUpgradeError{}))
+	err = env.vmk.UpgradePackage(ctx, NewMsgUpgradePackage(addr1, pkgPath, rFiles))
+	require.NoError(t, err)
+
+	// The counter is kept, and migrated.
+	assert.Equal(t, `(true bool)`, call("Migrated"))
+	assert.Equal(t, `(14 int)`, call("Inc"))
+
+	// Types may not change, and names may not be removed.
+	badFiles := []*std.MemFile{
+		{Name: "counter.gno", Body: strings.Replace(rFiles[0].Body, "N int", "N string", 1)},
+	}
+	assert.Panics(t, func() {
+		env.vmk.UpgradePackage(ctx, NewMsgUpgradePackage(addr1, pkgPath, badFiles))
+	})
+	badFiles[0].Body = strings.Replace(rFiles[0].Body, "func Migrated", "func Migrated2", 1)
+	assert.Panics(t, func() {
+		env.vmk.UpgradePackage(ctx, NewMsgUpgradePackage(addr1, pkgPath, badFiles))
+	})
+	assert.Equal(t, `(24 int)`, call("Inc"))
+
+	// migrate may not take parameters, nor return values.
+	badFiles[0].Body = strings.Replace(rFiles[0].Body, "func migrate()", "func migrate(n int)", 1)
+	assert.Panics(t, func() {
+		env.vmk.UpgradePackage(ctx, NewMsgUpgradePackage(addr1, pkgPath, badFiles))
+	})
+
+	// The migrate function of the previous version is not run again,
+	// nor needs to be declared again.
+	rFiles[0].Body = rFiles[0].Body[:strings.Index(rFiles[0].Body, "\n\nfunc migrate")]
+	err = env.vmk.UpgradePackage(ctx, NewMsgUpgradePackage(addr1, pkgPath, rFiles))
+	require.NoError(t, err)
+	assert.Equal(t, `(true bool)`, call("Migrated"))
+	assert.Equal(t, `(34 int)`, call("Inc"))
+
+	// Upgrading a pure package upgrades its dependents.
+	assert.Equal(t, `("hello" string)`, call("Hello"))
+	pFiles[0].Body = strings.Replace(pFiles[0].Body, `"hello"`, `"hi"`, 1)
+	err = env.vmk.UpgradePackage(ctx, NewMsgUpgradePackage(addr1, "gno.land/p/demo/greet", pFiles))
+	require.NoError(t, err)
+	assert.Equal(t, `("hi" string)`, call("Hello"))
+
+	// Upgrades are kept upon restart.
+	env.vmk = NewVMKeeper(env.vmk.baseKey, env.vmk.iavlKey, env.acck, env.bank, env.vmk.prmk, env.vmk.stdlibsDir, env.vmk.maxCycles)
+	env.vmk.Initialize(ctx.MultiStore())
+	assert.Equal(t, `(true bool)`, call("Migrated"))
+	assert.Equal(t, `(44 int)`, call("Inc"))
+	assert.Equal(t, `("hi" string)`, call("Hello"))
+}
+
+func TestVMKeeperUpgradePackageTransaction(t *testing.T) {
+	env := setupTestEnv()
+	ctx := env.ctx
+
+	// Give "addr1" some gnots.
+	addr := crypto.AddressFromPreimage([]byte("addr1"))
+	acc := env.acck.NewAccountWithAddress(ctx, addr)
+	env.acck.SetAccount(ctx, acc)
+	env.bank.SetCoins(ctx, addr, std.MustParseCoins("10000000ugnot"))
+
+	// Create a realm whose counter has a method.
+	files := []*std.MemFile{
+		{Name: "counter.gno", Body: `
+package counter
+
+type Counter struct {
+	N int
+}
+
+func (c *Counter) Inc() { c.N++ }
+
+var c = &Counter{}
+
+func Inc() int {
+	c.Inc()
+	return c.N
+}`},
+	}
+	pkgPath := "gno.land/r/test/counter"
+	err := env.vmk.AddPackage(ctx, NewMsgAddPackage(addr, pkgPath, files))
+	require.NoError(t, err)
+	call := func(ctx sdk.Context) string {
+		res, err := env.vmk.Call(ctx, NewMsgCall(addr, nil, pkgPath, "Inc", nil))
+		require.NoError(t, err)
+		return res
+	}
+	assert.Equal(t, `(1 int)`, call(ctx))
+
+	// upgrade runs the upgrade in a transaction, committed or not.
+	upgradedFiles := []*std.MemFile{
+		{Name: "counter.gno", Body: strings.Replace(files[0].Body, "c.N++", "c.N += 10", 1)},
+	}
+	upgrade := func(mode sdk.RunTxMode, committed bool, want string) {
+		txCtx, write := ctx.WithMode(mode).CacheContext()
+		txCtx = env.vmk.MakeGnoTransactionStore(txCtx)
+		err := env.vmk.UpgradePackage(txCtx, NewMsgUpgradePackage(addr, pkgPath, upgradedFiles))
+		require.NoError(t, err)
+		assert.Equal(t, want, call(txCtx))
+		if committed {
+			write()
+		}
+		env.vmk.CommitGnoTransactionStore(txCtx, committed)
+	}
+
+	// Simulated and failed upgrades leave the cached types and nodes as is.
+	upgrade(sdk.RunTxModeSimulate, false, `(11 int)`)
+	assert.Equal(t, `(2 int)`, call(ctx))
+	upgrade(sdk.RunTxModeDeliver, false, `(12 int)`)
+	assert.Equal(t, `(3 int)`, call(ctx))
+
+	// Committed upgrades are shared with the next transactions.
+	upgrade(sdk.RunTxModeDeliver, true, `(13 int)`)
+	assert.Equal(t, `(23 int)`, call(ctx))
+}
+
+func TestVMKeeperCallGas(t *testing.T) {
+	env := setupTestEnv()
+	ctx := env.ctx
+
+	// Give "addr1" some gnots.
+	addr := crypto.AddressFromPreimage([]byte("addr1"))
+	acc := env.acck.NewAccountWithAddress(ctx, addr)
+	env.acck.SetAccount(ctx, acc)
+	env.bank.SetCoins(ctx, addr, std.MustParseCoins("10000000ugnot"))
+
+	// Create test package.
+	files := []*std.MemFile{
+		{Name: "loop.gno", Body: `
+package loop
+
+func Loop(n int) int {
+	s := make([]int, 0)
+	for i := 0; i < n; i++ {
+		s = append(s, i)
+	}
+	return len(s)
+}`},
+	}
+	pkgPath := "gno.land/r/test/loop"
+	msg1 := NewMsgAddPackage(addr, pkgPath, files)
+	require.NoError(t, env.vmk.AddPackage(ctx, msg1))
+
+	// Longer loops consume more gas.
+	callGas := func(n string) int64 {
+		gctx := ctx.WithGasMeter(store.NewGasMeter(10_000_000))
+		msg2 := NewMsgCall(addr, nil, pkgPath, "Loop", []string{n})
+		res, err := env.vmk.Call(gctx, msg2)
+		require.NoError(t, err)
+		assert.Equal(t, "("+n+" int)", res)
+		return gctx.GasMeter().GasConsumed()
+	}
+	gas10, gas100 := callGas("10"), callGas("100")
+	assert.True(t, gas10 > 0)
+	assert.True(t, gas100 > gas10)
+
+	// Running out of gas panics with an OutOfGasException,
+	// which the baseapp reports as std.ErrOutOfGas.
+	gctx := ctx.WithGasMeter(store.NewGasMeter(gas10))
+	msg3 := NewMsgCall(addr, nil, pkgPath, "Loop", []string{"100"})
+	defer func() {
+		r := recover()
+		_, ok := r.(store.OutOfGasException)
+		assert.True(t, ok, "expected OutOfGasException, got %v", r)
+		assert.True(t, gctx.GasMeter().IsOutOfGas())
+	}()
+	env.vmk.Call(gctx, msg3)
+	t.Fatal("expected out of gas")
+}
+
+func TestVMKeeperParams(t *testing.T) {
+	env := setupTestEnv()
+	// The minimum deposit is not required for genesis transactions.
+	ctx := env.ctx.WithBlockHeader(&bft.Header{ChainID: "test-chain-id", Height: 1})
+
+	// Give "addr1" some gnots.
+	addr := crypto.AddressFromPreimage([]byte("addr1"))
+	acc := env.acck.NewAccountWithAddress(ctx, addr)
+	env.acck.SetAccount(ctx, acc)
+	env.bank.SetCoins(ctx, addr, std.MustParseCoins("10000000ugnot"))
+
+	// Defaults to the keeper's max cycles.
+	assert.Equal(t, Params{MaxCycles: 10_000_000}, env.vmk.GetParams(ctx))
+
+	// The max cycles must be positive.
+	assert.Error(t, Params{}.Validate())
+	assert.Error(t, Params{MaxCycles: -1}.Validate())
+	assert.NoError(t, Params{MaxCycles: 1}.Validate())
+
+	env.prmk.SetParam(ctx, ParamsKey, Params{
+		MaxCycles:  100_000,
+		MinDeposit: std.MustParseCoins("100ugnot"),
+	})
+
+	files := []*std.MemFile{
+		{Name: "loop.gno", Body: `
+package loop
+
+func Loop(n int) int {
+	s := 0
+	for i := 0; i < n; i++ {
+		s += i
+	}
+	return s
+}`},
+	}
+	pkgPath := "gno.land/r/test/loop"
+	msg1 := NewMsgAddPackage(addr, pkgPath, files)
+	err := env.vmk.AddPackage(ctx, msg1)
+	assert.Error(t, err)
+	assert.True(t, errors.Is(err, std.InsufficientCoinsError{}))
+
+	msg1.Deposit = std.MustParseCoins("100ugnot")
+	require.NoError(t, env.vmk.AddPackage(ctx, msg1))
+
+	// So are upgrades.
+	msg2 := NewMsgUpgradePackage(addr, pkgPath, files)
+	err = env.vmk.UpgradePackage(ctx, msg2)
+	assert.Error(t, err)
+	assert.True(t, errors.Is(err, std.InsufficientCoinsError{}))
+
+	msg2.Deposit = std.MustParseCoins("100ugnot")
+	require.NoError(t, env.vmk.UpgradePackage(ctx, msg2))
+
+	// Calls are limited by the max cycles param.
+	_, err = env.vmk.Call(ctx, NewMsgCall(addr, nil, pkgPath, "Loop", []string{"10"}))
+	assert.NoError(t, err)
+	_, err = env.vmk.Call(ctx, NewMsgCall(addr, nil, pkgPath, "Loop", []string{"100000"}))
+	assert.Error(t, err)
+	assert.True(t, strings.Contains(err.Error(), "CPU cycle overrun"))
+}
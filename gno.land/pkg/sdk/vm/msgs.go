@@ -78,18 +78,89 @@ func (msg MsgAddPackage) GetReceived() std.Coins {
 	return msg.Deposit
 }
 
+//----------------------------------------
+// MsgUpgradePackage
+
+// MsgUpgradePackage - replace the files of an existing package
+type MsgUpgradePackage struct {
+	Creator crypto.Address  `json:"creator" yaml:"creator"`
+	Package *std.MemPackage `json:"package" yaml:"package"`
+	Deposit std.Coins       `json:"deposit" yaml:"deposit"`
+}
+
+var _ std.Msg = MsgUpgradePackage{}
+
+// NewMsgUpgradePackage - upload the new files of a package.
+func NewMsgUpgradePackage(creator crypto.Address, pkgPath string, files []*std.MemFile) MsgUpgradePackage {
+	msg := NewMsgAddPackage(creator, pkgPath, files)
+	return MsgUpgradePackage{
+		Creator: msg.Creator,
+		Package: msg.Package,
+	}
+}
+
+// Implements Msg.
+func (msg MsgUpgradePackage) Route() string { return RouterKey }
+
+// Implements Msg.
+func (msg MsgUpgradePackage) Type() string { return "upgrade_package" }
+
+// Implements Msg.
+func (msg MsgUpgradePackage) ValidateBasic() error {
+	if msg.Creator.IsZero() {
+		return std.ErrInvalidAddress("missing creator address")
+	}
+	if msg.Package.Path == "" { // XXX
+		return ErrInvalidPkgPath("missing package path")
+	}
+	if !msg.Deposit.IsValid() {
+		return std.ErrTxDecode("invalid deposit")
+	}
+	// XXX validate files.
+	return nil
+}
+
+// Implements Msg.
+func (msg MsgUpgradePackage) GetSignBytes() []byte {
+	return std.MustSortJSON(amino.MustMarshalJSON(msg))
+}
+
+// Implements Msg.
+func (msg MsgUpgradePackage) GetSigners() []crypto.Address {
+	return []crypto.Address{msg.Creator}
+}
+
+// Implements ReceiveMsg.
+func (msg MsgUpgradePackage) GetReceived() std.Coins {
+	return msg.Deposit
+}
+
 //----------------------------------------
 // MsgCall
 
 // MsgCall - executes a Gno statement.
 type MsgCall struct {
-	Caller  crypto.Address `json:"caller" yaml:"caller"`
-	Send    std.Coins      `json:"send" yaml:"send"`
-	PkgPath string         `json:"pkg_path" yaml:"pkg_path"`
-	Func    string         `json:"func" yaml:"func"`
-	Args    []string       `json:"args" yaml:"args"`
+	Caller       crypto.Address `json:"caller" yaml:"caller"`
+	Send         std.Coins      `json:"send" yaml:"send"`
+	PkgPath      string         `json:"pkg_path" yaml:"pkg_path"`
+	Func         string         `json:"func" yaml:"func"`
+	Args         []string       `json:"args" yaml:"args"`
+	ArgsFormat   string         `json:"args_format,omitempty" yaml:"args_format"`
+	ResultFormat string         `json:"result_format,omitempty" yaml:"result_format"`
 }
 
+// Formats of MsgCall.Args.
+const (
+	ArgsFormatString = ""     // primitive values, see convertArgToGno()
+	ArgsFormatJSON   = "json" // JSON values, see convertJSONArgToGno()
+)
+
+// Formats of MsgCall results.
+const (
+	ResultFormatString = ""     // one result per line, see gno.TypedValue.String()
+	ResultFormatJSON   = "json" // JSON array of results, see gno.JSONTypedValue
+)
+
 var _ std.Msg = MsgCall{}
 
 func NewMsgCall(caller crypto.Address, send sdk.Coins, pkgPath, fnc string, args []string) MsgCall {
@@ -119,6 +190,12 @@ func (msg MsgCall) ValidateBasic() error {
 	if msg.Func == "" { // XXX
 		return ErrInvalidExpr("missing function to call")
 	}
+	if msg.ArgsFormat != ArgsFormatString && msg.ArgsFormat != ArgsFormatJSON {
+		return ErrInvalidExpr(fmt.Sprintf("unknown args format %q", msg.ArgsFormat))
+	}
+	if msg.ResultFormat != ResultFormatString && msg.ResultFormat != ResultFormatJSON {
+		return ErrInvalidExpr(fmt.Sprintf("unknown result format %q", msg.ResultFormat))
+	}
 	return nil
 }
 
@@ -15,9 +15,13 @@ var Package = amino.RegisterPackage(amino.NewPackage(
 	MsgCall{}, "m_call",
 	MsgRun{}, "m_run",
 	MsgAddPackage{}, "m_addpkg", // TODO rename both to MsgAddPkg?
+	MsgUpgradePackage{}, "m_upgradepkg",
 
 	// errors
 	InvalidPkgPathError{}, "InvalidPkgPathError",
 	InvalidStmtError{}, "InvalidStmtError",
 	InvalidExprError{}, "InvalidExprError",
+	InvalidStoreKeyError{}, "InvalidStoreKeyError",
+	UnauthorizedNamespaceError{}, "UnauthorizedNamespaceError",
+	UnauthorizedUpgradeError{}, "UnauthorizedUpgradeError",
 ))
@@ -0,0 +1,54 @@
+package vm
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gnolang/gno/tm2/pkg/amino"
+	"github.com/gnolang/gno/tm2/pkg/sdk"
+	"github.com/gnolang/gno/tm2/pkg/std"
+)
+
+// ParamsKey is the key of the vm params in the params keeper.
+const ParamsKey = "vm.params"
+
+// Params defines the parameters for the vm module.
+type Params struct {
+	MaxCycles  int64     `json:"max_cycles" yaml:"max_cycles"`   // must be positive.
+	MinDeposit std.Coins `json:"min_deposit" yaml:"min_deposit"` // required to add or upgrade a package.
+}
+
+// Equals returns a boolean determining if two Params types are identical.
+func (p Params) Equals(p2 Params) bool {
+	return amino.DeepEqual(p, p2)
+}
+
+// Validate implements params.Validator.
+func (p Params) Validate() error {
+	if p.MaxCycles <= 0 {
+		return fmt.Errorf("invalid max cycles: %d", p.MaxCycles)
+	}
+	if !p.MinDeposit.IsValid() && !p.MinDeposit.IsZero() {
+		return fmt.Errorf("invalid min deposit: %s", p.MinDeposit)
+	}
+	return nil
+}
+
+// String implements the stringer interface.
+func (p Params) String() string {
+	var sb strings.Builder
+	sb.WriteString("Params: \n")
+	sb.WriteString(fmt.Sprintf("MaxCycles: %d\n", p.MaxCycles))
+	sb.WriteString(fmt.Sprintf("MinDeposit: %s\n", p.MinDeposit))
+	return sb.String()
+}
+
+// GetParams returns the vm params set on chain, or the defaults the keeper
+// was constructed with.
+func (vm *VMKeeper) GetParams(ctx sdk.Context) Params {
+	params := Params{MaxCycles: vm.maxCycles}
+	if vm.prmk != nil {
+		vm.prmk.GetParam(ctx, ParamsKey, &params)
+	}
+	return params
+}
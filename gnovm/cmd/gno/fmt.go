@@ -0,0 +1,527 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"golang.org/x/tools/go/ast/astutil"
+
+	"github.com/gnolang/gno/gnovm/pkg/gnoenv"
+	"github.com/gnolang/gno/gnovm/pkg/gnomod"
+	"github.com/gnolang/gno/tm2/pkg/commands"
+)
+
+type fmtCfg struct {
+	write   bool
+	list    bool
+	imports bool
+	rootDir string
+}
+
+func newFmtCmd(io commands.IO) *commands.Command {
+	cfg := &fmtCfg{}
+
+	return commands.NewCommand(
+		commands.Metadata{
+			Name:       "fmt",
+			ShortUsage: "fmt [flags] <file or package> [<file or package>...]",
+			ShortHelp:  "formats the specified gno files",
+			LongHelp: `Formats the specified gno files, or the gno files of the specified directories,
+in the canonical gofmt style.
+
+By default, the formatted files are printed to the standard output. The 'w' flag
+writes them in place instead, and the 'l' flag lists the files whose formatting
+differs.
+
+The 'imports' flag also removes the unused imports, and adds the missing ones:
+the packages are resolved by name from the standard libraries and the packages
+of the examples directory of the gno root directory, which declare the
+referenced exported names. The standard libraries are grouped before the other
+packages, as goimports does.
+`,
+		},
+		cfg,
+		func(_ context.Context, args []string) error {
+			return execFmt(cfg, args, io)
+		},
+	)
+}
+
+func (c *fmtCfg) RegisterFlags(fs *flag.FlagSet) {
+	fs.BoolVar(
+		&c.write,
+		"w",
+		false,
+		"write the result to the source files instead of the standard output",
+	)
+
+	fs.BoolVar(
+		&c.list,
+		"l",
+		false,
+		"list the files whose formatting differs",
+	)
+
+	fs.BoolVar(
+		&c.imports,
+		"imports",
+		false,
+		"add the missing imports and remove the unused ones",
+	)
+
+	fs.StringVar(
+		&c.rootDir,
+		"root-dir",
+		"",
+		"clone location of github.com/gnolang/gno (gno binary tries to guess it)",
+	)
+}
+
+func execFmt(cfg *fmtCfg, args []string, io commands.IO) error {
+	if len(args) == 0 {
+		return flag.ErrHelp
+	}
+
+	paths, err := gnoFilesFromArgs(args)
+	if err != nil {
+		return fmt.Errorf("list files: %w", err)
+	}
+
+	var resolver *importResolver
+	if cfg.imports {
+		if cfg.rootDir == "" {
+			cfg.rootDir = gnoenv.RootDir()
+		}
+		resolver, err = newImportResolver(cfg.rootDir)
+		if err != nil {
+			return fmt.Errorf("list packages: %w", err)
+		}
+	}
+
+	errCount := 0
+	for _, path := range paths {
+		if err := fmtFile(cfg, path, resolver, io); err != nil {
+			io.ErrPrintln(err)
+			errCount++
+		}
+	}
+	if errCount > 0 {
+		return fmt.Errorf("%d file(s) could not be formatted", errCount)
+	}
+	return nil
+}
+
+func fmtFile(cfg *fmtCfg, path string, resolver *importResolver, io commands.IO) error {
+	src, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	res, err := formatGnoFile(path, src, resolver)
+	if err != nil {
+		return err
+	}
+
+	if !cfg.list && !cfg.write {
+		_, err := io.Out().Write(res)
+		return err
+	}
+	if bytes.Equal(src, res) {
+		return nil
+	}
+	if cfg.list {
+		io.Println(path)
+	}
+	if cfg.write {
+		info, err := os.Stat(path)
+		if err != nil {
+			return err
+		}
+		if err := os.WriteFile(path, res, info.Mode().Perm()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// formatGnoFile returns the source of the gno file formatted in the gofmt
+// style, with its imports fixed if resolver is not nil.
+func formatGnoFile(filename string, src []byte, resolver *importResolver) ([]byte, error) {
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, filename, src, parser.ParseComments)
+	if err != nil {
+		return nil, err
+	}
+	if resolver != nil {
+		if err := resolver.fixImports(fset, f, filepath.Dir(filename)); err != nil {
+			return nil, err
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := format.Node(&buf, fset, f); err != nil {
+		return nil, fmt.Errorf("%s: %w", filename, err)
+	}
+	if resolver != nil {
+		return resolver.groupImports(filename, buf.Bytes())
+	}
+	return buf.Bytes(), nil
+}
+
+// importResolver resolves the packages imported by gno files by name, from the
+// standard libraries and the packages of the examples directory.
+type importResolver struct {
+	byPath map[string]*knownPkg
+	byName map[string][]*knownPkg // stdlibs first, then by path length.
+}
+
+type knownPkg struct {
+	path    string
+	name    string
+	dir     string
+	stdlib  bool
+	exports map[string]bool // exported names, loaded on first use.
+}
+
+func newImportResolver(rootDir string) (*importResolver, error) {
+	r := &importResolver{
+		byPath: make(map[string]*knownPkg),
+		byName: make(map[string][]*knownPkg),
+	}
+
+	// standard libraries, by their path relative to the stdlibs directory.
+	stdlibsDir := filepath.Join(rootDir, "gnovm", "stdlibs")
+	err := filepath.WalkDir(stdlibsDir, func(path string, d os.DirEntry, err error) error {
+		if err != nil || !d.IsDir() || path == stdlibsDir {
+			return err
+		}
+		rel, err := filepath.Rel(stdlibsDir, path)
+		if err != nil {
+			return err
+		}
+		return r.add(filepath.ToSlash(rel), path, true)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// examples packages, by their gno.mod module path.
+	pkgs, err := gnomod.ListPkgs(filepath.Join(rootDir, "examples"))
+	if err != nil {
+		return nil, err
+	}
+	for _, pkg := range pkgs {
+		if err := r.add(pkg.Name, pkg.Dir, false); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, pkgs := range r.byName {
+		sort.Slice(pkgs, func(i, j int) bool {
+			pi, pj := pkgs[i], pkgs[j]
+			switch {
+			case pi.stdlib != pj.stdlib:
+				return pi.stdlib
+			case len(pi.path) != len(pj.path):
+				return len(pi.path) < len(pj.path)
+			default:
+				return pi.path < pj.path
+			}
+		})
+	}
+	return r, nil
+}
+
+// add records the package of dir, imported at path, if dir has gno files.
+func (r *importResolver) add(path, dir string, stdlib bool) error {
+	name, err := dirPackageName(dir)
+	if err != nil || name == "" {
+		return err
+	}
+	pkg := &knownPkg{path: path, name: name, dir: dir, stdlib: stdlib}
+	r.byPath[path] = pkg
+	r.byName[name] = append(r.byName[name], pkg)
+	return nil
+}
+
+// lookup returns the package named name declaring all the exported names
+// sels, or nil if there is none.
+func (r *importResolver) lookup(name string, sels map[string]bool) (*knownPkg, error) {
+	for _, pkg := range r.byName[name] {
+		if pkg.exports == nil {
+			exports, err := dirExports(pkg.dir, pkg.name)
+			if err != nil {
+				return nil, err
+			}
+			pkg.exports = exports
+		}
+		found := true
+		for sel := range sels {
+			if !pkg.exports[sel] {
+				found = false
+				break
+			}
+		}
+		if found {
+			return pkg, nil
+		}
+	}
+	return nil, nil
+}
+
+// fixImports removes the unused imports of f, the file of a package in dir,
+// and adds the packages it refers to which are not imported. The imports of
+// unknown packages are kept.
+func (r *importResolver) fixImports(fset *token.FileSet, f *ast.File, dir string) error {
+	declared, err := packageDecls(dir, f.Name.Name)
+	if err != nil {
+		return err
+	}
+
+	// the exported names selected from each unresolved name.
+	unresolved := make(map[*ast.Ident]bool, len(f.Unresolved))
+	for _, id := range f.Unresolved {
+		unresolved[id] = true
+	}
+	refs := make(map[string]map[string]bool)
+	ast.Inspect(f, func(n ast.Node) bool {
+		sel, ok := n.(*ast.SelectorExpr)
+		if !ok {
+			return true
+		}
+		id, ok := sel.X.(*ast.Ident)
+		if !ok || !unresolved[id] || declared[id.Name] {
+			return true
+		}
+		if refs[id.Name] == nil {
+			refs[id.Name] = make(map[string]bool)
+		}
+		refs[id.Name][sel.Sel.Name] = true
+		return true
+	})
+
+	imported := make(map[string]bool)
+	for _, spec := range append([]*ast.ImportSpec(nil), f.Imports...) {
+		path, err := strconv.Unquote(spec.Path.Value)
+		if err != nil {
+			return err
+		}
+		var name, alias string
+		if spec.Name != nil {
+			name, alias = spec.Name.Name, spec.Name.Name
+		} else if pkg := r.byPath[path]; pkg != nil {
+			name = pkg.name
+		} else {
+			// unknown package: keep it, assuming its name from its path.
+			imported[path[strings.LastIndexByte(path, '/')+1:]] = true
+			continue
+		}
+
+		switch {
+		case name == "_" || name == ".":
+		case refs[name] == nil:
+			astutil.DeleteNamedImport(fset, f, alias, path)
+		default:
+			imported[name] = true
+		}
+	}
+
+	names := make([]string, 0, len(refs))
+	for name := range refs {
+		if !imported[name] {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		pkg, err := r.lookup(name, refs[name])
+		if err != nil {
+			return err
+		}
+		if pkg != nil {
+			astutil.AddImport(fset, f, pkg.path)
+		}
+	}
+	return nil
+}
+
+// groupImports returns src, a formatted gno file, with the imports of each
+// import block in two groups, as goimports does: the standard libraries, then
+// the other packages. The blocks containing comments are left untouched.
+func (r *importResolver) groupImports(filename string, src []byte) ([]byte, error) {
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, filename, src, parser.ImportsOnly|parser.ParseComments)
+	if err != nil {
+		return nil, err
+	}
+
+	type importLine struct{ path, line string }
+	var (
+		buf  bytes.Buffer
+		last int
+	)
+	for _, decl := range f.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.IMPORT || !gen.Lparen.IsValid() || hasCommentIn(f, gen.Lparen, gen.Rparen) {
+			continue
+		}
+
+		var stdlibs, others []importLine
+		for _, spec := range gen.Specs {
+			spec := spec.(*ast.ImportSpec)
+			path, err := strconv.Unquote(spec.Path.Value)
+			if err != nil {
+				return nil, err
+			}
+			imp := importLine{path: path, line: spec.Path.Value}
+			if spec.Name != nil {
+				imp.line = spec.Name.Name + " " + imp.line
+			}
+			if r.isStdlib(path) {
+				stdlibs = append(stdlibs, imp)
+			} else {
+				others = append(others, imp)
+			}
+		}
+
+		start := fset.Position(gen.Lparen).Offset + 1
+		buf.Write(src[last:start])
+		buf.WriteByte('\n')
+		for i, group := range [][]importLine{stdlibs, others} {
+			if i > 0 && len(stdlibs) > 0 && len(others) > 0 {
+				buf.WriteByte('\n')
+			}
+			sort.SliceStable(group, func(i, j int) bool { return group[i].path < group[j].path })
+			for _, imp := range group {
+				buf.WriteString("\t" + imp.line + "\n")
+			}
+		}
+		last = fset.Position(gen.Rparen).Offset
+	}
+	buf.Write(src[last:])
+	return buf.Bytes(), nil
+}
+
+// isStdlib reports whether path is the path of a standard library: unknown
+// packages are, as for go, if the first element of their path has no dot.
+func (r *importResolver) isStdlib(path string) bool {
+	if pkg := r.byPath[path]; pkg != nil {
+		return pkg.stdlib
+	}
+	elem, _, _ := strings.Cut(path, "/")
+	return !strings.Contains(elem, ".")
+}
+
+// hasCommentIn reports whether f has a comment between the positions from and
+// to.
+func hasCommentIn(f *ast.File, from, to token.Pos) bool {
+	for _, c := range f.Comments {
+		if c.Pos() > from && c.End() < to {
+			return true
+		}
+	}
+	return false
+}
+
+// dirPackageName returns the name of the package of the gno files of dir, or
+// "" if it has none.
+func dirPackageName(dir string) (string, error) {
+	files, err := dirGnoFiles(dir)
+	if err != nil || len(files) == 0 {
+		return "", err
+	}
+	f, err := parser.ParseFile(token.NewFileSet(), files[0], nil, parser.PackageClauseOnly)
+	if err != nil {
+		return "", err
+	}
+	return f.Name.Name, nil
+}
+
+// dirExports returns the exported names declared by the package pkgName of
+// dir.
+func dirExports(dir, pkgName string) (map[string]bool, error) {
+	names, err := packageDecls(dir, pkgName)
+	if err != nil {
+		return nil, err
+	}
+	for name := range names {
+		if !ast.IsExported(name) {
+			delete(names, name)
+		}
+	}
+	return names, nil
+}
+
+// packageDecls returns the top-level names declared by the files of the
+// package pkgName in dir, test files included.
+func packageDecls(dir, pkgName string) (map[string]bool, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make(map[string]bool)
+	fset := token.NewFileSet()
+	for _, entry := range entries {
+		if !isGnoFile(entry) {
+			continue
+		}
+		f, err := parser.ParseFile(fset, filepath.Join(dir, entry.Name()), nil, parser.SkipObjectResolution)
+		if err != nil {
+			// the package is being edited: its invalid files are ignored.
+			continue
+		}
+		if f.Name.Name != pkgName {
+			continue
+		}
+		for _, decl := range f.Decls {
+			switch decl := decl.(type) {
+			case *ast.FuncDecl:
+				if decl.Recv == nil {
+					names[decl.Name.Name] = true
+				}
+			case *ast.GenDecl:
+				for _, spec := range decl.Specs {
+					switch spec := spec.(type) {
+					case *ast.TypeSpec:
+						names[spec.Name.Name] = true
+					case *ast.ValueSpec:
+						for _, id := range spec.Names {
+							names[id.Name] = true
+						}
+					}
+				}
+			}
+		}
+	}
+	return names, nil
+}
+
+// dirGnoFiles returns the paths of the gno files of dir, test files excluded.
+func dirGnoFiles(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	var files []string
+	for _, entry := range entries {
+		name := entry.Name()
+		if !isGnoFile(entry) ||
+			strings.HasSuffix(name, "_test.gno") ||
+			strings.HasSuffix(name, "_filetest.gno") {
+			continue
+		}
+		files = append(files, filepath.Join(dir, name))
+	}
+	return files, nil
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"flag"
 	"fmt"
+	"net"
 	"os"
 	"path/filepath"
 	"strings"
@@ -16,9 +17,11 @@ import (
 )
 
 type runCfg struct {
-	verbose bool
-	rootDir string
-	expr    string
+	verbose   bool
+	rootDir   string
+	expr      string
+	debug     bool
+	debugAddr string
 }
 
 func newRunCmd(io commands.IO) *commands.Command {
@@ -29,6 +32,14 @@ func newRunCmd(io commands.IO) *commands.Command {
 			Name:       "run",
 			ShortUsage: "run [flags] <file> [<file>...]",
 			ShortHelp:  "runs the specified gno files",
+			LongHelp: `Runs the specified gno files.
+
+The 'debug' flag runs the program under the interactive debugger, which stops
+before the first statement and reads its commands from the standard input; type
+'help' at its prompt for the list of commands. With the 'debug-addr' flag, the
+debugger waits for a Debug Adapter Protocol client, like an editor, to connect
+to the address instead.
+`,
 		},
 		cfg,
 		func(_ context.Context, args []string) error {
@@ -58,9 +69,23 @@ func (c *runCfg) RegisterFlags(fs *flag.FlagSet) {
 		"main()",
 		"value of expression to evaluate. Defaults to executing function main() with no args",
 	)
+
+	fs.BoolVar(
+		&c.debug,
+		"debug",
+		false,
+		"run the program under the interactive debugger",
+	)
+
+	fs.StringVar(
+		&c.debugAddr,
+		"debug-addr",
+		"",
+		"address to serve the debugger on, for a Debug Adapter Protocol client (sets -debug)",
+	)
 }
 
-func execRun(cfg *runCfg, args []string, io commands.IO) error {
+func execRun(cfg *runCfg, args []string, io commands.IO) (err error) {
 	if len(args) == 0 {
 		return flag.ErrHelp
 	}
@@ -95,10 +120,29 @@ func execRun(cfg *runCfg, args []string, io commands.IO) error {
 		return errors.New("no files to run")
 	}
 
+	var debugger *gno.Debugger
+	if cfg.debug || cfg.debugAddr != "" {
+		debugger, err = newDebugger(cfg.debugAddr, io)
+		if err != nil {
+			return err
+		}
+		defer debugger.Close()
+		defer func() {
+			// the program stops when the user exits the debugger.
+			if r := recover(); r != nil && !debugger.Exited() {
+				panic(r)
+			}
+		}()
+		if err := addDebugSources(debugger, string(files[0].PkgName), args); err != nil {
+			return err
+		}
+	}
+
 	m := gno.NewMachineWithOptions(gno.MachineOptions{
-		PkgPath: string(files[0].PkgName),
-		Output:  stdout,
-		Store:   testStore,
+		PkgPath:  string(files[0].PkgName),
+		Output:   stdout,
+		Store:    testStore,
+		Debugger: debugger,
 	})
 
 	defer m.Release()
@@ -134,6 +178,51 @@ func parseFiles(fnames []string) ([]*gno.FileNode, error) {
 	return files, nil
 }
 
+// newDebugger returns a debugger reading its commands from the standard input,
+// or served to the first Debug Adapter Protocol client connecting to addr, if
+// set.
+func newDebugger(addr string, io commands.IO) (*gno.Debugger, error) {
+	if addr == "" {
+		return gno.NewDebugger(io.In(), io.Err()), nil
+	}
+
+	l, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, fmt.Errorf("listen for debugger client: %w", err)
+	}
+	defer l.Close()
+	io.ErrPrintfln("Waiting for debugger client to connect at %s", l.Addr())
+	conn, err := l.Accept()
+	if err != nil {
+		return nil, fmt.Errorf("accept debugger client: %w", err)
+	}
+	io.ErrPrintfln("Debugger client connected from %s", conn.RemoteAddr())
+	return gno.NewDAPDebugger(conn)
+}
+
+// addDebugSources records the sources of the files run, found like parseFiles
+// does, for the debugger to list them and stop at their breakpoints.
+func addDebugSources(debugger *gno.Debugger, pkgPath string, fnames []string) error {
+	for _, fname := range fnames {
+		if s, err := os.Stat(fname); err == nil && s.IsDir() {
+			subFns, err := listNonTestFiles(fname)
+			if err != nil {
+				return err
+			}
+			if err := addDebugSources(debugger, pkgPath, subFns); err != nil {
+				return err
+			}
+			continue
+		}
+		bz, err := os.ReadFile(fname)
+		if err != nil {
+			return err
+		}
+		debugger.AddSource(pkgPath, fname, fname, string(bz))
+	}
+	return nil
+}
+
 func listNonTestFiles(dir string) ([]string, error) {
 	fs, err := os.ReadDir(dir)
 	if err != nil {
@@ -154,8 +243,10 @@ func listNonTestFiles(dir string) ([]string, error) {
 func runExpr(m *gno.Machine, expr string) {
 	defer func() {
 		if r := recover(); r != nil {
-			fmt.Printf("panic running expression %s: %v\n%s\n",
-				expr, r, m.String())
+			if r != gno.ErrDebuggerExit {
+				fmt.Printf("panic running expression %s: %v\n%s\n",
+					expr, r, m.String())
+			}
 			panic(r)
 		}
 	}()
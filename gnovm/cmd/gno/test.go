@@ -3,14 +3,22 @@ package main
 import (
 	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"flag"
 	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io"
 	"log"
+	"math"
 	"os"
 	"path/filepath"
 	"runtime/debug"
 	"sort"
+	"strconv"
 	"strings"
 	"text/template"
 	"time"
@@ -38,10 +46,18 @@ type testCfg struct {
 	updateGoldenTests   bool
 	printRuntimeMetrics bool
 	withNativeFallback  bool
+	cover               bool
+	coverProfile        string
+	debug               bool
+	debugAddr           string
+	bench               string
+	benchTime           benchTime
+	fuzz                string
+	fuzzTime            benchTime
 }
 
 func newTestCmd(io commands.IO) *commands.Command {
-	cfg := &testCfg{}
+	cfg := &testCfg{benchTime: benchTime{d: time.Second}}
 
 	return commands.NewCommand(
 		commands.Metadata{
@@ -56,8 +72,8 @@ file pattern "*_test.gno" or "*_filetest.gno".
 The <package> can be directory or file path (relative or absolute).
 
 - "*_test.gno" files work like "*_test.go" files, but they contain only test
-functions. Benchmark and fuzz functions aren't supported yet. Similarly, only
-tests that belong to the same package are supported for now (no "xxx_test").
+functions, benchmarks and fuzz tests. Similarly, only tests that belong to the
+same package are supported for now (no "xxx_test").
 
 The package path used to execute the "*_test.gno" file is fetched from the
 module name found in 'gno.mod', or else it is randomly generated like
@@ -93,6 +109,28 @@ List of available instructions that can be used in "*_filetest.gno" files:
 
 (*) The 'update-golden-tests' flag can be set to fill out the content of the
 instruction with the actual content of the test instead of failing.
+
+The 'cover' flag reports the percentage of the statements of each package
+executed by its tests, and the 'coverprofile' flag writes the coverage of the
+packages to a file which can be read by 'go tool cover'.
+
+The 'debug' flag runs the tests under the interactive debugger, as 'gno run'
+does; with the 'debug-addr' flag, it waits for a Debug Adapter Protocol
+client, like an editor, to connect to the address instead.
+
+The 'bench' flag also runs the benchmarks matching its pattern, the functions
+of the form "func BenchmarkXxx(b *testing.B)" which run their target code b.N
+times. For each benchmark, the time, the VM cycles, and the bytes and number
+of allocations of the VM are reported per iteration.
+
+The fuzz tests, the functions of the form "func FuzzXxx(f *testing.F)", run
+their fuzz target, the function passed to f.Fuzz, on the seed corpus added with
+f.Add and on the corpus of the files of testdata/fuzz/FuzzXxx. The arguments of
+the target may be strings, byte slices, booleans and integers. The 'fuzz' flag
+also fuzzes the fuzz test matching its pattern, running its target on inputs
+mutated from the corpus until one fails, or for the duration of the 'fuzztime'
+flag: the failing input is then minimized and written to testdata/fuzz/FuzzXxx,
+so that it is run by the next tests.
 `,
 		},
 		cfg,
@@ -158,6 +196,91 @@ func (c *testCfg) RegisterFlags(fs *flag.FlagSet) {
 		false,
 		"print runtime metrics (gas, memory, cpu cycles)",
 	)
+
+	fs.BoolVar(
+		&c.cover,
+		"cover",
+		false,
+		"enable coverage analysis",
+	)
+
+	fs.StringVar(
+		&c.coverProfile,
+		"coverprofile",
+		"",
+		"write a coverage profile to the file after all tests have run (sets -cover)",
+	)
+
+	fs.BoolVar(
+		&c.debug,
+		"debug",
+		false,
+		"run the tests under the interactive debugger",
+	)
+
+	fs.StringVar(
+		&c.debugAddr,
+		"debug-addr",
+		"",
+		"address to serve the debugger on, for a Debug Adapter Protocol client (sets -debug)",
+	)
+
+	fs.StringVar(
+		&c.bench,
+		"bench",
+		"",
+		"run the benchmarks matching the pattern",
+	)
+
+	fs.Var(
+		&c.benchTime,
+		"benchtime",
+		"run each benchmark for the duration, or the number of iterations with the form Nx",
+	)
+
+	fs.StringVar(
+		&c.fuzz,
+		"fuzz",
+		"",
+		"fuzz the fuzz test matching the pattern",
+	)
+
+	fs.Var(
+		&c.fuzzTime,
+		"fuzztime",
+		"fuzz for the duration, or the number of inputs with the form Nx (default unlimited)",
+	)
+}
+
+// benchTime is the value of the benchtime and fuzztime flags: a duration, or
+// a number of iterations of the form Nx.
+type benchTime struct {
+	d time.Duration
+	n int
+}
+
+func (f *benchTime) String() string {
+	if f.n > 0 {
+		return fmt.Sprintf("%dx", f.n)
+	}
+	return f.d.String()
+}
+
+func (f *benchTime) Set(s string) error {
+	if strings.HasSuffix(s, "x") {
+		n, err := strconv.Atoi(s[:len(s)-1])
+		if err != nil || n <= 0 {
+			return fmt.Errorf("invalid count %q", s)
+		}
+		*f = benchTime{n: n}
+		return nil
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil || d <= 0 {
+		return fmt.Errorf("invalid duration %q", s)
+	}
+	*f = benchTime{d: d}
+	return nil
 }
 
 func execTest(cfg *testCfg, args []string, io commands.IO) error {
@@ -206,8 +329,26 @@ func execTest(cfg *testCfg, args []string, io commands.IO) error {
 		return fmt.Errorf("list sub packages: %w", err)
 	}
 
+	if cfg.coverProfile != "" {
+		cfg.cover = true
+	}
+
+	if cfg.fuzz != "" && len(subPkgs) > 1 {
+		return errors.New("cannot use -fuzz flag with multiple packages")
+	}
+
+	var debugger *gno.Debugger
+	if cfg.debug || cfg.debugAddr != "" {
+		debugger, err = newDebugger(cfg.debugAddr, io)
+		if err != nil {
+			return err
+		}
+		defer debugger.Close()
+	}
+
 	buildErrCount := 0
 	testErrCount := 0
+	coverages := []*gno.Coverage{}
 	for _, pkg := range subPkgs {
 		if cfg.transpile {
 			if verbose {
@@ -254,8 +395,17 @@ func execTest(cfg *testCfg, args []string, io commands.IO) error {
 		sort.Strings(pkg.TestGnoFiles)
 		sort.Strings(pkg.FiletestGnoFiles)
 
+		var coverage *gno.Coverage
+		if cfg.cover {
+			coverage = gno.NewCoverage()
+			coverages = append(coverages, coverage)
+		}
+
 		startedAt := time.Now()
-		err = gnoTestPkg(pkg.Dir, pkg.TestGnoFiles, pkg.FiletestGnoFiles, cfg, io)
+		err = gnoTestPkg(pkg.Dir, pkg.TestGnoFiles, pkg.FiletestGnoFiles, cfg, coverage, debugger, io)
+		if err == gno.ErrDebuggerExit {
+			return nil
+		}
 		duration := time.Since(startedAt)
 		dstr := fmtDuration(duration)
 
@@ -265,10 +415,17 @@ func execTest(cfg *testCfg, args []string, io commands.IO) error {
 			io.ErrPrintfln("FAIL    %s \t%s", pkg.Dir, dstr)
 			io.ErrPrintfln("FAIL")
 			testErrCount++
+		} else if coverage != nil {
+			io.ErrPrintfln("ok      %s \t%s\t%s", pkg.Dir, dstr, coverage)
 		} else {
 			io.ErrPrintfln("ok      %s \t%s", pkg.Dir, dstr)
 		}
 	}
+	if cfg.coverProfile != "" {
+		if err := writeCoverProfile(cfg.coverProfile, coverages); err != nil {
+			return fmt.Errorf("write cover profile: %w", err)
+		}
+	}
 	if testErrCount > 0 || buildErrCount > 0 {
 		io.ErrPrintfln("FAIL")
 		return fmt.Errorf("FAIL: %d build errors, %d test errors", buildErrCount, testErrCount)
@@ -282,8 +439,10 @@ func gnoTestPkg(
 	unittestFiles,
 	filetestFiles []string,
 	cfg *testCfg,
+	coverage *gno.Coverage,
+	debugger *gno.Debugger,
 	io commands.IO,
-) error {
+) (errs error) {
 	var (
 		verbose             = cfg.verbose
 		rootDir             = cfg.rootDir
@@ -293,7 +452,6 @@ func gnoTestPkg(
 		stdin  = io.In()
 		stdout = io.Out()
 		stderr = io.Err()
-		errs   error
 	)
 
 	mode := tests.ImportModeStdlibsOnly
@@ -307,10 +465,12 @@ func gnoTestPkg(
 		stdout = commands.WriteNopCloser(mockOut)
 	}
 
-	// testing with *_test.gno
-	if len(unittestFiles) > 0 {
-		// Determine gnoPkgPath by reading gno.mod
-		var gnoPkgPath string
+	// Determine gnoPkgPath by reading gno.mod
+	var (
+		gnoPkgPath string
+		memPkg     *std.MemPackage
+	)
+	if len(unittestFiles) > 0 || coverage != nil || debugger != nil {
 		modfile, err := gnomod.ParseAt(pkgPath)
 		if err == nil {
 			gnoPkgPath = modfile.Module.Mod.Path
@@ -322,7 +482,30 @@ func gnoTestPkg(
 				gnoPkgPath = transpiler.GnoRealmPkgsPrefixBefore + random.RandStr(8)
 			}
 		}
-		memPkg := gno.ReadMemPackage(pkgPath, gnoPkgPath)
+		memPkg = gno.ReadMemPackage(pkgPath, gnoPkgPath)
+	}
+
+	// record the statements of the package files, to be covered.
+	if coverage != nil {
+		if err := addCoverFiles(coverage, pkgPath, memPkg); err != nil {
+			return err
+		}
+	}
+	if debugger != nil {
+		addDebugFiles(debugger, pkgPath, memPkg)
+		// the remaining tests are skipped once the user exits the debugger.
+		defer func() {
+			r := recover()
+			if debugger.Exited() {
+				errs = gno.ErrDebuggerExit
+			} else if r != nil {
+				panic(r)
+			}
+		}()
+	}
+
+	// testing with *_test.gno
+	if len(unittestFiles) > 0 {
 
 		// tfiles, ifiles := gno.ParseMemPackageTests(memPkg)
 		tfiles, ifiles := parseMemPackageTests(memPkg)
@@ -340,6 +523,8 @@ func gnoTestPkg(
 			}
 
 			m := tests.TestMachine(testStore, stdout, gnoPkgPath)
+			m.Coverage = coverage
+			m.Debugger = debugger
 			if printRuntimeMetrics {
 				// from tm2/pkg/sdk/vm/keeper.go
 				// XXX: make maxAllocTx configurable.
@@ -348,7 +533,7 @@ func gnoTestPkg(
 				m.Alloc = gno.NewAllocator(maxAllocTx)
 			}
 			m.RunMemPackage(memPkg, true)
-			err := runTestFiles(m, tfiles, memPkg.Name, verbose, printRuntimeMetrics, runFlag, io)
+			err := runTestFiles(m, tfiles, memPkg.Name, pkgPath, verbose, printRuntimeMetrics, runFlag, cfg, io)
 			if err != nil {
 				errs = multierr.Append(errs, err)
 			}
@@ -366,6 +551,8 @@ func gnoTestPkg(
 			}
 
 			m := tests.TestMachine(testStore, stdout, testPkgName)
+			m.Coverage = coverage
+			m.Debugger = debugger
 
 			memFiles := make([]*std.MemFile, 0, len(ifiles.FileNames())+1)
 			for _, f := range memPkg.Files {
@@ -382,7 +569,7 @@ func gnoTestPkg(
 			memPkg.Path = memPkg.Path + "_test"
 			m.RunMemPackage(memPkg, true)
 
-			err := runTestFiles(m, ifiles, testPkgName, verbose, printRuntimeMetrics, runFlag, io)
+			err := runTestFiles(m, ifiles, testPkgName, pkgPath, verbose, printRuntimeMetrics, runFlag, cfg, io)
 			if err != nil {
 				errs = multierr.Append(errs, err)
 			}
@@ -410,7 +597,14 @@ func gnoTestPkg(
 			}
 
 			testFilePath := filepath.Join(pkgPath, testFileName)
-			err := tests.RunFileTest(rootDir, testFilePath, tests.WithSyncWanted(cfg.updateGoldenTests))
+			err := tests.RunFileTest(rootDir, testFilePath,
+				tests.WithSyncWanted(cfg.updateGoldenTests),
+				tests.WithCoverage(coverage),
+				tests.WithDebugger(debugger),
+			)
+			if err == gno.ErrDebuggerExit {
+				return err
+			}
 			duration := time.Since(startedAt)
 			dstr := fmtDuration(duration)
 
@@ -437,6 +631,58 @@ func gnoTestPkg(
 	return errs
 }
 
+// addDebugFiles makes the files of memPkg, found in pkgDir, the sources of
+// debugger; the files of the xxx_test package are run in their own package.
+func addDebugFiles(debugger *gno.Debugger, pkgDir string, memPkg *std.MemPackage) {
+	debugger.ResetSources()
+	for _, mfile := range memPkg.Files {
+		if !strings.HasSuffix(mfile.Name, ".gno") || strings.HasSuffix(mfile.Name, "_filetest.gno") {
+			continue
+		}
+
+		pkgPath := memPkg.Path
+		if strings.HasSuffix(mfile.Name, "_test.gno") &&
+			gno.PackageNameFromFileBody(mfile.Name, mfile.Body) == gno.Name(memPkg.Name+"_test") {
+			pkgPath += "_test"
+		}
+		debugger.AddSource(pkgPath, mfile.Name, filepath.Join(pkgDir, mfile.Name), mfile.Body)
+	}
+}
+
+// addCoverFiles records the statements of the files of memPkg, found in
+// pkgDir, to be covered; the test files aren't covered.
+func addCoverFiles(coverage *gno.Coverage, pkgDir string, memPkg *std.MemPackage) error {
+	for _, mfile := range memPkg.Files {
+		if !strings.HasSuffix(mfile.Name, ".gno") || strings.HasSuffix(mfile.Name, "_test.gno") {
+			continue // _filetest.gno files too.
+		}
+
+		// go tool cover reads relative paths starting with "." from the
+		// current directory, and others as go import paths.
+		path := filepath.Join(pkgDir, mfile.Name)
+		if !filepath.IsAbs(path) {
+			path = "." + string(filepath.Separator) + path
+		}
+		if err := coverage.AddFile(memPkg.Path, mfile.Name, path, mfile.Body); err != nil {
+			return fmt.Errorf("parse %s: %w", path, err)
+		}
+	}
+	return nil
+}
+
+// writeCoverProfile writes the coverages of the tested packages as a Go cover
+// profile at path.
+func writeCoverProfile(path string, coverages []*gno.Coverage) error {
+	var buf bytes.Buffer
+	buf.WriteString("mode: set\n")
+	for _, coverage := range coverages {
+		if err := coverage.WriteProfile(&buf); err != nil {
+			return err
+		}
+	}
+	return os.WriteFile(path, buf.Bytes(), 0o644)
+}
+
 // attempts to determine the full gno pkg path by analyzing the directory.
 func pkgPathFromRootDir(pkgPath, rootDir string) string {
 	abPkgPath, err := filepath.Abs(pkgPath)
@@ -470,9 +716,11 @@ func runTestFiles(
 	m *gno.Machine,
 	files *gno.FileSet,
 	pkgName string,
+	pkgDir string,
 	verbose bool,
 	printRuntimeMetrics bool,
 	runFlag string,
+	cfg *testCfg,
 	io commands.IO,
 ) (errs error) {
 	defer func() {
@@ -485,9 +733,33 @@ func runTestFiles(
 		PackageName: pkgName,
 		Verbose:     verbose,
 		RunFlag:     runFlag,
+		BenchFlag:   cfg.bench,
+		BenchTime:   int64(cfg.benchTime.d),
+		BenchN:      cfg.benchTime.n,
+		FuzzTime:    int64(cfg.fuzzTime.d),
+		FuzzN:       cfg.fuzzTime.n,
 	}
 	loadTestFuncs(pkgName, testFuncs, files)
 
+	// the fuzz tests run on their corpus, and one of them is fuzzed.
+	var fuzzed []string
+	fuzzFilter := splitRegexp(cfg.fuzz)
+	for i := range testFuncs.FuzzTargets {
+		ft := &testFuncs.FuzzTargets[i]
+		corpus, err := readFuzzCorpus(filepath.Join(pkgDir, "testdata", "fuzz", ft.Name))
+		if err != nil {
+			return err
+		}
+		ft.Corpus = corpus
+		if cfg.fuzz != "" && shouldRun(fuzzFilter, ft.Name) {
+			ft.Fuzz = true
+			fuzzed = append(fuzzed, ft.Name)
+		}
+	}
+	if len(fuzzed) > 1 {
+		return fmt.Errorf("will not fuzz, -fuzz matches more than one fuzz test: %v", fuzzed)
+	}
+
 	// before/after statistics
 	numPackagesBefore := m.Store.NumMemPackages()
 
@@ -547,6 +819,61 @@ func runTestFiles(
 		}
 	}
 
+	for _, ft := range testFuncs.FuzzTargets {
+		rep, crashed, err := runFuzzTest(m, ft, io)
+		if err != nil {
+			errs = multierr.Append(errs, err)
+			io.ErrPrintfln("--- FAIL: %s [internal gno testing error]", ft.Name)
+			continue
+		}
+
+		if rep.Failed {
+			errs = multierr.Append(errs, errors.New("failed: %q", ft.Name))
+		}
+		if rep.Crasher != "" {
+			path, err := writeFuzzCorpusFile(filepath.Join(pkgDir, "testdata", "fuzz", ft.Name), rep.Crasher)
+			if err != nil {
+				errs = multierr.Append(errs, err)
+				continue
+			}
+			io.ErrPrintfln("    Failing input written to %s", path)
+			io.ErrPrintfln("    To re-run:")
+			io.ErrPrintfln("    gno test -run %s/%s %s", ft.Name, filepath.Base(path), pkgDir)
+		}
+		if crashed {
+			// the machine can't run anything else.
+			return errs
+		}
+	}
+
+	if cfg.bench == "" {
+		return errs
+	}
+	// the allocations of the benchmarks are measured, without limit.
+	alloc := m.Alloc
+	m.Alloc = gno.NewAllocator(math.MaxInt64)
+	defer func() { m.Alloc = alloc }()
+	for _, bench := range testFuncs.Benchmarks {
+		eval := m.Eval(gno.Call("runbench", fmt.Sprintf("%q", bench.Name)))
+
+		var results []benchmarkResult
+		if err := json.Unmarshal([]byte(eval[0].GetString()), &results); err != nil {
+			errs = multierr.Append(errs, err)
+			io.ErrPrintfln("--- FAIL: %s [internal gno testing error]", bench.Name)
+			continue
+		}
+
+		for _, res := range results {
+			switch {
+			case res.Failed:
+				errs = multierr.Append(errs, errors.New("failed: %q", res.Name))
+			case res.Skipped:
+			default:
+				io.ErrPrintfln("%s", res)
+			}
+		}
+	}
+
 	return errs
 }
 
@@ -556,6 +883,72 @@ type report struct {
 	Skipped bool
 }
 
+// runFuzzTest runs the fuzz test ft. If its fuzzing crashes the VM, the
+// crashing input is reported unminimized, and crashed is true.
+func runFuzzTest(m *gno.Machine, ft fuzzTarget, io commands.IO) (rep fuzzReport, crashed bool, err error) {
+	if ft.Fuzz {
+		defer func() {
+			r := recover()
+			if r == nil {
+				return
+			}
+			input := crashedFuzzInput(m)
+			if input == "" {
+				panic(r)
+			}
+			io.ErrPrintfln("--- FAIL: %s", ft.Name)
+			io.ErrPrintfln("    panic: %v", r)
+			rep, crashed = fuzzReport{Failed: true, Crasher: input}, true
+		}()
+	}
+
+	eval := m.Eval(gno.Call("runfuzz", fmt.Sprintf("%q", ft.Name)))
+	err = json.Unmarshal([]byte(eval[0].GetString()), &rep)
+	return rep, false, err
+}
+
+// crashedFuzzInput returns the input of the fuzz target being run when m
+// crashed, as a corpus file, or "" if none was.
+func crashedFuzzInput(m *gno.Machine) string {
+	cm := gno.NewMachineWithOptions(gno.MachineOptions{
+		PkgPath: "testing",
+		Store:   m.Store,
+		Output:  io.Discard,
+	})
+	defer cm.Release()
+	return cm.Eval(gno.Call("crashedFuzzInput"))[0].GetString()
+}
+
+// mirror of stdlibs/testing.FuzzReport
+type fuzzReport struct {
+	Failed  bool
+	Skipped bool
+	Crasher string
+}
+
+// mirror of stdlibs/testing.BenchmarkResult
+type benchmarkResult struct {
+	Name    string
+	N       int
+	T       int64
+	Cycles  int64
+	Bytes   int64
+	Allocs  int64
+	Failed  bool
+	Skipped bool
+}
+
+// String returns the result as printed by go test -bench -benchmem, with the
+// VM cycles.
+func (r benchmarkResult) String() string {
+	n := int64(r.N)
+	if n <= 0 {
+		n = 1
+	}
+	return fmt.Sprintf("%s\t%8d\t%10d ns/op\t%10d cycles/op\t%8d B/op\t%8d allocs/op",
+		r.Name, r.N, r.T/n, r.Cycles/n, r.Bytes/n, r.Allocs/n)
+}
+
 var testmainTmpl = template.Must(template.New("testmain").Parse(`
 package {{ .PackageName }}
 
@@ -578,13 +971,79 @@ func runtest(name string) (report string) {
 	panic("no such test: " + name)
 	return ""
 }
+
+var benchmarks = []testing.InternalBenchmark{
+{{range .Benchmarks}}
+    {"{{.Name}}", {{.Name}}},
+{{end}}
+}
+
+func runbench(name string) (report string) {
+	for _, bench := range benchmarks {
+		if bench.Name == name {
+			return testing.RunBenchmark({{printf "%q" .BenchFlag}}, {{.BenchTime}}, {{.BenchN}}, {{.Verbose}}, bench)
+		}
+	}
+	panic("no such benchmark: " + name)
+	return ""
+}
+
+var fuzzTargets = []testing.InternalFuzzTarget{
+{{range .FuzzTargets}}
+    {"{{.Name}}", {{.Name}}},
+{{end}}
+}
+
+var fuzzCorpus = map[string][]testing.CorpusEntry{
+{{range .FuzzTargets}}
+    "{{.Name}}": {
+{{range .Corpus}}        {{.}},
+{{end}}    },
+{{end}}
+}
+
+var fuzzFlags = map[string]bool{
+{{range .FuzzTargets}}
+    "{{.Name}}": {{.Fuzz}},
+{{end}}
+}
+
+func fuzzadapt(ff interface{}) ([]interface{}, func(*testing.T, []interface{})) {
+	switch ff := ff.(type) {
+{{range .FuzzSigs}}
+	case {{.Type}}:
+		return []interface{}{ {{.Zeros}} }, func(t *testing.T, args []interface{}) {
+			ff(t, {{.Args}})
+		}
+{{end}}
+	}
+	return nil, nil
+}
+
+func runfuzz(name string) (report string) {
+	for _, target := range fuzzTargets {
+		if target.Name == name {
+			return testing.RunFuzz({{printf "%q" .RunFlag}}, fuzzFlags[name], {{.FuzzTime}}, {{.FuzzN}}, {{.Verbose}}, fuzzCorpus[name], fuzzadapt, target)
+		}
+	}
+	panic("no such fuzz test: " + name)
+	return ""
+}
 `))
 
 type testFuncs struct {
 	Tests       []testFunc
+	Benchmarks  []testFunc
 	PackageName string
 	Verbose     bool
 	RunFlag     string
+	BenchFlag   string
+	BenchTime   int64 // in ns.
+	BenchN      int
+	FuzzTargets []fuzzTarget
+	FuzzSigs    []fuzzSig
+	FuzzTime    int64 // in ns.
+	FuzzN       int
 }
 
 type testFunc struct {
@@ -592,6 +1051,20 @@ type testFunc struct {
 	Name    string
 }
 
+type fuzzTarget struct {
+	Name   string
+	Corpus []string // gno expressions of the testdata/fuzz corpus entries.
+	Fuzz   bool
+}
+
+// fuzzSig is a signature of the fuzz targets of a package, for which
+// fuzzadapt is generated.
+type fuzzSig struct {
+	Type  string // function type.
+	Zeros string // zero values of the fuzzed arguments.
+	Args  string // fuzzed arguments, from the args slice.
+}
+
 func getPkgNameFromFileset(files *gno.FileSet) string {
 	if len(files.Files) <= 0 {
 		return ""
@@ -619,12 +1092,302 @@ func loadTestFuncs(pkgName string, t *testFuncs, tfiles *gno.FileSet) *testFuncs
 					}
 					t.Tests = append(t.Tests, tf)
 				}
+				if strings.HasPrefix(fname, "Benchmark") {
+					t.Benchmarks = append(t.Benchmarks, testFunc{
+						Package: pkgName,
+						Name:    fname,
+					})
+				}
+				if strings.HasPrefix(fname, "Fuzz") {
+					t.FuzzTargets = append(t.FuzzTargets, fuzzTarget{Name: fname})
+				}
 			}
 		}
 	}
+	t.FuzzSigs = loadFuzzSigs(tfiles)
 	return t
 }
 
+// fuzzArgTypes are the types of the fuzzed arguments, and their zero values.
+var fuzzArgTypes = map[string]string{
+	"string": `""`,
+	"[]byte": "[]byte(nil)",
+	"bool":   "false",
+	"int":    "int(0)",
+	"int8":   "int8(0)",
+	"int16":  "int16(0)",
+	"int32":  "int32(0)",
+	"int64":  "int64(0)",
+	"uint":   "uint(0)",
+	"uint8":  "uint8(0)",
+	"uint16": "uint16(0)",
+	"uint32": "uint32(0)",
+	"uint64": "uint64(0)",
+}
+
+// loadFuzzSigs returns the signatures of the fuzz targets passed to f.Fuzz in
+// the fuzz tests of tfiles, as function literals or top-level functions.
+func loadFuzzSigs(tfiles *gno.FileSet) []fuzzSig {
+	funcs := make(map[gno.Name]*gno.FuncDecl)
+	for _, tf := range tfiles.Files {
+		for _, d := range tf.Decls {
+			if fd, ok := d.(*gno.FuncDecl); ok && !fd.IsMethod {
+				funcs[fd.Name] = fd
+			}
+		}
+	}
+
+	var sigs []fuzzSig
+	seen := make(map[string]bool)
+	for name, fd := range funcs {
+		if !strings.HasPrefix(string(name), "Fuzz") {
+			continue
+		}
+		gno.Transcribe(fd, func(ns []gno.Node, ftype gno.TransField, index int, n gno.Node, stage gno.TransStage) (gno.Node, gno.TransCtrl) {
+			if stage != gno.TRANS_ENTER {
+				return n, gno.TRANS_CONTINUE
+			}
+			call, ok := n.(*gno.CallExpr)
+			if !ok || len(call.Args) != 1 {
+				return n, gno.TRANS_CONTINUE
+			}
+			if sel, ok := call.Func.(*gno.SelectorExpr); !ok || sel.Sel != "Fuzz" {
+				return n, gno.TRANS_CONTINUE
+			}
+			var fnType *gno.FuncTypeExpr
+			switch arg := call.Args[0].(type) {
+			case *gno.FuncLitExpr:
+				fnType = &arg.Type
+			case *gno.NameExpr:
+				if fd := funcs[arg.Name]; fd != nil {
+					fnType = &fd.Type
+				}
+			}
+			if fnType == nil {
+				return n, gno.TRANS_CONTINUE
+			}
+			if sig, ok := newFuzzSig(fnType); ok && !seen[sig.Type] {
+				seen[sig.Type] = true
+				sigs = append(sigs, sig)
+			}
+			return n, gno.TRANS_CONTINUE
+		})
+	}
+	sort.Slice(sigs, func(i, j int) bool { return sigs[i].Type < sigs[j].Type })
+	return sigs
+}
+
+// newFuzzSig returns the fuzzSig of a fuzz target of type ftype, if its
+// fuzzed arguments are supported.
+func newFuzzSig(ftype *gno.FuncTypeExpr) (fuzzSig, bool) {
+	if len(ftype.Params) < 2 || len(ftype.Results) > 0 {
+		return fuzzSig{}, false
+	}
+	types := make([]string, 0, len(ftype.Params)-1)
+	zeros := make([]string, 0, len(ftype.Params)-1)
+	args := make([]string, 0, len(ftype.Params)-1)
+	for i, param := range ftype.Params[1:] {
+		typ := fuzzArgType(param.Type)
+		if typ == "" {
+			return fuzzSig{}, false
+		}
+		types = append(types, typ)
+		zeros = append(zeros, fuzzArgTypes[typ])
+		args = append(args, fmt.Sprintf("args[%d].(%s)", i, typ))
+	}
+	return fuzzSig{
+		Type:  "func(*testing.T, " + strings.Join(types, ", ") + ")",
+		Zeros: strings.Join(zeros, ", "),
+		Args:  strings.Join(args, ", "),
+	}, true
+}
+
+// fuzzArgType returns the type of the fuzzed argument of type x, or "" if it
+// isn't supported.
+func fuzzArgType(x gno.Expr) string {
+	switch x := x.(type) {
+	case *gno.NameExpr:
+		switch x.Name {
+		case "byte":
+			return "uint8"
+		case "rune":
+			return "int32"
+		}
+		if _, ok := fuzzArgTypes[string(x.Name)]; ok && x.Name != "[]byte" {
+			return string(x.Name)
+		}
+	case *gno.SliceTypeExpr:
+		if elt, ok := x.Elt.(*gno.NameExpr); ok && !x.Vrd && (elt.Name == "byte" || elt.Name == "uint8") {
+			return "[]byte"
+		}
+	}
+	return ""
+}
+
+// fuzzCorpusHeader is the first line of the corpus files, in the format of
+// the Go fuzzing corpus.
+const fuzzCorpusHeader = "go test fuzz v1"
+
+// readFuzzCorpus reads the corpus files of dir, and returns their entries as
+// gno expressions of testing.CorpusEntry.
+func readFuzzCorpus(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var corpus []string
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		path := filepath.Join(dir, entry.Name())
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		values, err := parseFuzzCorpusFile(data)
+		if err != nil {
+			return nil, fmt.Errorf("malformed corpus file %s: %w", path, err)
+		}
+		corpus = append(corpus, fmt.Sprintf("{Name: %q, Values: []interface{}{%s}}",
+			entry.Name(), strings.Join(values, ", ")))
+	}
+	return corpus, nil
+}
+
+// parseFuzzCorpusFile returns the values of the corpus file data, as gno
+// expressions.
+func parseFuzzCorpusFile(data []byte) ([]string, error) {
+	lines := strings.Split(string(data), "\n")
+	if strings.TrimSpace(lines[0]) != fuzzCorpusHeader {
+		return nil, fmt.Errorf("missing %q header", fuzzCorpusHeader)
+	}
+	var values []string
+	for _, line := range lines[1:] {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		v, err := parseFuzzCorpusValue(line)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, v)
+	}
+	if len(values) == 0 {
+		return nil, errors.New("no values")
+	}
+	return values, nil
+}
+
+// parseFuzzCorpusValue parses a value of a corpus file, like int(42) or
+// []byte("abc"), and returns it in canonical form.
+func parseFuzzCorpusValue(line string) (string, error) {
+	expr, err := parser.ParseExpr(line)
+	if err != nil {
+		return "", err
+	}
+	call, ok := expr.(*ast.CallExpr)
+	if !ok || len(call.Args) != 1 {
+		return "", fmt.Errorf("expected a conversion: %s", line)
+	}
+	arg := call.Args[0]
+
+	var typ string
+	switch fn := call.Fun.(type) {
+	case *ast.ArrayType:
+		if elt, ok := fn.Elt.(*ast.Ident); ok && fn.Len == nil && (elt.Name == "byte" || elt.Name == "uint8") {
+			typ = "[]byte"
+		}
+	case *ast.Ident:
+		typ = fn.Name
+		switch typ {
+		case "byte":
+			typ = "uint8"
+		case "rune":
+			typ = "int32"
+		}
+	}
+	if _, ok := fuzzArgTypes[typ]; !ok {
+		return "", fmt.Errorf("unsupported type: %s", line)
+	}
+
+	switch typ {
+	case "string", "[]byte":
+		lit, ok := arg.(*ast.BasicLit)
+		if !ok || lit.Kind != token.STRING {
+			return "", fmt.Errorf("expected a string literal: %s", line)
+		}
+		s, err := strconv.Unquote(lit.Value)
+		if err != nil {
+			return "", fmt.Errorf("%w: %s", err, line)
+		}
+		return typ + "(" + strconv.Quote(s) + ")", nil
+	case "bool":
+		id, ok := arg.(*ast.Ident)
+		if !ok || (id.Name != "true" && id.Name != "false") {
+			return "", fmt.Errorf("expected a boolean: %s", line)
+		}
+		return "bool(" + id.Name + ")", nil
+	}
+
+	// integers, as literals or characters, with an optional sign.
+	sign := ""
+	if unary, ok := arg.(*ast.UnaryExpr); ok && unary.Op == token.SUB {
+		sign = "-"
+		arg = unary.X
+	}
+	lit, ok := arg.(*ast.BasicLit)
+	if !ok || (lit.Kind != token.INT && lit.Kind != token.CHAR) {
+		return "", fmt.Errorf("expected an integer: %s", line)
+	}
+	num := lit.Value
+	if lit.Kind == token.CHAR {
+		r, _, _, err := strconv.UnquoteChar(lit.Value[1:len(lit.Value)-1], '\'')
+		if err != nil {
+			return "", fmt.Errorf("%w: %s", err, line)
+		}
+		num = strconv.Itoa(int(r))
+	}
+	bits := 64
+	if n := strings.TrimPrefix(strings.TrimPrefix(typ, "u"), "int"); n != "" {
+		bits, _ = strconv.Atoi(n)
+	}
+	if strings.HasPrefix(typ, "u") {
+		if sign != "" {
+			return "", fmt.Errorf("negative unsigned integer: %s", line)
+		}
+		n, err := strconv.ParseUint(num, 0, bits)
+		if err != nil {
+			return "", fmt.Errorf("%w: %s", err, line)
+		}
+		return fmt.Sprintf("%s(%d)", typ, n), nil
+	}
+	n, err := strconv.ParseInt(sign+num, 0, bits)
+	if err != nil {
+		return "", fmt.Errorf("%w: %s", err, line)
+	}
+	return fmt.Sprintf("%s(%d)", typ, n), nil
+}
+
+// writeFuzzCorpusFile writes data to a corpus file of dir named after its
+// hash, and returns its path.
+func writeFuzzCorpusFile(dir, data string) (string, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256([]byte(data))
+	path := filepath.Join(dir, hex.EncodeToString(sum[:])[:16])
+	if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+		return "", err
+	}
+	return path, nil
+}
+
 // parseMemPackageTests is copied from gno.ParseMemPackageTests
 // for except to _filetest.gno
 func parseMemPackageTests(memPkg *std.MemPackage) (tset, itset *gno.FileSet) {
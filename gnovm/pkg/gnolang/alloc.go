@@ -1,16 +1,25 @@
 package gnolang
 
-import "reflect"
+import (
+	"reflect"
+
+	"github.com/gnolang/gno/tm2/pkg/store"
+)
 
 // Keeps track of in-memory allocations.
 // In the future, allocations within realm boundaries will be
 // (optionally?) condensed (objects to be GC'd will be discarded),
 // but for now, allocations strictly increment across the whole tx.
 type Allocator struct {
-	maxBytes int64
-	bytes    int64
+	maxBytes  int64
+	bytes     int64
+	numAllocs int64
+	gasMeter  store.GasMeter // or nil to not charge gas.
 }
 
+// GasFactorAlloc is the amount of gas charged per allocated byte.
+const GasFactorAlloc int64 = 1
+
 // for gonative, which doesn't consider the allocator.
 var nilAllocator = (*Allocator)(nil)
 
@@ -77,21 +86,39 @@ func (alloc *Allocator) Status() (maxBytes int64, bytes int64) {
 	return alloc.maxBytes, alloc.bytes
 }
 
+// NumAllocs returns the number of allocations made.
+func (alloc *Allocator) NumAllocs() int64 {
+	return alloc.numAllocs
+}
+
+// Reset clears the allocated bytes and the gas meter, if any.
 func (alloc *Allocator) Reset() *Allocator {
 	if alloc == nil {
 		return nil
 	}
 	alloc.bytes = 0
+	alloc.numAllocs = 0
+	alloc.gasMeter = nil
 	return alloc
 }
 
+// SetGasMeter sets the gas meter charged for each subsequent allocation.
+// It is cleared upon Reset().
+func (alloc *Allocator) SetGasMeter(gasMeter store.GasMeter) {
+	if alloc == nil {
+		return
+	}
+	alloc.gasMeter = gasMeter
+}
+
 func (alloc *Allocator) Fork() *Allocator {
 	if alloc == nil {
 		return nil
 	}
 	return &Allocator{
-		maxBytes: alloc.maxBytes,
-		bytes:    alloc.bytes,
+		maxBytes:  alloc.maxBytes,
+		bytes:     alloc.bytes,
+		numAllocs: alloc.numAllocs,
 	}
 }
 
@@ -100,7 +127,11 @@ func (alloc *Allocator) Allocate(size int64) {
 		// this can happen for map items just prior to assignment.
 		return
 	}
+	if alloc.gasMeter != nil {
+		alloc.gasMeter.ConsumeGas(size*GasFactorAlloc, "memory allocation")
+	}
 	alloc.bytes += size
+	alloc.numAllocs++
 	if alloc.bytes > alloc.maxBytes {
 		panic("allocation limit exceeded")
 	}
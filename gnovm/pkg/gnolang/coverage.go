@@ -0,0 +1,164 @@
+package gnolang
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// Coverage records the statements of a set of files which are executed by
+// machines, by location, to report their statement coverage as a Go cover
+// profile (see golang.org/x/tools/cover). Statements are tracked by line, as
+// nodes only know the line they start at.
+//
+// A Coverage is not safe for concurrent use.
+type Coverage struct {
+	files map[coverFileKey]*coverFile
+}
+
+type coverFileKey struct {
+	pkgPath string
+	name    string
+}
+
+type coverFile struct {
+	path  string             // path of the file in the profile.
+	lines map[int]*coverLine // by line number.
+}
+
+type coverLine struct {
+	endCol   int // column after the last byte of the line.
+	numStmts int
+	covered  bool
+}
+
+// NewCoverage returns a Coverage with no files.
+func NewCoverage() *Coverage {
+	return &Coverage{files: make(map[coverFileKey]*coverFile)}
+}
+
+// AddFile parses the file of package pkgPath named name, and records its
+// statements as to be covered. The file is reported at path in the profile.
+func (c *Coverage) AddFile(pkgPath, name, path, body string) error {
+	fn, err := ParseFile(name, body)
+	if err != nil {
+		return err
+	}
+
+	srcLines := strings.Split(body, "\n")
+	cf := &coverFile{
+		path:  path,
+		lines: make(map[int]*coverLine),
+	}
+	Transcribe(fn, func(ns []Node, ftype TransField, index int, n Node, stage TransStage) (Node, TransCtrl) {
+		if stage != TRANS_ENTER {
+			return n, TRANS_CONTINUE
+		}
+		if !isCoveredStmt(ftype, n) {
+			return n, TRANS_CONTINUE
+		}
+		line := n.GetLine()
+		if line <= 0 || line > len(srcLines) {
+			return n, TRANS_CONTINUE
+		}
+		cl := cf.lines[line]
+		if cl == nil {
+			cl = &coverLine{endCol: len(srcLines[line-1]) + 1}
+			cf.lines[line] = cl
+		}
+		cl.numStmts++
+		return n, TRANS_CONTINUE
+	})
+	c.files[coverFileKey{pkgPath, name}] = cf
+	return nil
+}
+
+// isCoveredStmt returns whether n, found in the field ftype of its parent, is
+// a statement counted like the go cover tool does: the top-level declarations,
+// and the clauses and inner statements of other statements are not counted.
+func isCoveredStmt(ftype TransField, n Node) bool {
+	switch ftype {
+	case TRANS_FILE_BODY, TRANS_DECL_BODY,
+		TRANS_FOR_INIT, TRANS_FOR_POST,
+		TRANS_IF_INIT, TRANS_SWITCH_INIT:
+		return false
+	}
+	switch n.(type) {
+	case *BlockStmt, *EmptyStmt, *IfCaseStmt, *SwitchClauseStmt, *SelectCaseStmt, *bodyStmt:
+		return false
+	case Stmt:
+		return true
+	default:
+		return false
+	}
+}
+
+// hit records the statement at loc as executed.
+func (c *Coverage) hit(loc Location, line int) {
+	cf := c.files[coverFileKey{loc.PkgPath, loc.File}]
+	if cf == nil {
+		return
+	}
+	if cl := cf.lines[line]; cl != nil {
+		cl.covered = true
+	}
+}
+
+// NumStmts returns the number of statements of the files, and how many of
+// them were executed.
+func (c *Coverage) NumStmts() (total, covered int) {
+	for _, cf := range c.files {
+		for _, cl := range cf.lines {
+			total += cl.numStmts
+			if cl.covered {
+				covered += cl.numStmts
+			}
+		}
+	}
+	return total, covered
+}
+
+// String returns the coverage summary printed by go test -cover.
+func (c *Coverage) String() string {
+	total, covered := c.NumStmts()
+	if total == 0 {
+		return "coverage: [no statements]"
+	}
+	return fmt.Sprintf("coverage: %.1f%% of statements", 100*float64(covered)/float64(total))
+}
+
+// WriteProfile writes the blocks of the files to w, in the format of a Go
+// cover profile of mode "set". The caller writes the "mode: set" line, which
+// precedes the blocks of all the coverages of a profile.
+func (c *Coverage) WriteProfile(w io.Writer) error {
+	files := make([]*coverFile, 0, len(c.files))
+	for _, cf := range c.files {
+		files = append(files, cf)
+	}
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].path < files[j].path
+	})
+
+	for _, cf := range files {
+		lines := make([]int, 0, len(cf.lines))
+		for line := range cf.lines {
+			lines = append(lines, line)
+		}
+		sort.Ints(lines)
+
+		for _, line := range lines {
+			cl := cf.lines[line]
+			count := 0
+			if cl.covered {
+				count = 1
+			}
+			_, err := fmt.Fprintf(w, "%s:%d.%d,%d.%d %d %d\n",
+				cf.path, line, 1, line, cl.endCol, cl.numStmts, count)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,60 @@
+package gnolang
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/gnolang/gno/tm2/pkg/std"
+	"github.com/jaekwon/testify/assert"
+	"github.com/jaekwon/testify/require"
+)
+
+func TestCoverage(t *testing.T) {
+	body := `package cov
+
+func Abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
+
+func Sum(xs ...int) (sum int) {
+	for _, x := range xs {
+		sum += x
+	}
+	return
+}
+`
+	coverage := NewCoverage()
+	require.NoError(t, coverage.AddFile("gno.land/p/demo/cov", "cov.gno", "./cov.gno", body))
+	total, covered := coverage.NumStmts()
+	assert.Equal(t, 6, total)
+	assert.Equal(t, 0, covered)
+
+	m := NewMachineWithOptions(MachineOptions{PkgPath: "gno.land/p/demo/cov", Coverage: coverage})
+	defer m.Release()
+	m.RunMemPackage(&std.MemPackage{
+		Name:  "cov",
+		Path:  "gno.land/p/demo/cov",
+		Files: []*std.MemFile{{Name: "cov.gno", Body: body}},
+	}, false)
+	m.RunStatement(S(Call(X("Abs"), 1)))
+
+	total, covered = coverage.NumStmts()
+	assert.Equal(t, 6, total)
+	assert.Equal(t, 2, covered)
+	assert.Equal(t, "coverage: 33.3% of statements", coverage.String())
+
+	var buf bytes.Buffer
+	require.NoError(t, coverage.WriteProfile(&buf))
+	assert.Equal(t, `./cov.gno:4.1,4.12 1 1
+./cov.gno:5.1,5.12 1 0
+./cov.gno:7.1,7.10 1 1
+./cov.gno:11.1,11.24 1 0
+./cov.gno:12.1,12.11 1 0
+./cov.gno:14.1,14.8 1 0
+`, buf.String())
+
+	assert.Equal(t, "coverage: [no statements]", NewCoverage().String())
+}
@@ -0,0 +1,706 @@
+package gnolang
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// ErrDebuggerExit is the panic of the machines attached to a Debugger whose
+// user requested to exit. Once exited, the machines panic again at their next
+// statement, so that their callers can stop running them.
+var ErrDebuggerExit = errors.New("debugger exited")
+
+// Debugger is an interactive source-level debugger of the machines it is
+// attached to with MachineOptions.Debugger. It stops the execution before the
+// first statement, the statements at its breakpoints, and after stepping, and
+// then reads commands until the execution is resumed.
+//
+// The commands are read from a terminal, see NewDebugger, or from a client of
+// the Debug Adapter Protocol, see NewDAPDebugger.
+//
+// The debugger is scoped to the package being debugged: it only lists and
+// stops at the breakpoints of the files added with AddSource, since the last
+// call to ResetSources.
+//
+// A Debugger is not safe for concurrent use.
+type Debugger struct {
+	in  *bufio.Scanner // or nil for a DAP client.
+	out io.Writer
+	dap *dapConn // or nil for a terminal.
+
+	sources     map[coverFileKey]*debugSource
+	breakpoints []debugBreakpoint
+	nextID      int
+	mode        debugMode
+	pausing     bool // whether a DAP client paused the execution.
+	exited      bool
+	lastCmd     string
+
+	// position of the last stop, and whether the execution moved to
+	// another line since.
+	stopLoc   Location
+	stopStmt  Stmt
+	stopDepth int
+	leftStop  bool
+	stopped   bool // whether the execution ever stopped.
+
+	positions []Location   // last position at each call depth.
+	frames    []debugFrame // frames of the stop.
+	frame     int          // selected frame, 0 being the innermost.
+}
+
+type debugMode int
+
+const (
+	debugStep     debugMode = iota // stop at the next statement.
+	debugNext                      // stop at the next statement of the frame.
+	debugStepOut                   // stop after returning from the frame.
+	debugContinue                  // stop at breakpoints.
+	debugDetached                  // never stop.
+)
+
+type debugSource struct {
+	path  string // on the filesystem.
+	lines []string
+}
+
+type debugBreakpoint struct {
+	id   int
+	file string // file name, or absolute path.
+	line int
+}
+
+func (bp debugBreakpoint) String() string {
+	return fmt.Sprintf("Breakpoint %d at %s:%d", bp.id, bp.file, bp.line)
+}
+
+// debugFrame is a call frame of the stopped execution.
+type debugFrame struct {
+	name  string   // qualified name of the function.
+	loc   Location // position in the function.
+	block *Block   // innermost block at loc.
+}
+
+// debugVar is a named value, as a local variable or an element of another
+// value.
+type debugVar struct {
+	name string
+	tv   TypedValue
+}
+
+// NewDebugger returns a Debugger reading its commands from in, and writing
+// its output to out.
+func NewDebugger(in io.Reader, out io.Writer) *Debugger {
+	return &Debugger{
+		in:      bufio.NewScanner(in),
+		out:     out,
+		sources: make(map[coverFileKey]*debugSource),
+		nextID:  1,
+	}
+}
+
+// AddSource records the body of the file of package pkgPath named name, found
+// at path on the filesystem. The debugger lists the source around the
+// positions it stops at, and only stops at the breakpoints of its sources.
+func (d *Debugger) AddSource(pkgPath, name, path, body string) {
+	if abs, err := filepath.Abs(path); err == nil {
+		path = abs
+	}
+	d.sources[coverFileKey{pkgPath, name}] = &debugSource{
+		path:  path,
+		lines: strings.Split(body, "\n"),
+	}
+}
+
+// ResetSources forgets the sources added, before debugging another package.
+func (d *Debugger) ResetSources() {
+	d.sources = make(map[coverFileKey]*debugSource)
+}
+
+// Exited reports whether the user requested to exit the debugger, in which
+// case the machines attached to it panic with ErrDebuggerExit.
+func (d *Debugger) Exited() bool {
+	return d.exited
+}
+
+// Close ends the debugging session, once the execution is over.
+func (d *Debugger) Close() error {
+	if d.dap == nil {
+		return nil
+	}
+	return d.dap.close()
+}
+
+// AddBreakpoint adds a breakpoint at the location spec, of the form
+// <file>:<line>, where file is the name or the path of a file.
+func (d *Debugger) AddBreakpoint(spec string) error {
+	i := strings.LastIndexByte(spec, ':')
+	if i <= 0 {
+		return fmt.Errorf("invalid breakpoint %q: expected <file>:<line>", spec)
+	}
+	line, err := strconv.Atoi(spec[i+1:])
+	if err != nil || line <= 0 {
+		return fmt.Errorf("invalid breakpoint line in %q", spec)
+	}
+	bp := d.addBreakpoint(spec[:i], line)
+	d.printf("%s\n", bp)
+	return nil
+}
+
+func (d *Debugger) addBreakpoint(file string, line int) debugBreakpoint {
+	bp := debugBreakpoint{
+		id:   d.nextID,
+		file: breakpointFile(file),
+		line: line,
+	}
+	d.nextID++
+	d.breakpoints = append(d.breakpoints, bp)
+	return bp
+}
+
+// breakpointFile returns the file of a breakpoint: a file name, or an
+// absolute path if file has a directory.
+func breakpointFile(file string) string {
+	if filepath.Base(file) == file {
+		return file
+	}
+	if abs, err := filepath.Abs(file); err == nil {
+		return abs
+	}
+	return file
+}
+
+// onStmt is called by the machine before executing s, and stops the execution
+// to read commands if s is at a stopping position.
+func (d *Debugger) onStmt(m *Machine, s Stmt) {
+	if d.exited {
+		panic(ErrDebuggerExit)
+	}
+	if d.dap != nil {
+		d.pollDAP(m)
+	}
+	if d.mode == debugDetached {
+		return
+	}
+	switch s.(type) {
+	case *bodyStmt, *BlockStmt, *EmptyStmt, *IfCaseStmt, *SwitchClauseStmt, *SelectCaseStmt:
+		return
+	}
+	src := m.LastBlock().Source
+	if src == nil {
+		return
+	}
+	loc := src.GetLocation()
+	if loc.File == "" || s.GetLine() == 0 {
+		// statements run by the machine, outside of files, or added by
+		// the preprocessor.
+		return
+	}
+	loc.Line, loc.Nonce = s.GetLine(), 0
+	depth := numCallFrames(m)
+	for len(d.positions) <= depth {
+		d.positions = append(d.positions, Location{})
+	}
+	d.positions = d.positions[:depth+1]
+	d.positions[depth] = loc
+
+	// a statement executed again, as in loops, is a new position.
+	moved := loc != d.stopLoc || depth != d.stopDepth || s == d.stopStmt
+	if moved {
+		d.leftStop = true
+	}
+	if !d.shouldStop(loc, depth, moved) {
+		return
+	}
+
+	reason := d.stopReason()
+	d.stopLoc, d.stopStmt, d.stopDepth, d.leftStop = loc, s, depth, false
+	d.stopped, d.pausing = true, false
+	d.frames = d.debugFrames(m, loc)
+	d.frame = 0
+	if d.dap != nil {
+		d.stopDAP(m, reason)
+	} else {
+		d.printf("> %s %s\n", d.frames[0].name, loc)
+		d.list(loc, 1)
+		d.repl(m)
+	}
+	if d.exited {
+		panic(ErrDebuggerExit)
+	}
+}
+
+func (d *Debugger) shouldStop(loc Location, depth int, moved bool) bool {
+	switch d.mode {
+	case debugStep:
+		return moved
+	case debugNext:
+		return depth < d.stopDepth || (depth == d.stopDepth && moved)
+	case debugStepOut:
+		return depth < d.stopDepth
+	case debugContinue:
+		return d.leftStop && d.isBreakpoint(loc)
+	default:
+		return false
+	}
+}
+
+// stopReason returns why the execution stops, as reported to DAP clients.
+func (d *Debugger) stopReason() string {
+	switch {
+	case d.pausing:
+		return "pause"
+	case d.mode == debugContinue:
+		return "breakpoint"
+	case !d.stopped:
+		return "entry"
+	default:
+		return "step"
+	}
+}
+
+// isBreakpoint returns whether there is a breakpoint at loc, in the sources of
+// the debugged package.
+func (d *Debugger) isBreakpoint(loc Location) bool {
+	src := d.sources[coverFileKey{loc.PkgPath, loc.File}]
+	if src == nil {
+		return false
+	}
+	for _, bp := range d.breakpoints {
+		if bp.line == loc.Line && (bp.file == filepath.Base(loc.File) || bp.file == src.path) {
+			return true
+		}
+	}
+	return false
+}
+
+// exit stops the execution of the machines attached to the debugger.
+func (d *Debugger) exit() {
+	d.exited = true
+	d.mode = debugDetached
+}
+
+// repl reads and runs commands until one resumes the execution.
+func (d *Debugger) repl(m *Machine) {
+	for {
+		d.printf("dbg> ")
+		if !d.in.Scan() {
+			// no more commands: run until the end.
+			d.printf("\n")
+			d.mode = debugDetached
+			return
+		}
+		line := strings.TrimSpace(d.in.Text())
+		if line == "" {
+			line = d.lastCmd
+		}
+		d.lastCmd = line
+		if resume := d.command(m, line); resume {
+			return
+		}
+	}
+}
+
+// command runs the command line, and returns whether the execution resumes.
+func (d *Debugger) command(m *Machine, line string) (resume bool) {
+	cmd, arg, _ := strings.Cut(line, " ")
+	arg = strings.TrimSpace(arg)
+	switch cmd {
+	case "":
+	case "break", "b":
+		if arg == "" {
+			d.printf("usage: break [<file>:]<line>\n")
+			break
+		}
+		if !strings.Contains(arg, ":") {
+			arg = d.frames[d.frame].loc.File + ":" + arg
+		}
+		if err := d.AddBreakpoint(arg); err != nil {
+			d.printf("%v\n", err)
+		}
+	case "breakpoints", "bp":
+		for _, bp := range d.breakpoints {
+			d.printf("%s\n", bp)
+		}
+	case "clear":
+		d.clear(arg)
+	case "continue", "c":
+		d.mode = debugContinue
+		return true
+	case "next", "n":
+		d.mode = debugNext
+		return true
+	case "step", "s":
+		d.mode = debugStep
+		return true
+	case "stepout", "so":
+		d.mode = debugStepOut
+		return true
+	case "detach":
+		d.mode = debugDetached
+		return true
+	case "exit", "quit", "q":
+		d.exit()
+		return true
+	case "stack", "bt":
+		for i, fr := range d.frames {
+			marker := " "
+			if i == d.frame {
+				marker = "*"
+			}
+			d.printf("%s %d %s\n      at %s\n", marker, i, fr.name, fr.loc)
+		}
+	case "up", "down":
+		n := 1
+		if arg != "" {
+			var err error
+			if n, err = strconv.Atoi(arg); err != nil {
+				d.printf("invalid frame count %q\n", arg)
+				break
+			}
+		}
+		if cmd == "down" {
+			n = -n
+		}
+		d.selectFrame(d.frame + n)
+		fr := d.frames[d.frame]
+		d.printf("Frame %d: %s %s\n", d.frame, fr.name, fr.loc)
+		d.list(fr.loc, 1)
+	case "list", "l":
+		d.list(d.frames[d.frame].loc, 5)
+	case "locals":
+		for _, v := range d.locals(m) {
+			d.printf("%s = %s\n", v.name, v.tv.String())
+		}
+	case "print", "p":
+		if arg == "" {
+			d.printf("usage: print <expression>\n")
+			break
+		}
+		tv, err := d.eval(m, arg)
+		if err != nil {
+			d.printf("%v\n", err)
+			break
+		}
+		d.printf("%s\n", tv.String())
+	case "help", "h":
+		d.printf("%s", debugHelp)
+	default:
+		d.printf("unknown command %q, type help for the list of commands\n", cmd)
+	}
+	return false
+}
+
+const debugHelp = `The commands are:
+	break, b [<file>:]<line>	set a breakpoint
+	breakpoints, bp			list the breakpoints
+	clear [<id>]			delete a breakpoint, or all of them
+	continue, c			run until a breakpoint
+	next, n				step over to the next line
+	step, s				step into the next line
+	stepout, so			run until the current function returns
+	stack, bt			print the call stack
+	up [<n>], down [<n>]		select a caller, or callee, frame
+	list, l				list the source around the selected frame
+	locals				print the local variables of the selected frame
+	print, p <expression>		print a variable, field, element or dereference
+	detach				run until the end without stopping
+	exit, quit, q			stop the execution and exit
+	help, h				print this help
+An empty line repeats the last command.
+`
+
+func (d *Debugger) clear(arg string) {
+	if arg == "" {
+		d.breakpoints = nil
+		d.printf("All breakpoints cleared\n")
+		return
+	}
+	id, err := strconv.Atoi(arg)
+	if err != nil {
+		d.printf("invalid breakpoint id %q\n", arg)
+		return
+	}
+	for i, bp := range d.breakpoints {
+		if bp.id == id {
+			d.breakpoints = append(d.breakpoints[:i], d.breakpoints[i+1:]...)
+			d.printf("%s cleared\n", bp)
+			return
+		}
+	}
+	d.printf("no breakpoint %d\n", id)
+}
+
+// selectFrame selects the frame i, within the frames of the stop.
+func (d *Debugger) selectFrame(i int) {
+	d.frame = max(0, min(len(d.frames)-1, i))
+}
+
+// list prints the source lines of loc's file within n lines of loc.
+func (d *Debugger) list(loc Location, n int) {
+	src := d.sources[coverFileKey{loc.PkgPath, loc.File}]
+	if src == nil {
+		return
+	}
+	for i := max(1, loc.Line-n); i <= min(len(src.lines), loc.Line+n); i++ {
+		marker := "  "
+		if i == loc.Line {
+			marker = "=>"
+		}
+		d.printf("%s %4d: %s\n", marker, i, src.lines[i-1])
+	}
+}
+
+// locals returns the variables of the function blocks of the selected frame,
+// from the innermost block.
+func (d *Debugger) locals(m *Machine) []debugVar {
+	var vars []debugVar
+	for b := d.frames[d.frame].block; b != nil; b = b.GetParent(m.Store) {
+		src := b.GetSource(m.Store)
+		switch src.(type) {
+		case *FileNode, *PackageNode:
+			return vars
+		}
+		for i, name := range src.GetBlockNames() {
+			if i >= len(b.Values) || name == "" || name == "_" || name[0] == '.' {
+				continue
+			}
+			vars = append(vars, debugVar{name: string(name), tv: b.Values[i]})
+		}
+	}
+	return vars
+}
+
+// maxDebugElems is the maximum number of elements of a value listed by
+// elems.
+const maxDebugElems = 100
+
+// elems returns the fields of a struct, the elements of an array, slice or
+// map, or the value pointed to, for clients to expand tv.
+func (d *Debugger) elems(m *Machine, tv TypedValue) (vars []debugVar) {
+	defer func() {
+		if r := recover(); r != nil {
+			// values which can't be loaded aren't expanded.
+			vars = nil
+		}
+	}()
+	if tv.V == nil {
+		return nil
+	}
+	switch bt := baseOf(tv.T).(type) {
+	case *PointerType:
+		if pv, ok := tv.V.(PointerValue); ok && pv.TV != nil {
+			return []debugVar{{name: "*", tv: pv.Deref()}}
+		}
+	case *StructType:
+		sv, ok := tv.V.(*StructValue)
+		if !ok {
+			return nil
+		}
+		for i, f := range bt.Fields {
+			vars = append(vars, debugVar{
+				name: string(f.Name),
+				tv:   sv.GetPointerToInt(m.Store, i).Deref(),
+			})
+		}
+	case *ArrayType, *SliceType:
+		for i := 0; i < min(tv.GetLength(), maxDebugElems); i++ {
+			vars = append(vars, debugVar{
+				name: fmt.Sprintf("[%d]", i),
+				tv:   tv.GetPointerAtIndexInt(m.Store, i).Deref(),
+			})
+		}
+	case *MapType:
+		mv, ok := tv.V.(*MapValue)
+		if !ok {
+			return nil
+		}
+		for item := mv.List.Head; item != nil && len(vars) < maxDebugElems; item = item.Next {
+			vars = append(vars, debugVar{
+				name: "[" + item.Key.String() + "]",
+				tv:   item.Value,
+			})
+		}
+	}
+	return vars
+}
+
+// eval evaluates the expression expr in the selected frame, without running
+// the machine: only names, field selectors, constant indexes and
+// dereferences are supported.
+func (d *Debugger) eval(m *Machine, expr string) (tv TypedValue, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("cannot evaluate %s: %v", expr, r)
+		}
+	}()
+	x, err := ParseExpr(expr)
+	if err != nil {
+		return tv, err
+	}
+	return d.evalExpr(m, x)
+}
+
+func (d *Debugger) evalExpr(m *Machine, x Expr) (TypedValue, error) {
+	switch x := x.(type) {
+	case *NameExpr:
+		return d.lookup(m, x.Name)
+	case *StarExpr:
+		tv, err := d.evalExpr(m, x.X)
+		if err != nil {
+			return tv, err
+		}
+		pv, ok := tv.V.(PointerValue)
+		if !ok {
+			return tv, fmt.Errorf("cannot dereference %s", x.X)
+		}
+		return pv.Deref(), nil
+	case *SelectorExpr:
+		tv, err := d.evalExpr(m, x.X)
+		if err != nil {
+			return tv, err
+		}
+		if pv, ok := tv.V.(PointerValue); ok {
+			tv = pv.Deref()
+		}
+		st, ok := baseOf(tv.T).(*StructType)
+		if !ok || tv.V == nil {
+			return tv, fmt.Errorf("%s is not a struct", x.X)
+		}
+		for i, f := range st.Fields {
+			if f.Name == x.Sel {
+				return tv.V.(*StructValue).GetPointerToInt(m.Store, i).Deref(), nil
+			}
+		}
+		return tv, fmt.Errorf("%s has no field %s", x.X, x.Sel)
+	case *IndexExpr:
+		tv, err := d.evalExpr(m, x.X)
+		if err != nil {
+			return tv, err
+		}
+		iv, err := d.evalIndex(m, x.Index)
+		if err != nil {
+			return tv, err
+		}
+		if mv, ok := tv.V.(*MapValue); ok {
+			// GetPointerAtIndex would insert missing keys.
+			kt := baseOf(tv.T).(*MapType).Key
+			iv = convertDebugIndex(iv, kt)
+			val, ok := mv.GetValueForKey(m.Store, &iv)
+			if !ok {
+				return TypedValue{T: baseOf(tv.T).(*MapType).Value}, nil
+			}
+			return val, nil
+		}
+		return tv.GetPointerAtIndex(nilAllocator, m.Store, &iv).Deref(), nil
+	default:
+		return TypedValue{}, fmt.Errorf("unsupported expression %s", x)
+	}
+}
+
+// evalIndex evaluates an index, which may be a constant or a variable.
+func (d *Debugger) evalIndex(m *Machine, x Expr) (TypedValue, error) {
+	bx, ok := x.(*BasicLitExpr)
+	if !ok {
+		return d.evalExpr(m, x)
+	}
+	switch bx.Kind {
+	case INT:
+		i, err := strconv.Atoi(bx.Value)
+		if err != nil {
+			return TypedValue{}, err
+		}
+		tv := TypedValue{T: IntType}
+		tv.SetInt(i)
+		return tv, nil
+	case STRING:
+		s, err := strconv.Unquote(bx.Value)
+		if err != nil {
+			return TypedValue{}, err
+		}
+		return TypedValue{T: StringType, V: StringValue(s)}, nil
+	default:
+		return TypedValue{}, fmt.Errorf("unsupported index %s", bx.Value)
+	}
+}
+
+// convertDebugIndex converts the constant key iv to the named key types of
+// maps whose keys are declared of an int or string kind.
+func convertDebugIndex(iv TypedValue, kt Type) TypedValue {
+	if iv.T.Kind() != kt.Kind() {
+		return iv
+	}
+	iv.T = kt
+	return iv
+}
+
+// lookup returns the value of the name visible from the selected frame.
+func (d *Debugger) lookup(m *Machine, name Name) (TypedValue, error) {
+	for b := d.frames[d.frame].block; b != nil; b = b.GetParent(m.Store) {
+		names := b.GetSource(m.Store).GetBlockNames()
+		for i := len(names) - 1; i >= 0; i-- {
+			if names[i] == name && i < len(b.Values) {
+				return b.Values[i], nil
+			}
+		}
+	}
+	return TypedValue{}, fmt.Errorf("undefined: %s", name)
+}
+
+func (d *Debugger) printf(format string, args ...interface{}) {
+	fmt.Fprintf(d.out, format, args...)
+}
+
+// numCallFrames returns the number of function calls of the machine.
+func numCallFrames(m *Machine) int {
+	n := 0
+	for _, fr := range m.Frames {
+		if fr.Func != nil {
+			n++
+		}
+	}
+	return n
+}
+
+// debugFrames returns the call frames of the machine, stopped at loc, from
+// the innermost one.
+func (d *Debugger) debugFrames(m *Machine, loc Location) []debugFrame {
+	var calls []*Frame
+	for _, fr := range m.Frames {
+		if fr.Func != nil {
+			calls = append(calls, fr)
+		}
+	}
+
+	frameName := func(i int) string {
+		if i < 0 {
+			return "<toplevel>"
+		}
+		fv := calls[i].Func
+		name := string(fv.Name)
+		if name == "" {
+			name = "func literal"
+		}
+		return fv.PkgPath + "." + name
+	}
+
+	frames := []debugFrame{{name: frameName(len(calls) - 1), loc: loc, block: m.LastBlock()}}
+	for i := len(calls) - 1; i >= 0; i-- {
+		// the caller is at its last position, in the block of the call.
+		fr := calls[i]
+		if fr.NumBlocks == 0 || d.positions[i].File == "" {
+			break
+		}
+		frames = append(frames, debugFrame{
+			name:  frameName(i - 1),
+			loc:   d.positions[i],
+			block: m.Blocks[fr.NumBlocks-1],
+		})
+	}
+	return frames
+}
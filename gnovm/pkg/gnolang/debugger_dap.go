@@ -0,0 +1,480 @@
+package gnolang
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// The Debugger serves the Debug Adapter Protocol, see
+// https://microsoft.github.io/debug-adapter-protocol/specification, to a
+// single client, for the machines it is attached to, as a single thread.
+//
+// Requests are read by a goroutine, and handled by the machine: while the
+// execution is stopped, or before every statement while it runs, so that
+// clients can pause it or change the breakpoints.
+
+// dapThreadID is the ID of the only thread reported to clients.
+const dapThreadID = 1
+
+type dapRequest struct {
+	Seq       int             `json:"seq"`
+	Type      string          `json:"type"`
+	Command   string          `json:"command"`
+	Arguments json.RawMessage `json:"arguments,omitempty"`
+}
+
+type dapResponse struct {
+	Seq        int         `json:"seq"`
+	Type       string      `json:"type"`
+	RequestSeq int         `json:"request_seq"`
+	Success    bool        `json:"success"`
+	Command    string      `json:"command"`
+	Message    string      `json:"message,omitempty"`
+	Body       interface{} `json:"body,omitempty"`
+}
+
+type dapEvent struct {
+	Seq   int         `json:"seq"`
+	Type  string      `json:"type"`
+	Event string      `json:"event"`
+	Body  interface{} `json:"body,omitempty"`
+}
+
+type dapSource struct {
+	Name string `json:"name"`
+	Path string `json:"path,omitempty"`
+}
+
+type dapBreakpoint struct {
+	ID       int        `json:"id"`
+	Verified bool       `json:"verified"`
+	Line     int        `json:"line"`
+	Source   *dapSource `json:"source,omitempty"`
+}
+
+type dapStackFrame struct {
+	ID     int        `json:"id"`
+	Name   string     `json:"name"`
+	Source *dapSource `json:"source,omitempty"`
+	Line   int        `json:"line"`
+	Column int        `json:"column"`
+}
+
+type dapVariable struct {
+	Name               string `json:"name"`
+	Value              string `json:"value"`
+	Type               string `json:"type,omitempty"`
+	VariablesReference int    `json:"variablesReference"`
+}
+
+// dapConn is the connection of the Debugger to its DAP client.
+type dapConn struct {
+	rwc       io.ReadWriteCloser
+	seq       int
+	reqs      chan dapRequest // closed when the client disconnects.
+	done      chan struct{}   // closed by close.
+	closeOnce sync.Once
+
+	configured   bool
+	disconnected bool
+	stopped      bool // whether the execution is stopped.
+
+	// values of the variables references, from 1, valid while the
+	// execution is stopped; a nil tv refers to the locals of the frame.
+	refs []dapRef
+}
+
+type dapRef struct {
+	frame int
+	tv    *TypedValue
+}
+
+// NewDAPDebugger returns a Debugger served to the Debug Adapter Protocol
+// client connected with rwc. It returns once the client is configured, with
+// the breakpoints set before the execution starts. The execution stops at
+// entry if the launch or attach request sets stopOnEntry.
+func NewDAPDebugger(rwc io.ReadWriteCloser) (*Debugger, error) {
+	d := &Debugger{
+		out:     io.Discard,
+		sources: make(map[coverFileKey]*debugSource),
+		nextID:  1,
+		mode:    debugContinue,
+		dap: &dapConn{
+			rwc:  rwc,
+			reqs: make(chan dapRequest, 16),
+			done: make(chan struct{}),
+		},
+	}
+	go d.dap.readRequests()
+
+	for !d.dap.configured {
+		req, ok := <-d.dap.reqs
+		if !ok {
+			d.dap.close()
+			return nil, errors.New("debugger client disconnected before the configuration was done")
+		}
+		d.handleDAP(nil, req)
+	}
+	return d, nil
+}
+
+// readRequests reads the requests of the client, until it disconnects.
+func (c *dapConn) readRequests() {
+	defer close(c.reqs)
+	r := bufio.NewReader(c.rwc)
+	for {
+		bz, err := readDAPMessage(r)
+		if err != nil {
+			return
+		}
+		var req dapRequest
+		if err := json.Unmarshal(bz, &req); err != nil || req.Type != "request" {
+			continue
+		}
+		select {
+		case c.reqs <- req:
+		case <-c.done:
+			return
+		}
+	}
+}
+
+// readDAPMessage reads the content of a message, following its headers.
+func readDAPMessage(r *bufio.Reader) ([]byte, error) {
+	length := -1
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return nil, err
+		}
+		line = strings.TrimRight(line, "\r\n")
+		if line == "" {
+			break
+		}
+		name, value, ok := strings.Cut(line, ":")
+		if ok && strings.EqualFold(strings.TrimSpace(name), "Content-Length") {
+			if length, err = strconv.Atoi(strings.TrimSpace(value)); err != nil {
+				return nil, fmt.Errorf("invalid Content-Length %q", value)
+			}
+		}
+	}
+	if length < 0 {
+		return nil, errors.New("missing Content-Length header")
+	}
+	bz := make([]byte, length)
+	_, err := io.ReadFull(r, bz)
+	return bz, err
+}
+
+func (c *dapConn) send(msg interface{}) {
+	bz, err := json.Marshal(msg)
+	if err != nil {
+		panic(err)
+	}
+	// a client which went away is detected by readRequests.
+	fmt.Fprintf(c.rwc, "Content-Length: %d\r\n\r\n%s", len(bz), bz)
+}
+
+func (c *dapConn) respond(req dapRequest, body interface{}) {
+	c.seq++
+	c.send(dapResponse{
+		Seq:        c.seq,
+		Type:       "response",
+		RequestSeq: req.Seq,
+		Success:    true,
+		Command:    req.Command,
+		Body:       body,
+	})
+}
+
+func (c *dapConn) respondErr(req dapRequest, err error) {
+	c.seq++
+	c.send(dapResponse{
+		Seq:        c.seq,
+		Type:       "response",
+		RequestSeq: req.Seq,
+		Success:    false,
+		Command:    req.Command,
+		Message:    err.Error(),
+	})
+}
+
+func (c *dapConn) event(event string, body interface{}) {
+	c.seq++
+	c.send(dapEvent{
+		Seq:   c.seq,
+		Type:  "event",
+		Event: event,
+		Body:  body,
+	})
+}
+
+// close notifies the client that the execution is over, and closes the
+// connection.
+func (c *dapConn) close() (err error) {
+	c.closeOnce.Do(func() {
+		if !c.disconnected {
+			c.event("terminated", nil)
+		}
+		close(c.done)
+		err = c.rwc.Close()
+	})
+	return err
+}
+
+// pollDAP handles the requests received while the execution runs.
+func (d *Debugger) pollDAP(m *Machine) {
+	for {
+		select {
+		case req, ok := <-d.dap.reqs:
+			if !ok {
+				d.dap.disconnected = true
+				d.mode = debugDetached
+				return
+			}
+			d.handleDAP(m, req)
+		default:
+			return
+		}
+	}
+}
+
+// stopDAP notifies the client that the execution stopped, and handles its
+// requests until it resumes the execution.
+func (d *Debugger) stopDAP(m *Machine, reason string) {
+	d.dap.stopped = true
+	defer func() { d.dap.stopped, d.dap.refs = false, nil }()
+	d.dap.event("stopped", map[string]interface{}{
+		"reason":            reason,
+		"threadId":          dapThreadID,
+		"allThreadsStopped": true,
+	})
+	for {
+		req, ok := <-d.dap.reqs
+		if !ok {
+			d.dap.disconnected = true
+			d.mode = debugDetached
+			return
+		}
+		if resume := d.handleDAP(m, req); resume {
+			return
+		}
+	}
+}
+
+// handleDAP handles the request of the client, and returns whether the
+// execution resumes. m is nil before the execution starts.
+func (d *Debugger) handleDAP(m *Machine, req dapRequest) (resume bool) {
+	c := d.dap
+	stopped := c.stopped
+	switch req.Command {
+	case "initialize":
+		c.respond(req, map[string]interface{}{
+			"supportsConfigurationDoneRequest": true,
+			"supportsTerminateRequest":         true,
+		})
+		c.event("initialized", nil)
+	case "launch", "attach":
+		var args struct {
+			StopOnEntry bool `json:"stopOnEntry"`
+		}
+		if err := json.Unmarshal(req.Arguments, &args); err == nil && args.StopOnEntry && !d.stopped {
+			d.mode = debugStep
+		}
+		c.respond(req, nil)
+	case "configurationDone":
+		c.configured = true
+		c.respond(req, nil)
+	case "setBreakpoints":
+		var args struct {
+			Source      dapSource `json:"source"`
+			Breakpoints []struct {
+				Line int `json:"line"`
+			} `json:"breakpoints"`
+		}
+		if err := json.Unmarshal(req.Arguments, &args); err != nil {
+			c.respondErr(req, err)
+			break
+		}
+		file := args.Source.Path
+		if file == "" {
+			file = args.Source.Name
+		}
+		file = breakpointFile(file)
+		bps := d.breakpoints[:0]
+		for _, bp := range d.breakpoints {
+			if bp.file != file {
+				bps = append(bps, bp)
+			}
+		}
+		d.breakpoints = bps
+		res := []dapBreakpoint{}
+		for _, sbp := range args.Breakpoints {
+			bp := d.addBreakpoint(file, sbp.Line)
+			res = append(res, dapBreakpoint{
+				ID:       bp.id,
+				Verified: true,
+				Line:     bp.line,
+				Source:   &args.Source,
+			})
+		}
+		c.respond(req, map[string]interface{}{"breakpoints": res})
+	case "setExceptionBreakpoints":
+		c.respond(req, nil)
+	case "threads":
+		c.respond(req, map[string]interface{}{
+			"threads": []map[string]interface{}{{"id": dapThreadID, "name": "main"}},
+		})
+	case "stackTrace":
+		if !stopped {
+			c.respondErr(req, errors.New("the execution is not stopped"))
+			break
+		}
+		frames := make([]dapStackFrame, len(d.frames))
+		for i, fr := range d.frames {
+			frames[i] = dapStackFrame{
+				ID:     i + 1,
+				Name:   fr.name,
+				Source: d.dapSource(fr.loc),
+				Line:   fr.loc.Line,
+				Column: 1,
+			}
+		}
+		c.respond(req, map[string]interface{}{
+			"stackFrames": frames,
+			"totalFrames": len(frames),
+		})
+	case "scopes":
+		var args struct {
+			FrameID int `json:"frameId"`
+		}
+		if err := json.Unmarshal(req.Arguments, &args); err != nil || !stopped {
+			c.respondErr(req, errors.New("the execution is not stopped"))
+			break
+		}
+		c.respond(req, map[string]interface{}{
+			"scopes": []map[string]interface{}{{
+				"name":               "Locals",
+				"variablesReference": c.ref(dapRef{frame: args.FrameID - 1}),
+				"expensive":          false,
+			}},
+		})
+	case "variables":
+		var args struct {
+			VariablesReference int `json:"variablesReference"`
+		}
+		if err := json.Unmarshal(req.Arguments, &args); err != nil || !stopped ||
+			args.VariablesReference <= 0 || args.VariablesReference > len(c.refs) {
+			c.respondErr(req, errors.New("invalid variables reference"))
+			break
+		}
+		var vars []debugVar
+		if ref := c.refs[args.VariablesReference-1]; ref.tv == nil {
+			d.selectFrame(ref.frame)
+			vars = d.locals(m)
+		} else {
+			vars = d.elems(m, *ref.tv)
+		}
+		res := make([]dapVariable, len(vars))
+		for i, v := range vars {
+			res[i] = d.dapVariable(m, v)
+		}
+		c.respond(req, map[string]interface{}{"variables": res})
+	case "evaluate":
+		var args struct {
+			Expression string `json:"expression"`
+			FrameID    int    `json:"frameId"`
+		}
+		if err := json.Unmarshal(req.Arguments, &args); err != nil || !stopped {
+			c.respondErr(req, errors.New("the execution is not stopped"))
+			break
+		}
+		if args.FrameID > 0 {
+			d.selectFrame(args.FrameID - 1)
+		}
+		tv, err := d.eval(m, args.Expression)
+		if err != nil {
+			c.respondErr(req, err)
+			break
+		}
+		v := d.dapVariable(m, debugVar{name: args.Expression, tv: tv})
+		c.respond(req, map[string]interface{}{
+			"result":             v.Value,
+			"type":               v.Type,
+			"variablesReference": v.VariablesReference,
+		})
+	case "continue":
+		d.mode = debugContinue
+		c.respond(req, map[string]interface{}{"allThreadsContinued": true})
+		return stopped
+	case "next":
+		d.mode = debugNext
+		c.respond(req, nil)
+		return stopped
+	case "stepIn":
+		d.mode = debugStep
+		c.respond(req, nil)
+		return stopped
+	case "stepOut":
+		d.mode = debugStepOut
+		c.respond(req, nil)
+		return stopped
+	case "pause":
+		if !stopped {
+			d.mode, d.pausing = debugStep, true
+		}
+		c.respond(req, nil)
+	case "disconnect":
+		var args struct {
+			TerminateDebuggee bool `json:"terminateDebuggee"`
+		}
+		_ = json.Unmarshal(req.Arguments, &args)
+		c.respond(req, nil)
+		c.disconnected = true
+		if args.TerminateDebuggee {
+			d.exit()
+		} else {
+			d.mode = debugDetached
+		}
+		return true
+	case "terminate":
+		c.respond(req, nil)
+		d.exit()
+		return true
+	default:
+		c.respondErr(req, fmt.Errorf("unsupported request %q", req.Command))
+	}
+	return false
+}
+
+// ref returns a new variables reference to r.
+func (c *dapConn) ref(r dapRef) int {
+	c.refs = append(c.refs, r)
+	return len(c.refs)
+}
+
+func (d *Debugger) dapVariable(m *Machine, v debugVar) dapVariable {
+	dv := dapVariable{Name: v.name, Value: v.tv.String()}
+	if v.tv.T != nil {
+		dv.Type = v.tv.T.String()
+	}
+	if len(d.elems(m, v.tv)) > 0 {
+		tv := v.tv
+		dv.VariablesReference = d.dap.ref(dapRef{tv: &tv})
+	}
+	return dv
+}
+
+func (d *Debugger) dapSource(loc Location) *dapSource {
+	src := &dapSource{Name: loc.File}
+	if s := d.sources[coverFileKey{loc.PkgPath, loc.File}]; s != nil {
+		src.Path = s.path
+	}
+	return src
+}
@@ -0,0 +1,168 @@
+package gnolang
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"net"
+	"testing"
+
+	"github.com/jaekwon/testify/assert"
+	"github.com/jaekwon/testify/require"
+)
+
+func TestDAPDebugger(t *testing.T) {
+	body := `package dbg
+
+type Point struct {
+	X, Y int
+}
+
+func Norm1(p *Point) int {
+	return abs(p.X) + abs(p.Y)
+}
+
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
+
+func Main() int {
+	return Norm1(&Point{3, -4})
+}
+`
+	server, conn := net.Pipe()
+	done := make(chan error, 1)
+	go func() {
+		debugger, err := NewDAPDebugger(server)
+		if err != nil {
+			done <- err
+			return
+		}
+		debugger.AddSource("gno.land/p/demo/dbg", "dbg.gno", "dbg.gno", body)
+		runDebugged(debugger, body, S(Call(X("Main"))))
+		done <- debugger.Close()
+	}()
+
+	c := &dapTestClient{t: t, r: bufio.NewReader(conn), w: conn}
+	caps := c.request("initialize", nil)
+	assert.Equal(t, true, caps["supportsConfigurationDoneRequest"])
+	c.expectEvent("initialized")
+	c.request("attach", map[string]interface{}{})
+	bps := c.request("setBreakpoints", map[string]interface{}{
+		"source":      map[string]interface{}{"name": "dbg.gno"},
+		"breakpoints": []interface{}{map[string]interface{}{"line": 12}},
+	})
+	assert.Equal(t, true, bps["breakpoints"].([]interface{})[0].(map[string]interface{})["verified"])
+	c.request("configurationDone", nil)
+
+	// stopped at the breakpoint, in abs called by Norm1.
+	stopped := c.expectEvent("stopped")
+	assert.Equal(t, "breakpoint", stopped["reason"])
+	trace := c.request("stackTrace", map[string]interface{}{"threadId": 1})
+	frames := trace["stackFrames"].([]interface{})
+	require.Len(t, frames, 3)
+	top := frames[0].(map[string]interface{})
+	assert.Equal(t, "gno.land/p/demo/dbg.abs", top["name"])
+	assert.Equal(t, float64(12), top["line"])
+	assert.Equal(t, "dbg.gno", top["source"].(map[string]interface{})["name"])
+
+	scopes := c.request("scopes", map[string]interface{}{"frameId": 1})["scopes"].([]interface{})
+	ref := scopes[0].(map[string]interface{})["variablesReference"]
+	vars := c.request("variables", map[string]interface{}{"variablesReference": ref})["variables"].([]interface{})
+	require.Len(t, vars, 1)
+	assert.Equal(t, "x", vars[0].(map[string]interface{})["name"])
+	assert.Equal(t, "(3 int)", vars[0].(map[string]interface{})["value"])
+
+	// values are expanded by reference.
+	eval := c.request("evaluate", map[string]interface{}{"expression": "p", "frameId": 2})
+	assert.Equal(t, "*gno.land/p/demo/dbg.Point", eval["type"])
+	vars = c.request("variables", map[string]interface{}{"variablesReference": eval["variablesReference"]})["variables"].([]interface{})
+	require.Len(t, vars, 1)
+	vars = c.request("variables", map[string]interface{}{
+		"variablesReference": vars[0].(map[string]interface{})["variablesReference"],
+	})["variables"].([]interface{})
+	require.Len(t, vars, 2)
+	assert.Equal(t, "Y", vars[1].(map[string]interface{})["name"])
+	assert.Equal(t, "(-4 int)", vars[1].(map[string]interface{})["value"])
+
+	// stepping.
+	c.request("next", map[string]interface{}{"threadId": 1})
+	assert.Equal(t, "step", c.expectEvent("stopped")["reason"])
+	trace = c.request("stackTrace", map[string]interface{}{"threadId": 1})
+	assert.Equal(t, float64(15), trace["stackFrames"].([]interface{})[0].(map[string]interface{})["line"])
+
+	// the breakpoint hits again, until it is cleared.
+	c.request("continue", map[string]interface{}{"threadId": 1})
+	c.expectEvent("stopped")
+	c.request("setBreakpoints", map[string]interface{}{
+		"source":      map[string]interface{}{"name": "dbg.gno"},
+		"breakpoints": []interface{}{},
+	})
+	c.request("continue", map[string]interface{}{"threadId": 1})
+	c.expectEvent("terminated")
+	require.NoError(t, <-done)
+}
+
+type dapTestClient struct {
+	t      *testing.T
+	r      *bufio.Reader
+	w      net.Conn
+	seq    int
+	events []map[string]interface{}
+}
+
+// request sends the request, and returns the body of its response.
+func (c *dapTestClient) request(command string, args interface{}) map[string]interface{} {
+	c.t.Helper()
+
+	c.seq++
+	bz, err := json.Marshal(map[string]interface{}{
+		"seq":       c.seq,
+		"type":      "request",
+		"command":   command,
+		"arguments": args,
+	})
+	require.NoError(c.t, err)
+	_, err = fmt.Fprintf(c.w, "Content-Length: %d\r\n\r\n%s", len(bz), bz)
+	require.NoError(c.t, err)
+
+	for {
+		msg := c.read()
+		if msg["type"] == "event" {
+			c.events = append(c.events, msg)
+			continue
+		}
+		require.Equal(c.t, float64(c.seq), msg["request_seq"])
+		require.Equal(c.t, true, msg["success"], "%s: %v", command, msg["message"])
+		body, _ := msg["body"].(map[string]interface{})
+		return body
+	}
+}
+
+// expectEvent returns the body of the next event, which must be event.
+func (c *dapTestClient) expectEvent(event string) map[string]interface{} {
+	c.t.Helper()
+
+	var msg map[string]interface{}
+	if len(c.events) > 0 {
+		msg, c.events = c.events[0], c.events[1:]
+	} else {
+		msg = c.read()
+	}
+	require.Equal(c.t, event, msg["event"])
+	body, _ := msg["body"].(map[string]interface{})
+	return body
+}
+
+func (c *dapTestClient) read() map[string]interface{} {
+	c.t.Helper()
+
+	bz, err := readDAPMessage(c.r)
+	require.NoError(c.t, err)
+	var msg map[string]interface{}
+	require.NoError(c.t, json.Unmarshal(bz, &msg))
+	return msg
+}
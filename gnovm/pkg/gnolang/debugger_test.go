@@ -0,0 +1,106 @@
+package gnolang
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/gnolang/gno/tm2/pkg/std"
+	"github.com/jaekwon/testify/assert"
+)
+
+func TestDebugger(t *testing.T) {
+	body := `package dbg
+
+type Point struct {
+	X, Y int
+}
+
+func Norm1(p *Point) int {
+	return abs(p.X) + abs(p.Y)
+}
+
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
+
+func Sum(xs []int) (sum int) {
+	for _, x := range xs {
+		sum += x
+	}
+	return
+}
+
+func Main1() int {
+	return Norm1(&Point{3, -4})
+}
+
+func Main2() int {
+	return Sum([]int{1, 2, 3})
+}
+`
+	run := func(cmds string, stmt Stmt) string {
+		var out bytes.Buffer
+		debugger := NewDebugger(strings.NewReader(cmds), &out)
+		debugger.AddSource("gno.land/p/demo/dbg", "dbg.gno", "dbg.gno", body)
+		runDebugged(debugger, body, stmt)
+		return out.String()
+	}
+
+	// breakpoints, stack and variables.
+	out := run("b dbg.gno:12\nc\nbt\np x\np p\nup\np p.Y\np *p\nlocals\n",
+		S(Call(X("Main1"))))
+	assert.Contains(t, out, "> gno.land/p/demo/dbg.Main1 gno.land/p/demo/dbg/dbg.gno:26\n")
+	assert.Contains(t, out, "Breakpoint 1 at dbg.gno:12\n")
+	assert.Contains(t, out, "> gno.land/p/demo/dbg.abs gno.land/p/demo/dbg/dbg.gno:12\n"+
+		"     11: func abs(x int) int {\n"+
+		"=>   12: \tif x < 0 {\n"+
+		"     13: \t\treturn -x\n")
+	assert.Contains(t, out, "* 0 gno.land/p/demo/dbg.abs\n      at gno.land/p/demo/dbg/dbg.gno:12\n"+
+		"  1 gno.land/p/demo/dbg.Norm1\n      at gno.land/p/demo/dbg/dbg.gno:8\n"+
+		"  2 gno.land/p/demo/dbg.Main1\n      at gno.land/p/demo/dbg/dbg.gno:26\n")
+	assert.Contains(t, out, "dbg> (3 int)\ndbg> undefined: p\n")
+	assert.Contains(t, out, "Frame 1: gno.land/p/demo/dbg.Norm1 gno.land/p/demo/dbg/dbg.gno:8\n")
+	assert.Contains(t, out, "dbg> (-4 int)\n")
+	assert.Contains(t, out, "dbg> (struct{(3 int),(-4 int)} gno.land/p/demo/dbg.Point)\n")
+	assert.Contains(t, out, "dbg> p = (&(struct{(3 int),(-4 int)} gno.land/p/demo/dbg.Point) *gno.land/p/demo/dbg.Point)\n")
+
+	// loops stop at each iteration, until the breakpoint is cleared.
+	out = run("b 20\nc\np sum\nc\np sum\np xs[2]\nclear\nc\n",
+		S(Call(X("Main2"))))
+	assert.Equal(t, 2, strings.Count(out, "> gno.land/p/demo/dbg.Sum gno.land/p/demo/dbg/dbg.gno:20\n"))
+	assert.Contains(t, out, "dbg> (0 int)\n")
+	assert.Contains(t, out, "dbg> (1 int)\ndbg> (3 int)\ndbg> All breakpoints cleared\n")
+
+	// stepping into calls, and over them.
+	out = run("s\ns\nn\n", S(Call(X("Main1"))))
+	assert.Contains(t, out, "> gno.land/p/demo/dbg.Norm1 gno.land/p/demo/dbg/dbg.gno:8\n")
+	assert.Contains(t, out, "> gno.land/p/demo/dbg.abs gno.land/p/demo/dbg/dbg.gno:12\n")
+	assert.Contains(t, out, "> gno.land/p/demo/dbg.abs gno.land/p/demo/dbg/dbg.gno:15\n")
+	assert.NotContains(t, out, "dbg.gno:13\n")
+
+	// exiting stops the execution.
+	var out2 bytes.Buffer
+	debugger := NewDebugger(strings.NewReader("s\nexit\n"), &out2)
+	assert.PanicsWithValue(t, func() {
+		runDebugged(debugger, body, S(Call(X("Main1"))))
+	}, ErrDebuggerExit)
+	assert.True(t, debugger.Exited())
+}
+
+// runDebugged runs stmt in the package dbg of body, with the debugger
+// attached.
+func runDebugged(debugger *Debugger, body string, stmt Stmt) {
+	m := NewMachineWithOptions(MachineOptions{PkgPath: "gno.land/p/demo/dbg"})
+	defer m.Release()
+	m.RunMemPackage(&std.MemPackage{
+		Name:  "dbg",
+		Path:  "gno.land/p/demo/dbg",
+		Files: []*std.MemFile{{Name: "dbg.gno", Body: body}},
+	}, false)
+	m.Debugger = debugger
+	m.RunStatement(stmt)
+}
@@ -0,0 +1,899 @@
+package gnolang
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Generics are implemented by the preprocessor through instantiation (or
+// monomorphization): generic function and type declarations are removed from
+// their file before predefinition and kept as templates on the package node.
+// Each distinct list of type arguments produces a copy of the template where
+// the type parameters are replaced by the type arguments. The copy is then
+// predefined and preprocessed like any other declaration, in the context of
+// the file that declares the template.
+//
+// Instantiated types are declared types named after the template and their
+// type arguments, e.g. "Tree[string]", and belong to the package declaring
+// the template. Instantiated functions are not declared in any block; they
+// are referenced through constant expressions.
+
+// genericRegistry holds the generic declarations of a package.
+type genericRegistry struct {
+	decls       map[Name]*genericDecl
+	constraints map[Name]*genericConstraint
+	depth       int      // number of instantiations in progress
+	pending     []func() // deferred preprocessing of bodies
+}
+
+// genericConstraint is a constraint interface with a type set, like
+// interface{ ~int | ~string }.
+type genericConstraint struct {
+	last  BlockNode
+	terms Exprs
+}
+
+// genericDecl is a generic function or type declaration.
+type genericDecl struct {
+	last      BlockNode // file (or package) of the declaration
+	decl      Decl      // *FuncDecl or *TypeDecl
+	methods   []*genericMethod
+	instances map[string]*genericInstance
+}
+
+// genericMethod is a method declared on a generic type.
+type genericMethod struct {
+	last    BlockNode
+	decl    *FuncDecl
+	tparams []Name // receiver type parameter names
+}
+
+type genericInstance struct {
+	targs []Type
+	t     Type       // *DeclaredType or *FuncType
+	fv    *FuncValue // for function instances
+	done  bool       // false while the instantiation is in progress
+}
+
+// persist saves the instantiated type in store. Instances are memoized on
+// the package node, which is shared by the forked stores of queries and
+// simulations: an instance created by one of them must still be saved in the
+// store of the first transaction using it, for it to be found after a
+// restart.
+func (inst *genericInstance) persist(store Store) {
+	if store == nil || !inst.done {
+		return
+	}
+	if dt, ok := inst.t.(*DeclaredType); ok {
+		store.SetType(dt)
+	}
+}
+
+func (pn *PackageNode) getGenerics() *genericRegistry {
+	if pn.generics == nil {
+		pn.generics = &genericRegistry{
+			decls:       make(map[Name]*genericDecl),
+			constraints: make(map[Name]*genericConstraint),
+		}
+	}
+	return pn.generics
+}
+
+func (g *genericDecl) typeParams() FieldTypeExprs {
+	switch d := g.decl.(type) {
+	case *FuncDecl:
+		return d.TypeParams
+	case *TypeDecl:
+		return d.TypeParams
+	default:
+		panic("should not happen")
+	}
+}
+
+func (g *genericDecl) isFunc() bool {
+	_, ok := g.decl.(*FuncDecl)
+	return ok
+}
+
+// ----------------------------------------
+// Registration
+
+// registerGenerics removes the generic declarations (and the methods of
+// generic types) from the decls of each file in fns, and registers them on
+// pn. It also records the type sets of constraint interfaces.
+func registerGenerics(pn *PackageNode, fns ...*FileNode) {
+	var methods []*genericMethod
+	for _, fn := range fns {
+		decls := fn.Decls[:0]
+		for _, d := range fn.Decls {
+			if m, ok := registerGenericDecl(pn, fn, d); ok {
+				if m != nil {
+					methods = append(methods, m)
+				}
+				continue
+			}
+			decls = append(decls, d)
+		}
+		fn.Decls = decls
+	}
+	for _, m := range methods {
+		attachGenericMethod(pn, m)
+	}
+}
+
+// registerGenericDecl registers d on pn if it is a generic declaration, and
+// returns true. Methods of generic types are returned to be attached once
+// all types are registered.
+func registerGenericDecl(pn *PackageNode, last BlockNode, d Decl) (*genericMethod, bool) {
+	reg := pn.getGenerics()
+	switch d := d.(type) {
+	case *TypeDecl:
+		if it, ok := d.Type.(*InterfaceTypeExpr); ok && len(it.Types) > 0 {
+			reg.constraints[d.Name] = &genericConstraint{
+				last:  last,
+				terms: it.Types,
+			}
+		}
+		if len(d.TypeParams) == 0 {
+			return nil, false
+		}
+		if d.IsAlias {
+			panic(fmt.Sprintf("generic type alias %s is not supported", d.Name))
+		}
+		registerGenericName(pn, d.Name)
+		reg.decls[d.Name] = &genericDecl{
+			last:      last,
+			decl:      d,
+			instances: make(map[string]*genericInstance),
+		}
+		return nil, true
+	case *FuncDecl:
+		if len(d.TypeParams) > 0 {
+			if d.IsMethod {
+				panic(fmt.Sprintf("method %s cannot have type parameters", d.Name))
+			}
+			if d.Body == nil {
+				panic(fmt.Sprintf("generic function %s must have a body", d.Name))
+			}
+			registerGenericName(pn, d.Name)
+			reg.decls[d.Name] = &genericDecl{
+				last:      last,
+				decl:      d,
+				instances: make(map[string]*genericInstance),
+			}
+			return nil, true
+		}
+		if !d.IsMethod {
+			return nil, false
+		}
+		rt := d.Recv.Type
+		if sx, ok := rt.(*StarExpr); ok {
+			rt = sx.X
+		}
+		var idxs Exprs
+		switch rx := rt.(type) {
+		case *IndexExpr:
+			idxs = Exprs{rx.Index}
+		case *IndexListExpr:
+			idxs = rx.Indices
+		default:
+			return nil, false
+		}
+		tparams := make([]Name, len(idxs))
+		for i, ix := range idxs {
+			nx, ok := ix.(*NameExpr)
+			if !ok {
+				panic(fmt.Sprintf(
+					"receiver type parameter %s must be an identifier", ix))
+			}
+			tparams[i] = nx.Name
+		}
+		return &genericMethod{
+			last:    last,
+			decl:    d,
+			tparams: tparams,
+		}, true
+	default:
+		return nil, false
+	}
+}
+
+func registerGenericName(pn *PackageNode, n Name) {
+	if isUverseName(n) {
+		panic(fmt.Sprintf("builtin identifiers cannot be shadowed: %s", n))
+	}
+	if _, exists := pn.getGenerics().decls[n]; exists {
+		panic(fmt.Sprintf("redeclaration of generic %s", n))
+	}
+	if _, exists := pn.GetLocalIndex(n); exists {
+		panic(fmt.Sprintf("redeclaration of %s", n))
+	}
+}
+
+func attachGenericMethod(pn *PackageNode, m *genericMethod) {
+	rt := m.decl.Recv.Type
+	if sx, ok := rt.(*StarExpr); ok {
+		rt = sx.X
+	}
+	var rx Expr
+	switch cx := rt.(type) {
+	case *IndexExpr:
+		rx = cx.X
+	case *IndexListExpr:
+		rx = cx.X
+	}
+	nx, ok := rx.(*NameExpr)
+	if !ok {
+		panic(fmt.Sprintf("invalid receiver type %s", m.decl.Recv.Type))
+	}
+	g := pn.getGenerics().decls[nx.Name]
+	if g == nil || g.isFunc() {
+		panic(fmt.Sprintf("%s is not a generic type", nx.Name))
+	}
+	if len(m.tparams) != len(g.typeParams()) {
+		panic(fmt.Sprintf(
+			"receiver of method %s.%s has %d type parameters, expected %d",
+			nx.Name, m.decl.Name, len(m.tparams), len(g.typeParams())))
+	}
+	for _, om := range g.methods {
+		if om.decl.Name == m.decl.Name {
+			panic(fmt.Sprintf("redeclaration of method %s.%s",
+				nx.Name, m.decl.Name))
+		}
+	}
+	g.methods = append(g.methods, m)
+}
+
+// ----------------------------------------
+// Lookup
+
+// lookupGeneric returns the generic declaration x refers to, if any. x may be
+// a name declared in the package of last, or a selector of an imported
+// package. Local declarations shadow generic declarations.
+func lookupGeneric(store Store, last BlockNode, x Expr) *genericDecl {
+	switch cx := x.(type) {
+	case *NameExpr:
+		reg := packageOf(last).generics
+		if reg == nil {
+			return nil
+		}
+		g := reg.decls[cx.Name]
+		if g == nil {
+			return nil
+		}
+		if last.GetValueRef(store, cx.Name) != nil {
+			return nil // shadowed.
+		}
+		return g
+	case *SelectorExpr:
+		nx, ok := cx.X.(*NameExpr)
+		if !ok {
+			return nil
+		}
+		tv := last.GetValueRef(store, nx.Name)
+		if tv == nil {
+			return nil
+		}
+		pv, ok := tv.V.(*PackageValue)
+		if !ok {
+			return nil
+		}
+		pn := pv.GetPackageNode(store)
+		if pn.generics == nil {
+			return nil
+		}
+		g := pn.generics.decls[cx.Sel]
+		if g != nil && !isUpper(string(cx.Sel)) {
+			panic(fmt.Sprintf("cannot refer to unexported name %s", cx))
+		}
+		return g
+	default:
+		return nil
+	}
+}
+
+// isGenericName returns true if n refers to a generic declaration of the
+// package of last.
+func isGenericName(last BlockNode, n Name) bool {
+	reg := packageOf(last).generics
+	if reg == nil {
+		return false
+	}
+	_, ok := reg.decls[n]
+	return ok
+}
+
+// ----------------------------------------
+// Instantiation
+
+// instantiateExpr instantiates the generic g referred to by x with the type
+// arguments idxs, and returns the (preprocessed) expression for the
+// instance.
+func instantiateExpr(store Store, last BlockNode, x Expr, g *genericDecl, idxs Exprs) Expr {
+	targs := evalTypeArgs(store, last, idxs)
+	if len(targs) < len(g.typeParams()) && g.isFunc() {
+		panic(fmt.Sprintf(
+			"cannot use generic function %s without instantiation", x))
+	}
+	inst := g.instantiate(store, targs)
+	return instanceExpr(x, inst)
+}
+
+func evalTypeArgs(store Store, last BlockNode, idxs Exprs) []Type {
+	targs := make([]Type, len(idxs))
+	for i, ix := range idxs {
+		ix = Preprocess(store, last, ix).(Expr)
+		idxs[i] = ix
+		targs[i] = evalStaticType(store, last, ix)
+	}
+	return targs
+}
+
+func instanceExpr(source Expr, inst *genericInstance) Expr {
+	if inst.fv == nil {
+		tx := constType(source, inst.t)
+		tx.SetLine(source.GetLine())
+		return tx
+	}
+	cx := &ConstExpr{Source: source}
+	cx.T = inst.t
+	cx.V = inst.fv.Copy(nilAllocator)
+	cx.SetLine(source.GetLine())
+	cx.SetAttribute(ATTR_PREPROCESSED, true)
+	setConstAttrs(cx)
+	return cx
+}
+
+// instantiate returns the instance of g for targs, creating it if needed.
+func (g *genericDecl) instantiate(store Store, targs []Type) *genericInstance {
+	tparams := g.typeParams()
+	if len(targs) != len(tparams) {
+		panic(fmt.Sprintf(
+			"wrong number of type arguments for %s: want %d got %d",
+			g.decl.GetDeclNames()[0], len(tparams), len(targs)))
+	}
+	key := typeArgsKey(targs)
+	if inst, ok := g.instances[key]; ok {
+		inst.persist(store)
+		return inst
+	}
+	for i := range tparams {
+		g.checkConstraint(store, i, targs)
+	}
+	pn := packageOf(g.last)
+	reg := pn.getGenerics()
+	reg.depth++
+	ok := false
+	defer func() {
+		reg.depth--
+		if !ok {
+			// drop the incomplete instance, and any
+			// deferred work that depends on it.
+			delete(g.instances, key)
+			if reg.depth == 0 {
+				reg.pending = nil
+			}
+		}
+	}()
+	var inst *genericInstance
+	switch d := g.decl.(type) {
+	case *TypeDecl:
+		inst = g.instantiateType(store, pn, d, targs, key)
+	case *FuncDecl:
+		inst = g.instantiateFunc(store, pn, d, targs, key)
+	}
+	if reg.depth == 1 {
+		reg.flush()
+	}
+	inst.done = true
+	ok = true
+	return inst
+}
+
+func (g *genericDecl) instantiateType(store Store, pn *PackageNode, d *TypeDecl, targs []Type, key string) *genericInstance {
+	name := Name(fmt.Sprintf("%s[%s]", d.Name, key))
+	inst := &genericInstance{targs: targs}
+	var st Type
+	if store != nil {
+		st = store.GetTypeSafe(DeclaredTypeID(pn.PkgPath, name))
+	}
+	if st != nil {
+		// already instantiated, e.g. before a restart.
+		inst.t = st.(*DeclaredType)
+		g.instances[key] = inst
+	} else {
+		td := copyWithPositions(d).(*TypeDecl)
+		substituteTypeParams(td, d.TypeParams, targs)
+		// the placeholder supports recursive references.
+		dt := &DeclaredType{
+			PkgPath: pn.PkgPath,
+			Name:    name,
+			Base:    placeholderTypeOf(td.Type),
+		}
+		inst.t = dt
+		g.instances[key] = inst
+		td.Type = Preprocess(store, g.last, td.Type).(Expr)
+		bt := evalStaticType(store, g.last, td.Type)
+		dt.Base = baseOf(bt)
+		dt.Seal()
+	}
+	dt := inst.t.(*DeclaredType)
+	// define the methods now, and preprocess their bodies later.
+	reg := pn.getGenerics()
+	for _, m := range g.methods {
+		md := copyWithPositions(m.decl).(*FuncDecl)
+		params := make(FieldTypeExprs, len(m.tparams))
+		for i, tp := range m.tparams {
+			params[i] = FieldTypeExpr{Name: tp}
+		}
+		substituteTypeParams(md, params, targs)
+		setInstanceLocations(pn.PkgPath, m.last, key, md)
+		predefineNow(store, m.last, md)
+		mlast := m.last
+		reg.pending = append(reg.pending, func() {
+			Preprocess(store, mlast, md)
+			saveInstanceNodes(store, md)
+		})
+	}
+	if store != nil {
+		store.SetType(dt)
+	}
+	return inst
+}
+
+func (g *genericDecl) instantiateFunc(store Store, pn *PackageNode, d *FuncDecl, targs []Type, key string) *genericInstance {
+	fd := copyWithPositions(d).(*FuncDecl)
+	fd.TypeParams = nil
+	substituteTypeParams(fd, d.TypeParams, targs)
+	fd.Name = Name(fmt.Sprintf("%s[%s]", d.Name, key))
+	setInstanceLocations(pn.PkgPath, g.last, key, fd)
+	fd.Type = *Preprocess(store, g.last, &fd.Type).(*FuncTypeExpr)
+	ft := evalStaticType(store, g.last, &fd.Type).(*FuncType)
+	fv := &FuncValue{
+		Type:       ft,
+		IsMethod:   false,
+		Source:     fd,
+		Name:       fd.Name,
+		Closure:    nil, // set lazily.
+		FileName:   fileNameOf(g.last),
+		PkgPath:    pn.PkgPath,
+		body:       fd.Body,
+		nativeBody: nil,
+	}
+	fd.SetAttribute(ATTR_PREDEFINED, true)
+	inst := &genericInstance{targs: targs, t: ft, fv: fv}
+	g.instances[key] = inst
+	last := g.last
+	reg := pn.getGenerics()
+	reg.pending = append(reg.pending, func() {
+		Preprocess(store, last, fd)
+		saveInstanceNodes(store, fd)
+	})
+	return inst
+}
+
+// flush preprocesses the bodies of the instantiated functions and methods.
+// It is deferred until no instantiation is in progress, so that the bodies
+// may refer to the complete instantiated types.
+func (reg *genericRegistry) flush() {
+	for len(reg.pending) > 0 {
+		f := reg.pending[0]
+		reg.pending = reg.pending[1:]
+		f()
+	}
+}
+
+// deferGenerics defers the preprocessing of instance bodies until the
+// returned function is called, e.g. until all declarations of a package
+// are predefined.
+func deferGenerics(pn *PackageNode) func() {
+	reg := pn.getGenerics()
+	reg.depth++
+	return func() {
+		reg.depth--
+		if r := recover(); r != nil {
+			if reg.depth == 0 {
+				reg.pending = nil
+			}
+			panic(r)
+		}
+		if reg.depth == 0 {
+			reg.depth++
+			reg.flush()
+			reg.depth--
+		}
+	}
+}
+
+func typeArgsKey(targs []Type) string {
+	strs := make([]string, len(targs))
+	for i, t := range targs {
+		strs[i] = t.TypeID().String()
+	}
+	return strings.Join(strs, ",")
+}
+
+func placeholderTypeOf(x Expr) Type {
+	switch x.(type) {
+	case *FuncTypeExpr:
+		return &FuncType{}
+	case *ArrayTypeExpr:
+		return &ArrayType{}
+	case *SliceTypeExpr:
+		return &SliceType{}
+	case *InterfaceTypeExpr:
+		return &InterfaceType{}
+	case *ChanTypeExpr:
+		return &ChanType{}
+	case *MapTypeExpr:
+		return &MapType{}
+	case *StructTypeExpr:
+		return &StructType{}
+	case *StarExpr:
+		return &PointerType{}
+	default:
+		return nil
+	}
+}
+
+// ----------------------------------------
+// Constraints
+
+func (g *genericDecl) checkConstraint(store Store, i int, targs []Type) {
+	tp := g.typeParams()[i]
+	t := targs[i]
+	cx := copyWithPositions(tp.Type).(Expr)
+	cx = substituteTypeParams(cx, g.typeParams(), targs).(Expr)
+	var gc *genericConstraint
+	var methods Expr
+	switch ccx := cx.(type) {
+	case *NameExpr:
+		if g.last.GetValueRef(store, ccx.Name) == nil {
+			switch ccx.Name {
+			case "comparable":
+				if !isComparableType(t) {
+					panic(fmt.Sprintf("%s does not satisfy comparable", t))
+				}
+				return
+			case "any":
+				return
+			}
+		}
+		gc = packageOf(g.last).getGenerics().constraints[ccx.Name]
+		methods = cx
+	case *SelectorExpr:
+		if nx, ok := ccx.X.(*NameExpr); ok {
+			if tv := g.last.GetValueRef(store, nx.Name); tv != nil {
+				if pv, ok := tv.V.(*PackageValue); ok {
+					pn := pv.GetPackageNode(store)
+					gc = pn.getGenerics().constraints[ccx.Sel]
+				}
+			}
+		}
+		methods = cx
+	case *BinaryExpr, *UnaryExpr:
+		gc = &genericConstraint{last: g.last, terms: flattenTypeSet(cx)}
+	case *InterfaceTypeExpr:
+		gc = &genericConstraint{last: g.last, terms: ccx.Types}
+		methods = &InterfaceTypeExpr{Methods: ccx.Methods}
+	default:
+		methods = cx
+	}
+	if methods != nil {
+		methods = Preprocess(store, g.last, methods).(Expr)
+		mt := evalStaticType(store, g.last, methods)
+		if it, ok := baseOf(mt).(*InterfaceType); ok {
+			if !it.IsImplementedBy(t) {
+				panic(fmt.Sprintf(
+					"%s does not satisfy %s (missing method)", t, mt))
+			}
+		} else {
+			// a non-interface constraint is a type set
+			// of a single type.
+			gc = &genericConstraint{last: g.last, terms: Exprs{constType(cx, mt)}}
+		}
+	}
+	if gc == nil || len(gc.terms) == 0 {
+		return
+	}
+	strs := make([]string, len(gc.terms))
+	for i, term := range gc.terms {
+		tilde := false
+		if ux, ok := term.(*UnaryExpr); ok && ux.Op == TILDE {
+			tilde = true
+			term = ux.X
+		}
+		var tt Type
+		if ctx, ok := term.(*constTypeExpr); ok {
+			tt = ctx.Type
+		} else {
+			term = copyWithPositions(term).(Expr)
+			term = Preprocess(store, gc.last, term).(Expr)
+			tt = evalStaticType(store, gc.last, term)
+		}
+		strs[i] = tt.String()
+		if tilde {
+			strs[i] = "~" + strs[i]
+			if baseOf(t).TypeID() == tt.TypeID() {
+				return
+			}
+		} else if t.TypeID() == tt.TypeID() {
+			return
+		}
+	}
+	ts := strings.Join(strs, " | ")
+	cs := ts
+	if nx, ok := tp.Type.(*NameExpr); ok {
+		cs = string(nx.Name)
+	}
+	panic(fmt.Sprintf("%s does not satisfy %s (%s missing in %s)",
+		t, cs, t, ts))
+}
+
+func flattenTypeSet(x Expr) Exprs {
+	if bx, ok := x.(*BinaryExpr); ok && bx.Op == BOR {
+		return append(flattenTypeSet(bx.Left), flattenTypeSet(bx.Right)...)
+	}
+	return Exprs{x}
+}
+
+func isComparableType(t Type) bool {
+	switch ct := baseOf(t).(type) {
+	case *SliceType, *MapType, *FuncType:
+		return false
+	case *ArrayType:
+		return isComparableType(ct.Elt)
+	case *StructType:
+		for _, f := range ct.Fields {
+			if !isComparableType(f.Type) {
+				return false
+			}
+		}
+		return true
+	default:
+		return true
+	}
+}
+
+// ----------------------------------------
+// Inference
+
+// genericCallOf returns the generic function called by cx, with any
+// explicit (partial) type arguments, if the type arguments must be
+// inferred from the call arguments.
+func genericCallOf(store Store, last BlockNode, cx *CallExpr) (*genericDecl, Exprs) {
+	var x Expr = cx.Func
+	var idxs Exprs
+	switch fx := cx.Func.(type) {
+	case *IndexExpr:
+		x, idxs = fx.X, Exprs{fx.Index}
+	case *IndexListExpr:
+		x, idxs = fx.X, fx.Indices
+	}
+	g := lookupGeneric(store, last, x)
+	if g == nil || !g.isFunc() || len(idxs) >= len(g.typeParams()) {
+		return nil, nil
+	}
+	return g, idxs
+}
+
+// inferGenericCall infers the type arguments of the generic function called
+// by cx from its explicit type arguments idxs and from the types of its
+// arguments, and returns the expression for the instance.
+func inferGenericCall(store Store, last BlockNode, cx *CallExpr, g *genericDecl, idxs Exprs) Expr {
+	fd := g.decl.(*FuncDecl)
+	tparams := fd.TypeParams
+	bound := make(map[Name]Type, len(tparams))
+	for i, t := range evalTypeArgs(store, last, idxs) {
+		bound[tparams[i].Name] = t
+	}
+	isParam := func(n Name) bool {
+		for _, tp := range tparams {
+			if tp.Name == n {
+				return true
+			}
+		}
+		return false
+	}
+	// preprocess the arguments to get their types.
+	var ats []Type
+	for i := range cx.Args {
+		cx.Args[i] = Preprocess(store, last, cx.Args[i]).(Expr)
+		at := evalStaticTypeOf(store, last, cx.Args[i])
+		if tt, ok := at.(*tupleType); ok && len(cx.Args) == 1 {
+			ats = append(ats, tt.Elts...)
+		} else {
+			ats = append(ats, at)
+		}
+	}
+	params := fd.Type.Params
+	paramOf := func(i int) Expr {
+		if n := len(params); n > 0 {
+			if stx, ok := params[n-1].Type.(*SliceTypeExpr); ok && stx.Vrd && i >= n-1 {
+				if cx.Varg {
+					return &SliceTypeExpr{Elt: stx.Elt}
+				}
+				return stx.Elt
+			}
+		}
+		if i < len(params) {
+			return params[i].Type
+		}
+		return nil
+	}
+	// typed arguments first, then untyped constants.
+	for i, at := range ats {
+		if at != nil && !isUntyped(at) {
+			unifyTypeParams(store, g, isParam, bound, paramOf(i), at)
+		}
+	}
+	for i, at := range ats {
+		if at != nil && isUntyped(at) {
+			if nx, ok := paramOf(i).(*NameExpr); ok && isParam(nx.Name) {
+				if _, ok := bound[nx.Name]; !ok {
+					bound[nx.Name] = defaultTypeOf(at)
+				}
+			}
+		}
+	}
+	targs := make([]Type, len(tparams))
+	for i, tp := range tparams {
+		t, ok := bound[tp.Name]
+		if !ok {
+			panic(fmt.Sprintf("cannot infer %s in call to %s", tp.Name, cx.Func))
+		}
+		targs[i] = t
+	}
+	inst := g.instantiate(store, targs)
+	return instanceExpr(cx.Func, inst)
+}
+
+// unifyTypeParams binds the type parameters in the (template) type expression
+// x by matching it against t.
+func unifyTypeParams(store Store, g *genericDecl, isParam func(Name) bool, bound map[Name]Type, x Expr, t Type) {
+	switch cx := x.(type) {
+	case *NameExpr:
+		if !isParam(cx.Name) {
+			return
+		}
+		if bt, ok := bound[cx.Name]; ok {
+			if bt.TypeID() != t.TypeID() {
+				panic(fmt.Sprintf(
+					"type %s does not match inferred type %s for %s",
+					t, bt, cx.Name))
+			}
+			return
+		}
+		bound[cx.Name] = t
+	case *StarExpr:
+		if pt, ok := baseOf(t).(*PointerType); ok {
+			unifyTypeParams(store, g, isParam, bound, cx.X, pt.Elt)
+		}
+	case *SliceTypeExpr:
+		if st, ok := baseOf(t).(*SliceType); ok {
+			unifyTypeParams(store, g, isParam, bound, cx.Elt, st.Elt)
+		}
+	case *ArrayTypeExpr:
+		if at, ok := baseOf(t).(*ArrayType); ok {
+			unifyTypeParams(store, g, isParam, bound, cx.Elt, at.Elt)
+		}
+	case *MapTypeExpr:
+		if mt, ok := baseOf(t).(*MapType); ok {
+			unifyTypeParams(store, g, isParam, bound, cx.Key, mt.Key)
+			unifyTypeParams(store, g, isParam, bound, cx.Value, mt.Value)
+		}
+	case *FuncTypeExpr:
+		if ft, ok := baseOf(t).(*FuncType); ok &&
+			len(ft.Params) == len(cx.Params) &&
+			len(ft.Results) == len(cx.Results) {
+			for i := range cx.Params {
+				unifyTypeParams(store, g, isParam, bound, cx.Params[i].Type, ft.Params[i].Type)
+			}
+			for i := range cx.Results {
+				unifyTypeParams(store, g, isParam, bound, cx.Results[i].Type, ft.Results[i].Type)
+			}
+		}
+	case *IndexExpr, *IndexListExpr:
+		// an instance of a generic type.
+		var gx Expr
+		var idxs Exprs
+		if ix, ok := cx.(*IndexExpr); ok {
+			gx, idxs = ix.X, Exprs{ix.Index}
+		} else {
+			ilx := cx.(*IndexListExpr)
+			gx, idxs = ilx.X, ilx.Indices
+		}
+		tg := lookupGeneric(store, g.last, gx)
+		if tg == nil {
+			return
+		}
+		for _, inst := range tg.instances {
+			if inst.t == t && len(inst.targs) == len(idxs) {
+				for i, ix := range idxs {
+					unifyTypeParams(store, g, isParam, bound, ix, inst.targs[i])
+				}
+				return
+			}
+		}
+	}
+}
+
+// ----------------------------------------
+// Copying and substitution
+
+// copyWithPositions returns a deep copy of n, like n.Copy(), but keeping the
+// line numbers and labels of the nodes.
+func copyWithPositions(n Node) Node {
+	nc := n.Copy()
+	orig := collectNodes(n)
+	copied := collectNodes(nc)
+	if len(orig) != len(copied) {
+		panic("should not happen")
+	}
+	for i, on := range orig {
+		copied[i].SetLine(on.GetLine())
+		if label := on.GetLabel(); label != "" {
+			copied[i].SetLabel(label)
+		}
+	}
+	return nc
+}
+
+func collectNodes(n Node) []Node {
+	var ns []Node
+	Transcribe(n, func(ns_ []Node, ftype TransField, index int, n Node, stage TransStage) (Node, TransCtrl) {
+		if stage == TRANS_ENTER {
+			ns = append(ns, n)
+		}
+		return n, TRANS_CONTINUE
+	})
+	return ns
+}
+
+// substituteTypeParams replaces the names of the type parameters in n with
+// their type arguments.
+func substituteTypeParams(n Node, tparams FieldTypeExprs, targs []Type) Node {
+	return Transcribe(n, func(ns []Node, ftype TransField, index int, n Node, stage TransStage) (Node, TransCtrl) {
+		if stage != TRANS_ENTER {
+			return n, TRANS_CONTINUE
+		}
+		nx, ok := n.(*NameExpr)
+		if !ok || ftype == TRANS_COMPOSITE_KEY {
+			return n, TRANS_CONTINUE
+		}
+		for i, tp := range tparams {
+			if tp.Name == nx.Name && tp.Name != "_" {
+				tx := constType(nx, targs[i])
+				tx.SetLine(nx.GetLine())
+				return tx, TRANS_SKIP
+			}
+		}
+		return n, TRANS_CONTINUE
+	})
+}
+
+// setInstanceLocations sets the locations of the block nodes of an instance
+// declared in the file of last. The type arguments are appended to the file
+// name, to distinguish the nodes of different instances.
+func setInstanceLocations(pkgPath string, last BlockNode, key string, d Decl) {
+	fileName := string(fileNameOf(last))
+	if fileName == "" {
+		fileName = "."
+	}
+	SetNodeLocations(pkgPath, fmt.Sprintf("%s[%s]", fileName, key), d)
+}
+
+func saveInstanceNodes(store Store, d Decl) {
+	if store == nil {
+		return
+	}
+	Transcribe(d, func(ns []Node, ftype TransField, index int, n Node, stage TransStage) (Node, TransCtrl) {
+		if stage != TRANS_ENTER {
+			return n, TRANS_CONTINUE
+		}
+		if bn, ok := n.(BlockNode); ok {
+			store.SetBlockNode(bn)
+		}
+		return n, TRANS_CONTINUE
+	})
+}
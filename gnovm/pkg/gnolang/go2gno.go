@@ -128,6 +128,10 @@ func ParseFile(filename string, body string) (fn *FileNode, err error) {
 }
 
 func setLoc(fs *token.FileSet, pos token.Pos, n Node) Node {
+	if fs == nil {
+		// e.g. from ParseExpr().
+		return n
+	}
 	posn := fs.Position(pos)
 	n.SetLine(posn.Line)
 	return n
@@ -175,6 +179,11 @@ func Go2Gno(fs *token.FileSet, gon ast.Node) (n Node) {
 			X:     toExpr(fs, gon.X),
 			Index: toExpr(fs, gon.Index),
 		}
+	case *ast.IndexListExpr:
+		return &IndexListExpr{
+			X:       toExpr(fs, gon.X),
+			Indices: toExprs(fs, gon.Indices),
+		}
 	case *ast.SelectorExpr:
 		return &SelectorExpr{
 			X:   toExpr(fs, gon.X),
@@ -267,8 +276,10 @@ func Go2Gno(fs *token.FileSet, gon ast.Node) (n Node) {
 			Vrd: true,
 		}
 	case *ast.InterfaceType:
+		methods, types := toInterfaceElems(fs, gon.Methods)
 		return &InterfaceTypeExpr{
-			Methods: toFieldsFromList(fs, gon.Methods),
+			Methods: methods,
+			Types:   types,
 		}
 	case *ast.ChanType:
 		var dir ChanDir
@@ -334,6 +345,11 @@ func Go2Gno(fs *token.FileSet, gon ast.Node) (n Node) {
 		return &ExprStmt{
 			X: toExpr(fs, gon.X),
 		}
+	case *ast.GoStmt:
+		cx := toExpr(fs, gon.Call).(*CallExpr)
+		return &GoStmt{
+			Call: *cx,
+		}
 	case *ast.ForStmt:
 		return &ForStmt{
 			Init: toSimp(fs, gon.Init),
@@ -387,6 +403,15 @@ func Go2Gno(fs *token.FileSet, gon ast.Node) (n Node) {
 		return &ReturnStmt{
 			Results: toExprs(fs, gon.Results),
 		}
+	case *ast.SelectStmt:
+		return &SelectStmt{
+			Cases: toSelectCases(fs, gon.Body.List),
+		}
+	case *ast.SendStmt:
+		return &SendStmt{
+			Chan:  toExpr(fs, gon.Chan),
+			Value: toExpr(fs, gon.Value),
+		}
 	case *ast.TypeSwitchStmt:
 		switch as := gon.Assign.(type) {
 		case *ast.AssignStmt:
@@ -438,11 +463,12 @@ func Go2Gno(fs *token.FileSet, gon ast.Node) (n Node) {
 			body = Go2Gno(fs, gon.Body).(*BlockStmt).Body
 		}
 		return &FuncDecl{
-			IsMethod: isMethod,
-			Recv:     recv,
-			NameExpr: NameExpr{Name: name},
-			Type:     *type_,
-			Body:     body,
+			IsMethod:   isMethod,
+			Recv:       recv,
+			NameExpr:   NameExpr{Name: name},
+			TypeParams: toFieldsFromList(fs, gon.Type.TypeParams),
+			Type:       *type_,
+			Body:       body,
 		}
 	case *ast.GenDecl:
 		panic("unexpected *ast.GenDecl; use toDecls(fs,) instead")
@@ -524,6 +550,7 @@ var token2word = map[token.Token]Word{
 	token.LEQ:            LEQ,
 	token.GEQ:            GEQ,
 	token.DEFINE:         DEFINE,
+	token.TILDE:          TILDE,
 	token.BREAK:          BREAK,
 	token.CASE:           CASE,
 	token.CHAN:           CHAN,
@@ -631,9 +658,10 @@ func toDecls(fs *token.FileSet, gd *ast.GenDecl) (ds Decls) {
 			tipe := toExpr(fs, s.Type)
 			alias := s.Assign != 0
 			ds = append(ds, &TypeDecl{
-				NameExpr: NameExpr{Name: name},
-				Type:     tipe,
-				IsAlias:  alias,
+				NameExpr:   NameExpr{Name: name},
+				TypeParams: toFieldsFromList(fs, s.TypeParams),
+				Type:       tipe,
+				IsAlias:    alias,
 			})
 		case *ast.ValueSpec:
 			if gd.Tok == token.CONST {
@@ -718,6 +746,42 @@ func toFieldsFromList(fs *token.FileSet, fl *ast.FieldList) (ftxs []FieldTypeExp
 	}
 }
 
+// toInterfaceElems splits the elements of an interface type into its methods
+// (and embedded interfaces) and its type set elements, like ~int | string,
+// which may only appear in type parameter constraints.
+func toInterfaceElems(fs *token.FileSet, fl *ast.FieldList) (methods []FieldTypeExpr, types Exprs) {
+	if fl == nil {
+		return nil, nil
+	}
+	fields := make([]*ast.Field, 0, len(fl.List))
+	for _, f := range fl.List {
+		if len(f.Names) == 0 && isTypeSetElem(f.Type) {
+			types = append(types, toTypeSetTerms(fs, f.Type)...)
+		} else {
+			fields = append(fields, f)
+		}
+	}
+	return toFields(fs, fields...), types
+}
+
+func isTypeSetElem(gox ast.Expr) bool {
+	switch gox := gox.(type) {
+	case *ast.BinaryExpr:
+		return gox.Op == token.OR
+	case *ast.UnaryExpr:
+		return gox.Op == token.TILDE
+	default:
+		return false
+	}
+}
+
+func toTypeSetTerms(fs *token.FileSet, gox ast.Expr) Exprs {
+	if bx, ok := gox.(*ast.BinaryExpr); ok && bx.Op == token.OR {
+		return append(toTypeSetTerms(fs, bx.X), toTypeSetTerms(fs, bx.Y)...)
+	}
+	return Exprs{toExpr(fs, gox)}
+}
+
 func toFields(fs *token.FileSet, fields ...*ast.Field) (ftxs []FieldTypeExpr) {
 	if len(fields) == 0 {
 		return nil
@@ -784,6 +848,19 @@ func toClauses(fs *token.FileSet, csz []ast.Stmt) []SwitchClauseStmt {
 	return res
 }
 
+func toSelectCases(fs *token.FileSet, csz []ast.Stmt) []SelectCaseStmt {
+	res := make([]SelectCaseStmt, len(csz))
+	for i, cs := range csz {
+		cc := cs.(*ast.CommClause)
+		res[i] = SelectCaseStmt{
+			Comm: toSimp(fs, cc.Comm),
+			Body: toStmts(fs, cc.Body),
+		}
+		setLoc(fs, cc.Pos(), &res[i])
+	}
+	return res
+}
+
 func toSwitchClauseStmt(fs *token.FileSet, cc *ast.CaseClause) SwitchClauseStmt {
 	return SwitchClauseStmt{
 		Cases: toExprs(fs, cc.List),
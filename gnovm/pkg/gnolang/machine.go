@@ -14,6 +14,7 @@ import (
 
 	"github.com/gnolang/gno/tm2/pkg/errors"
 	"github.com/gnolang/gno/tm2/pkg/std"
+	"github.com/gnolang/gno/tm2/pkg/store"
 )
 
 // Exception represents a panic that originates from a gno program.
@@ -53,6 +54,9 @@ type Machine struct {
 	CheckTypes bool // not yet used
 	ReadOnly   bool
 	MaxCycles  int64
+	GasMeter   store.GasMeter // or nil to not charge gas.
+	Coverage   *Coverage      // or nil to not record executed statements.
+	Debugger   *Debugger      // or nil to run without stopping.
 
 	Output  io.Writer
 	Store   Store
@@ -93,9 +97,12 @@ type MachineOptions struct {
 	Output        io.Writer // default os.Stdout
 	Store         Store     // default NewStore(Alloc, nil, nil)
 	Context       interface{}
-	Alloc         *Allocator // or see MaxAllocBytes.
-	MaxAllocBytes int64      // or 0 for no limit.
-	MaxCycles     int64      // or 0 for no limit.
+	Alloc         *Allocator     // or see MaxAllocBytes.
+	MaxAllocBytes int64          // or 0 for no limit.
+	MaxCycles     int64          // or 0 for no limit.
+	GasMeter      store.GasMeter // or nil; charged for cycles and allocations.
+	Coverage      *Coverage      // or nil; records executed statements.
+	Debugger      *Debugger      // or nil; stops the execution interactively.
 }
 
 // the machine constructor gets spammed
@@ -128,6 +135,9 @@ func NewMachineWithOptions(opts MachineOptions) *Machine {
 	if alloc == nil {
 		alloc = NewAllocator(opts.MaxAllocBytes)
 	}
+	if opts.GasMeter != nil {
+		alloc.SetGasMeter(opts.GasMeter)
+	}
 	store := opts.Store
 	if store == nil {
 		// bare store, no stdlibs.
@@ -151,6 +161,9 @@ func NewMachineWithOptions(opts MachineOptions) *Machine {
 	mm.CheckTypes = checkTypes
 	mm.ReadOnly = readOnly
 	mm.MaxCycles = maxCycles
+	mm.GasMeter = opts.GasMeter
+	mm.Coverage = opts.Coverage
+	mm.Debugger = opts.Debugger
 	mm.Output = output
 	mm.Store = store
 	mm.Context = context
@@ -208,9 +221,36 @@ func (m *Machine) SetActivePackage(pv *PackageValue) {
 // to support cases of stdlibs processed through [RunMemPackagesWithOverrides].
 func (m *Machine) PreprocessAllFilesAndSaveBlockNodes() {
 	ch := m.Store.IterMemPackage()
+	var memPkgs []*std.MemPackage
+	byPath := make(map[string]*std.MemPackage)
 	for memPkg := range ch {
+		memPkgs = append(memPkgs, memPkg)
+		byPath[memPkg.Path] = memPkg
+	}
+	done := make(map[string]bool, len(memPkgs))
+	var preprocess func(memPkg *std.MemPackage)
+	preprocess = func(memPkg *std.MemPackage) {
+		if done[memPkg.Path] {
+			return
+		}
+		done[memPkg.Path] = true
 		fset := ParseMemPackage(memPkg)
+		// Upgraded packages may import packages added after
+		// them, so preprocess imports first.
+		for _, fn := range fset.Files {
+			for _, decl := range fn.Decls {
+				if id, ok := decl.(*ImportDecl); ok {
+					if dep, ok := byPath[id.PkgPath]; ok {
+						preprocess(dep)
+					}
+				}
+			}
+		}
 		pn := NewPackageNode(Name(memPkg.Name), memPkg.Path, fset)
+		if names := m.Store.GetPackageNames(memPkg.Path); names != nil {
+			// upgraded package.
+			pn.reserveNames(names)
+		}
 		m.Store.SetBlockNode(pn)
 		PredefineFileSet(m.Store, pn, fset)
 		for _, fn := range fset.Files {
@@ -231,6 +271,9 @@ func (m *Machine) PreprocessAllFilesAndSaveBlockNodes() {
 			// TODO ensure the files are the same.
 		}
 	}
+	for _, memPkg := range memPkgs {
+		preprocess(memPkg)
+	}
 }
 
 //----------------------------------------
@@ -283,6 +326,230 @@ func (m *Machine) runMemPackage(memPkg *std.MemPackage, save, overrides bool) (*
 	return pn, pv
 }
 
+// UpgradeMemPackage replaces the files of the existing package at memPkg.Path
+// with those of memPkg, and saves the package. The package value and its
+// objects are kept, and so are the values of the package variables declared
+// again; new variables are initialized, but init functions are not run.
+// Instead, if memPkg declares a migrate function, which must take no
+// parameters and return no values, it is run last.
+//
+// As other packages and objects may depend on it, every package-level name
+// must be declared again with the same type, and so must the methods of
+// declared types, whose underlying types may not change. Like init
+// functions, the migrate function of the previous version is dropped.
+func (m *Machine) UpgradeMemPackage(memPkg *std.MemPackage) (*PackageNode, *PackageValue) {
+	// parse files.
+	files := ParseMemPackage(memPkg)
+	if checkDuplicates(files) {
+		panic(fmt.Errorf("upgrading package %q: duplicate declarations not allowed", memPkg.Path))
+	}
+	pv := m.Store.GetPackage(memPkg.Path, false)
+	if pv == nil {
+		panic(fmt.Sprintf("cannot upgrade package %q: package does not exist",
+			memPkg.Path))
+	}
+	if pv.PkgName != Name(memPkg.Name) {
+		panic(fmt.Sprintf("cannot upgrade package %q: cannot change package name from %s to %s",
+			memPkg.Path, pv.PkgName, memPkg.Name))
+	}
+	rlm := pv.GetRealm()
+	if rlm == nil {
+		// pure packages keep the realm used upon deployment.
+		rlm = m.Store.GetPackageRealm(pv.PkgPath)
+		if rlm == nil {
+			panic(fmt.Sprintf("cannot upgrade package %q: missing realm info",
+				memPkg.Path))
+		}
+	}
+	opn := pv.GetPackageNode(m.Store)
+	pb := pv.GetBlock(m.Store)
+
+	// The values of the package block are referred to by index, so the
+	// names of the previous version keep their index, except for init
+	// and migrate functions which are not run again.
+	names := make([]Name, len(opn.Names))
+	vars := make(map[Name]struct{})
+	for i, n := range opn.Names {
+		if n == "" || n == "migrate" || strings.HasPrefix(string(n), "init.") {
+			continue
+		}
+		names[i] = n
+		otv := opn.Values[i]
+		if _, ok := otv.V.(*FuncValue); !ok && !opn.getLocalIsConst(n) &&
+			(otv.T == nil || otv.T.Kind() != TypeKind) {
+			vars[n] = struct{}{}
+		}
+	}
+	declared := make(map[Name]struct{})
+	migrates := false
+	for _, fn := range files.Files {
+		for _, decl := range fn.Decls {
+			if fd, ok := decl.(*FuncDecl); ok && !fd.IsMethod && fd.Name == "migrate" {
+				if len(fd.TypeParams) != 0 || len(fd.Type.Params) != 0 || len(fd.Type.Results) != 0 {
+					panic(fmt.Sprintf("cannot upgrade package %q: func migrate must have no parameters and no results",
+						memPkg.Path))
+				}
+				migrates = true
+			}
+			dnames := decl.GetDeclNames()
+			for _, n := range dnames {
+				declared[n] = struct{}{}
+			}
+			// variables are either all new, or all declared again.
+			if vd, ok := decl.(*ValueDecl); ok && !vd.Const {
+				_, old := vars[dnames[0]]
+				for _, n := range dnames[1:] {
+					if _, ok := vars[n]; ok != old {
+						panic(fmt.Sprintf("cannot upgrade package %q: cannot mix new and existing variables in declaration of %s",
+							memPkg.Path, n))
+					}
+				}
+			}
+		}
+	}
+	for _, n := range names {
+		if _, ok := declared[n]; n != "" && !ok {
+			panic(fmt.Sprintf("cannot upgrade package %q: missing declaration of %s",
+				memPkg.Path, n))
+		}
+	}
+
+	// Methods of the declared types of the package may be redefined.
+	var dts []*DeclaredType
+	var methods [][]TypedValue
+	for _, tv := range pb.Values {
+		if tvv, ok := tv.V.(TypeValue); ok {
+			if dt, ok := tvv.Type.(*DeclaredType); ok &&
+				dt.PkgPath == pv.PkgPath && !dt.upgrading {
+				dt.upgrading = true
+				dts = append(dts, dt)
+				methods = append(methods, append([]TypedValue(nil), dt.Methods...))
+			}
+		}
+	}
+	// The cached types are shared with other stores, so their methods are
+	// restored if the upgrade fails, or if its transaction is discarded.
+	restoreMethods := func() {
+		for i, dt := range dts {
+			dt.Methods = methods[i]
+		}
+	}
+	upgraded := false
+	defer func() {
+		for _, dt := range dts {
+			dt.upgrading = false
+		}
+		if !upgraded {
+			restoreMethods()
+			// restore the nodes of the previous version.
+			// XXX outside of a transaction, types declared by the
+			// new files are still cached.
+			for _, fn := range opn.FileSet.Files {
+				SaveBlockNodes(m.Store, fn)
+			}
+			m.Store.SetBlockNode(opn)
+		} else if ds, ok := m.Store.(*defaultStore); ok {
+			ds.onDiscard(restoreMethods)
+		}
+	}()
+
+	// Make the new package node.
+	pn := NewPackageNode(pv.PkgName, pv.PkgPath, files)
+	pn.reserveNames(names)
+	m.Store.SetBlockNode(pn)
+	pb.Source = pn
+	// The file blocks of the previous version are dropped, but not
+	// deleted, as function values may still refer to them.
+	pv.FNames = nil
+	pv.FBlocks = nil
+	pv.fBlocksMap = nil
+	m.SetActivePackage(pv)
+	m.Realm = rlm
+
+	// Predefine and preprocess the files, as in runFiles.
+	PredefineFileSet(m.Store, pn, files)
+	for _, fn := range files.Files {
+		fn = Preprocess(m.Store, pn, fn).(*FileNode)
+		SaveBlockNodes(m.Store, fn)
+		fb := m.Alloc.NewBlock(fn, pb)
+		fb.Values = make([]TypedValue, len(fn.StaticBlock.Values))
+		copy(fb.Values, fn.StaticBlock.Values)
+		pv.AddFileBlock(fn.Name, fb)
+		rlm.DidUpdate(pv, nil, fb)
+	}
+	for i, dt := range dts {
+		for j, tv := range methods[i] {
+			if fv := tv.V.(*FuncValue); dt.Methods[j].V == fv && !fv.IsNative() {
+				panic(fmt.Sprintf("cannot upgrade package %q: missing declaration of method %s.%s",
+					memPkg.Path, dt.Name, fv.Name))
+			}
+		}
+	}
+
+	// Check and set the values of the names declared again.
+	for i, n := range names {
+		if n == "" {
+			pb.Values[i] = TypedValue{}
+			continue
+		}
+		otv, ntv := opn.Values[i], pn.Values[i]
+		if opn.getLocalIsConst(n) != pn.getLocalIsConst(n) ||
+			typeIDOf(opn.Types[i]) != typeIDOf(pn.Types[i]) ||
+			typeIDOf(otv.T) != typeIDOf(ntv.T) {
+			panic(fmt.Sprintf("cannot upgrade package %q: cannot change declaration of %s",
+				memPkg.Path, n))
+		}
+		if ntv.T != nil && ntv.T.Kind() == TypeKind {
+			if otv.GetType().TypeID() != ntv.GetType().TypeID() {
+				panic(fmt.Sprintf("cannot upgrade package %q: cannot change type %s",
+					memPkg.Path, n))
+			}
+			pb.Values[i] = ntv
+		} else if fv, ok := ntv.V.(*FuncValue); ok {
+			fv = fv.Copy(nilAllocator)
+			fv.Closure = pv.fBlocksMap[fv.FileName]
+			pb.Values[i] = TypedValue{T: ntv.T, V: fv}
+		} else if pn.getLocalIsConst(n) {
+			pb.Values[i] = ntv
+		}
+	}
+	pn.PrepareNewValues(pv)
+	rlm.MarkDirty(pb)
+
+	// Initialize new variables.
+	fdeclared := make(map[Name]struct{}, len(vars))
+	for n := range vars {
+		fdeclared[n] = struct{}{}
+	}
+	m.runDeclarations(pn, files.Files, fdeclared, vars)
+
+	// Run the migrate function, if declared by the new files.
+	if migrates {
+		fv := pn.GetValueRef(m.Store, "migrate").V.(*FuncValue)
+		fb := pv.GetFileBlock(m.Store, fv.FileName)
+		m.PushBlock(fb)
+		m.RunFunc(fv.Name)
+		m.PopBlock()
+	}
+
+	// Save the package, and the names of its block.
+	rlm.FinalizeRealmTransaction(m.ReadOnly, m.Store)
+	m.Store.SetPackageRealm(rlm)
+	m.saveDeclaredTypes()
+	m.Store.SetPackageNames(pn.PkgPath, pn.Names)
+	m.Store.AddMemPackage(memPkg)
+	upgraded = true
+	return pn, pv
+}
+
+// typeIDOf returns the type id of t, or an empty id if t is nil.
+func typeIDOf(t Type) TypeID {
+	if t == nil {
+		return ""
+	}
+	return t.TypeID()
+}
+
 // checkDuplicates returns true if there duplicate declarations in the fset.
 func checkDuplicates(fset *FileSet) bool {
 	defined := make(map[Name]struct{}, 128)
@@ -545,6 +812,37 @@ func (m *Machine) runFiles(fns ...*FileNode) {
 	// Get new values across all files in package.
 	updates := pn.PrepareNewValues(pv)
 
+	// Declarations (and variable initializations).  This must happen
+	// after all files are preprocessed, because value decl may be out of
+	// order and depend on other files.
+	m.runDeclarations(pn, fns, fdeclared, nil)
+
+	// Run new init functions.
+	// Go spec: "To ensure reproducible initialization
+	// behavior, build systems are encouraged to present
+	// multiple files belonging to the same package in
+	// lexical file name order to a compiler."
+	for _, tv := range updates {
+		if tv.IsDefined() && tv.T.Kind() == FuncKind && tv.V != nil {
+			fv, ok := tv.V.(*FuncValue)
+			if !ok {
+				continue // skip native functions.
+			}
+			if strings.HasPrefix(string(fv.Name), "init.") {
+				fb := pv.GetFileBlock(m.Store, fv.FileName)
+				m.PushBlock(fb)
+				m.RunFunc(fv.Name)
+				m.PopBlock()
+			}
+		}
+	}
+}
+
+// runDeclarations runs the declarations of fns, each after the declarations
+// it depends on. Dependencies in fdeclared are not run again, and neither are
+// the declarations of the variables in skip.
+func (m *Machine) runDeclarations(pn *PackageNode, fns []*FileNode, fdeclared, skip map[Name]struct{}) {
+	pv := m.Package
 	// to detect loops in var declarations.
 	loopfindr := []Name{}
 	// recursive function for var declarations.
@@ -572,7 +870,7 @@ func (m *Machine) runFiles(fns ...*FileNode) {
 				} else { // is an undefined dependency.
 					panic(fmt.Sprintf(
 						"dependency %s not defined in fileset with files %v",
-						dep, fs.FileNames()))
+						dep, (&FileSet{Files: fns}).FileNames()))
 				}
 			}
 			// if dep already in loopfindr, abort.
@@ -601,34 +899,15 @@ func (m *Machine) runFiles(fns ...*FileNode) {
 		}
 	}
 
-	// Declarations (and variable initializations).  This must happen
-	// after all files are preprocessed, because value decl may be out of
-	// order and depend on other files.
-
 	// Run declarations.
 	for _, fn := range fns {
 		for _, decl := range fn.Decls {
-			runDeclarationFor(fn, decl)
-		}
-	}
-
-	// Run new init functions.
-	// Go spec: "To ensure reproducible initialization
-	// behavior, build systems are encouraged to present
-	// multiple files belonging to the same package in
-	// lexical file name order to a compiler."
-	for _, tv := range updates {
-		if tv.IsDefined() && tv.T.Kind() == FuncKind && tv.V != nil {
-			fv, ok := tv.V.(*FuncValue)
-			if !ok {
-				continue // skip native functions.
-			}
-			if strings.HasPrefix(string(fv.Name), "init.") {
-				fb := pv.GetFileBlock(m.Store, fv.FileName)
-				m.PushBlock(fb)
-				m.RunFunc(fv.Name)
-				m.PopBlock()
+			if vd, ok := decl.(*ValueDecl); ok && skip != nil {
+				if _, ok := skip[vd.NameExprs[0].Name]; ok {
+					continue
+				}
 			}
+			runDeclarationFor(fn, decl)
 		}
 	}
 }
@@ -648,8 +927,15 @@ func (m *Machine) savePackageValuesAndTypes() {
 		rlm := NewRealm(pv.PkgPath)
 		rlm.MarkNewReal(pv)
 		rlm.FinalizeRealmTransaction(m.ReadOnly, m.Store)
+		// save it too, for the ids of new objects upon upgrade.
+		m.Store.SetPackageRealm(rlm)
 	}
-	// save declared types.
+	m.saveDeclaredTypes()
+}
+
+// Save the declared types of the machine's package.
+func (m *Machine) saveDeclaredTypes() {
+	pv := m.Package
 	if bv, ok := pv.Block.(*Block); ok {
 		for _, tv := range bv.Values {
 			if tvv, ok := tv.V.(TypeValue); ok {
@@ -664,8 +950,10 @@ func (m *Machine) savePackageValuesAndTypes() {
 func (m *Machine) RunFunc(fn Name) {
 	defer func() {
 		if r := recover(); r != nil {
-			fmt.Printf("Machine.RunFunc(%q) panic: %v\n%s\n",
-				fn, r, m.String())
+			if r != ErrDebuggerExit {
+				fmt.Printf("Machine.RunFunc(%q) panic: %v\n%s\n",
+					fn, r, m.String())
+			}
 			panic(r)
 		}
 	}()
@@ -675,8 +963,10 @@ func (m *Machine) RunFunc(fn Name) {
 func (m *Machine) RunMain() {
 	defer func() {
 		if r := recover(); r != nil {
-			fmt.Printf("Machine.RunMain() panic: %v\n%s\n",
-				r, m.String())
+			if r != ErrDebuggerExit {
+				fmt.Printf("Machine.RunMain() panic: %v\n%s\n",
+					r, m.String())
+			}
 			panic(r)
 		}
 	}()
@@ -802,6 +1092,13 @@ func (m *Machine) RunDeclaration(d Decl) {
 	// Preprocess input using package block.  There should only
 	// be one block right now, and it's a *PackageNode.
 	pn := m.LastBlock().GetSource(m.Store).(*PackageNode)
+	// generic declarations are only instantiated upon use.
+	if gm, ok := registerGenericDecl(pn, pn, d); ok {
+		if gm != nil {
+			attachGenericMethod(pn, gm)
+		}
+		return
+	}
 	d = Preprocess(m.Store, pn, d).(Decl)
 	// do not SaveBlockNodes(m.Store, d).
 	pn.PrepareNewValues(m.Package)
@@ -970,7 +1267,13 @@ const (
 //----------------------------------------
 // "CPU" steps.
 
+// GasFactorCPU is the amount of gas charged per "cpu" cycle.
+const GasFactorCPU int64 = 1
+
 func (m *Machine) incrCPU(cycles int64) {
+	if m.GasMeter != nil {
+		m.GasMeter.ConsumeGas(cycles*GasFactorCPU, "CPU cycles")
+	}
 	m.Cycles += cycles
 	if m.MaxCycles != 0 && m.Cycles > m.MaxCycles {
 		panic("CPU cycle overrun")
@@ -75,6 +75,7 @@ const (
 	LEQ    // <=
 	GEQ    // >=
 	DEFINE // :=
+	TILDE  // ~
 
 	// Keywords
 	BREAK
@@ -229,6 +230,7 @@ func (x *BasicLitExpr) assertNode()        {}
 func (x *BinaryExpr) assertNode()          {}
 func (x *CallExpr) assertNode()            {}
 func (x *IndexExpr) assertNode()           {}
+func (x *IndexListExpr) assertNode()       {}
 func (x *SelectorExpr) assertNode()        {}
 func (x *SliceExpr) assertNode()           {}
 func (x *StarExpr) assertNode()            {}
@@ -283,6 +285,7 @@ var (
 	_ Node = &BinaryExpr{}
 	_ Node = &CallExpr{}
 	_ Node = &IndexExpr{}
+	_ Node = &IndexListExpr{}
 	_ Node = &SelectorExpr{}
 	_ Node = &SliceExpr{}
 	_ Node = &StarExpr{}
@@ -351,6 +354,7 @@ func (*BasicLitExpr) assertExpr()     {}
 func (*BinaryExpr) assertExpr()       {}
 func (*CallExpr) assertExpr()         {}
 func (*IndexExpr) assertExpr()        {}
+func (*IndexListExpr) assertExpr()    {}
 func (*SelectorExpr) assertExpr()     {}
 func (*SliceExpr) assertExpr()        {}
 func (*StarExpr) assertExpr()         {}
@@ -368,6 +372,7 @@ var (
 	_ Expr = &BinaryExpr{}
 	_ Expr = &CallExpr{}
 	_ Expr = &IndexExpr{}
+	_ Expr = &IndexListExpr{}
 	_ Expr = &SelectorExpr{}
 	_ Expr = &SliceExpr{}
 	_ Expr = &StarExpr{}
@@ -420,6 +425,14 @@ type IndexExpr struct { // X[Index]
 	HasOK bool // if true, is form: `value, ok := <X>[<Key>]
 }
 
+// IndexListExpr is only used for the instantiation of generic
+// functions and types with more than one type argument.
+type IndexListExpr struct { // X[Indices...]
+	Attributes
+	X       Expr  // generic function or type
+	Indices Exprs // type arguments
+}
+
 type SelectorExpr struct { // X.Sel
 	Attributes
 	X    Expr      // expression
@@ -621,6 +634,7 @@ type InterfaceTypeExpr struct {
 	Attributes
 	Methods FieldTypeExprs // list of methods
 	Generic Name           // for uverse generics
+	Types   Exprs          // type set elements; only valid in constraints
 }
 
 type ChanDir int
@@ -992,10 +1006,11 @@ type FuncDecl struct {
 	Attributes
 	StaticBlock
 	NameExpr
-	IsMethod bool
-	Recv     FieldTypeExpr // receiver (if method); or empty (if function)
-	Type     FuncTypeExpr  // function signature: parameters and results
-	Body                   // function body; or empty for external (non-Go) function
+	IsMethod   bool
+	Recv       FieldTypeExpr  // receiver (if method); or empty (if function)
+	TypeParams FieldTypeExprs // type parameters (if generic); or empty
+	Type       FuncTypeExpr   // function signature: parameters and results
+	Body                      // function body; or empty for external (non-Go) function
 }
 
 func (x *FuncDecl) GetDeclNames() []Name {
@@ -1043,8 +1058,9 @@ func (x *ValueDecl) GetDeclNames() []Name {
 type TypeDecl struct {
 	Attributes
 	NameExpr
-	Type    Expr // Name, SelectorExpr, StarExpr, or XxxTypes
-	IsAlias bool // type alias since Go 1.9
+	TypeParams FieldTypeExprs // type parameters (if generic); or empty
+	Type       Expr           // Name, SelectorExpr, StarExpr, or XxxTypes
+	IsAlias    bool           // type alias since Go 1.9
 }
 
 func (x *TypeDecl) GetDeclNames() []Name {
@@ -1317,6 +1333,8 @@ type PackageNode struct {
 	PkgPath string
 	PkgName Name
 	*FileSet
+
+	generics *genericRegistry // generic declarations; set by PredefineFileSet
 }
 
 func PackageNodeLocation(path string) Location {
@@ -1502,6 +1520,9 @@ type StaticBlock struct {
 
 	// temporary storage for rolling back redefinitions.
 	oldValues []oldValue
+
+	// indices of names not yet defined, see reserveNames.
+	reserved map[Name]uint16
 }
 
 type oldValue struct {
@@ -1837,6 +1858,15 @@ func (sb *StaticBlock) Define2(isConst bool, n Name, st Type, tv TypedValue) {
 		}
 		sb.Block.Values[idx] = tv
 		sb.Types[idx] = st
+	} else if idx, ok := sb.reserved[n]; ok {
+		// Defining a reserved name.
+		delete(sb.reserved, n)
+		sb.Names[idx] = n
+		if isConst {
+			sb.Consts = append(sb.Consts, n)
+		}
+		sb.Block.Values[idx] = tv
+		sb.Types[idx] = st
 	} else {
 		// The general case without re-definition.
 		sb.Names = append(sb.Names, n)
@@ -1849,6 +1879,27 @@ func (sb *StaticBlock) Define2(isConst bool, n Name, st Type, tv TypedValue) {
 	}
 }
 
+// reserveNames reserves the first indices of an empty block for names, such
+// that each is defined at the same index as in names. Until then, reserved
+// names are not found in the block. Empty names reserve indices that are
+// never defined. This is used for the package block of upgraded packages,
+// whose values are referred to by index.
+func (sb *StaticBlock) reserveNames(names []Name) {
+	if sb.NumNames != 0 {
+		panic("cannot reserve names in a non-empty block")
+	}
+	sb.reserved = make(map[Name]uint16, len(names))
+	for i, n := range names {
+		if n != "" {
+			sb.reserved[n] = uint16(i)
+		}
+	}
+	sb.Names = make([]Name, len(names))
+	sb.NumNames = uint16(len(names))
+	sb.Block.Values = make([]TypedValue, len(names))
+	sb.Types = make([]Type, len(names))
+}
+
 // Implements BlockNode
 func (sb *StaticBlock) SetStaticBlock(osb StaticBlock) {
 	*sb = osb
@@ -53,6 +53,13 @@ func (x *IndexExpr) Copy() Node {
 	}
 }
 
+func (x *IndexListExpr) Copy() Node {
+	return &IndexListExpr{
+		X:       x.X.Copy().(Expr),
+		Indices: copyExprs(x.Indices),
+	}
+}
+
 func (x *SelectorExpr) Copy() Node {
 	return &SelectorExpr{
 		X:   x.X.Copy().(Expr),
@@ -97,7 +104,7 @@ func (x *UnaryExpr) Copy() Node {
 
 func (x *CompositeLitExpr) Copy() Node {
 	return &CompositeLitExpr{
-		Type: x.Type.Copy().(Expr),
+		Type: copyExpr(x.Type),
 		Elts: copyKVs(x.Elts),
 	}
 }
@@ -141,6 +148,8 @@ func (x *SliceTypeExpr) Copy() Node {
 func (x *InterfaceTypeExpr) Copy() Node {
 	return &InterfaceTypeExpr{
 		Methods: copyFTs(x.Methods),
+		Generic: x.Generic,
+		Types:   copyExprs(x.Types),
 	}
 }
 
@@ -287,7 +296,7 @@ func (x *SelectStmt) Copy() Node {
 
 func (x *SelectCaseStmt) Copy() Node {
 	return &SelectCaseStmt{
-		Comm: x.Comm.Copy().(Stmt),
+		Comm: copyStmt(x.Comm),
 		Body: copyStmts(x.Body),
 	}
 }
@@ -301,10 +310,11 @@ func (x *SendStmt) Copy() Node {
 
 func (x *SwitchStmt) Copy() Node {
 	return &SwitchStmt{
-		Init:    copyStmt(x.Init),
-		X:       x.X.Copy().(Expr),
-		Clauses: copyCaseClauses(x.Clauses),
-		VarName: x.VarName,
+		Init:         copyStmt(x.Init),
+		X:            x.X.Copy().(Expr),
+		IsTypeSwitch: x.IsTypeSwitch,
+		Clauses:      copyCaseClauses(x.Clauses),
+		VarName:      x.VarName,
 	}
 }
 
@@ -317,10 +327,11 @@ func (x *SwitchClauseStmt) Copy() Node {
 
 func (x *FuncDecl) Copy() Node {
 	funcDecl := &FuncDecl{
-		NameExpr: *(x.NameExpr.Copy().(*NameExpr)),
-		IsMethod: x.IsMethod,
-		Type:     *(x.Type.Copy().(*FuncTypeExpr)),
-		Body:     copyStmts(x.Body),
+		NameExpr:   *(x.NameExpr.Copy().(*NameExpr)),
+		IsMethod:   x.IsMethod,
+		TypeParams: copyFTs(x.TypeParams),
+		Type:       *(x.Type.Copy().(*FuncTypeExpr)),
+		Body:       copyStmts(x.Body),
 	}
 	if x.IsMethod {
 		funcDecl.Recv = *(x.Recv.Copy().(*FieldTypeExpr))
@@ -346,9 +357,10 @@ func (x *ValueDecl) Copy() Node {
 
 func (x *TypeDecl) Copy() Node {
 	return &TypeDecl{
-		NameExpr: *(x.NameExpr.Copy().(*NameExpr)),
-		Type:     x.Type.Copy().(Expr),
-		IsAlias:  x.IsAlias,
+		NameExpr:   *(x.NameExpr.Copy().(*NameExpr)),
+		TypeParams: copyFTs(x.TypeParams),
+		Type:       x.Type.Copy().(Expr),
+		IsAlias:    x.IsAlias,
 	}
 }
 
@@ -45,6 +45,7 @@ var wordTokenStrings = map[Word]string{
 	LEQ:             "<=",
 	GEQ:             ">=",
 	DEFINE:          ":=",
+	TILDE:           "~",
 
 	// Branch operations
 	BREAK:       "break",
@@ -122,6 +123,10 @@ func (x IndexExpr) String() string {
 	return fmt.Sprintf("%s[%s]", x.X, x.Index)
 }
 
+func (x IndexListExpr) String() string {
+	return fmt.Sprintf("%s[%s]", x.X, x.Indices.String())
+}
+
 func (x SelectorExpr) String() string {
 	// NOTE: for debugging selector issues:
 	// return fmt.Sprintf("%s.(%v).%s", n.X, n.Path.Type, n.Sel)
@@ -204,9 +209,24 @@ func (x SliceTypeExpr) String() string {
 }
 
 func (x InterfaceTypeExpr) String() string {
+	if len(x.Types) > 0 {
+		return fmt.Sprintf("interface { %v; %s }", x.Methods, typeSetString(x.Types))
+	}
 	return fmt.Sprintf("interface { %v }", x.Methods)
 }
 
+func typeSetString(xs Exprs) string {
+	str := ""
+	for i, x := range xs {
+		if i == 0 {
+			str += x.String()
+		} else {
+			str += " | " + x.String()
+		}
+	}
+	return str
+}
+
 func (x ChanTypeExpr) String() string {
 	switch x.Dir {
 	case SEND:
@@ -408,8 +428,12 @@ func (x FuncDecl) String() string {
 	if x.IsMethod {
 		recv = "(" + x.Recv.String() + ") "
 	}
-	return fmt.Sprintf("func %s%s%s { %s }",
-		recv, x.Name, x.Type.String()[4:], x.Body.String())
+	tparams := ""
+	if len(x.TypeParams) > 0 {
+		tparams = "[" + x.TypeParams.String() + "]"
+	}
+	return fmt.Sprintf("func %s%s%s%s { %s }",
+		recv, x.Name, tparams, x.Type.String()[4:], x.Body.String())
 }
 
 func (x ImportDecl) String() string {
@@ -440,6 +464,9 @@ func (x TypeDecl) String() string {
 	if x.IsAlias {
 		return fmt.Sprintf("type %s = %s", x.Name, x.Type.String())
 	}
+	if len(x.TypeParams) > 0 {
+		return fmt.Sprintf("type %s[%s] %s", x.Name, x.TypeParams.String(), x.Type.String())
+	}
 	return fmt.Sprintf("type %s %s", x.Name, x.Type.String())
 }
 
@@ -38,8 +38,8 @@ SwitchStmt -> +block
     OpSwitchClauseCase
   OpTypeSwitch
 
-SelectStmt ->
-  OpSelectCase +block
+SelectStmt -> +frame
+  OpBody (default case) +block
 
 */
 
@@ -431,6 +431,14 @@ EXEC_SWITCH:
 	if debug {
 		debug.Printf("EXEC: %v\n", s)
 	}
+	if m.Coverage != nil {
+		if src := m.LastBlock().Source; src != nil {
+			m.Coverage.hit(src.GetLocation(), s.GetLine())
+		}
+	}
+	if m.Debugger != nil {
+		m.Debugger.onStmt(m, s)
+	}
 	switch cs := s.(type) {
 	case *AssignStmt:
 		switch cs.Op {
@@ -628,7 +636,7 @@ EXEC_SWITCH:
 			for {
 				fr := m.LastFrame()
 				switch fr.Source.(type) {
-				case *ForStmt, *RangeStmt, *SwitchStmt:
+				case *ForStmt, *RangeStmt, *SwitchStmt, *SelectStmt:
 					if cs.Label != "" && cs.Label != fr.Label {
 						m.PopFrame()
 					} else {
@@ -779,6 +787,32 @@ EXEC_SWITCH:
 		}
 		m.PushOp(OpBody)
 		m.PushStmt(b.GetBodyStmt())
+	case *SelectStmt:
+		// Without goroutines no communication can ever
+		// proceed, so only the default case may be selected.
+		// NOTE: the preprocessor rejects communication cases.
+		var dc *SelectCaseStmt
+		for i := range cs.Cases {
+			if cs.Cases[i].Comm == nil {
+				dc = &cs.Cases[i]
+				break
+			}
+		}
+		if dc == nil {
+			panic("all goroutines are asleep - deadlock!")
+		}
+		m.PushFrameBasic(cs)
+		m.PushOp(OpPopFrameAndReset)
+		b := m.Alloc.NewBlock(dc, m.LastBlock())
+		m.PushBlock(b)
+		m.PushOp(OpPopBlock)
+		b.bodyStmt = bodyStmt{
+			Body:          dc.Body,
+			BodyLen:       len(dc.Body),
+			NextBodyIndex: -2,
+		}
+		m.PushOp(OpBody)
+		m.PushStmt(b.GetBodyStmt())
 	default:
 		panic(fmt.Sprintf("unexpected statement %#v", s))
 	}
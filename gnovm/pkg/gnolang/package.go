@@ -53,6 +53,7 @@ var Package = amino.RegisterPackage(amino.NewPackage(
 	BinaryExpr{},
 	CallExpr{},
 	IndexExpr{},
+	IndexListExpr{},
 	SelectorExpr{},
 	SliceExpr{},
 	StarExpr{},
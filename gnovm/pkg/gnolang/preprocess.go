@@ -14,6 +14,11 @@ import (
 // Anything predefined or preprocessed here get skipped during the Preprocess
 // phase.
 func PredefineFileSet(store Store, pn *PackageNode, fset *FileSet) {
+	// Set aside generic declarations, which are only instantiated
+	// (and preprocessed) upon use.
+	registerGenerics(pn, fset.Files...)
+	defer deferGenerics(pn)()
+
 	for _, fn := range fset.Files {
 		decls, err := sortValueDeps(fn.Decls)
 		if err != nil {
@@ -213,6 +218,13 @@ func Preprocess(store Store, ctx BlockNode, n Node) Node {
 				// but for testing convenience we allow
 				// importing directly onto the package.
 				// Uverse requires this.
+				// NOTE generic package level declarations are
+				// set aside before preprocessing.
+				if td, ok := n.(*TypeDecl); ok && len(td.TypeParams) > 0 {
+					panic(fmt.Sprintf(
+						"generic type %s must be declared at package level",
+						td.Name))
+				}
 				if n.GetAttribute(ATTR_PREDEFINED) == true {
 					// skip declarations already predefined
 					// (e.g. through recursion for a dependent)
@@ -246,6 +258,57 @@ func Preprocess(store Store, ctx BlockNode, n Node) Node {
 						r.Name = Name(rn)
 					}
 				}
+
+			// TRANS_ENTER -----------------------
+			case *IndexExpr:
+				// instantiation of a generic function or type.
+				if g := lookupGeneric(store, last, n.X); g != nil {
+					return instantiateExpr(store, last, n, g, Exprs{n.Index}), TRANS_SKIP
+				}
+
+			// TRANS_ENTER -----------------------
+			case *IndexListExpr:
+				g := lookupGeneric(store, last, n.X)
+				if g == nil {
+					panic(fmt.Sprintf(
+						"%s is not a generic function or type", n.X))
+				}
+				return instantiateExpr(store, last, n, g, n.Indices), TRANS_SKIP
+
+			// TRANS_ENTER -----------------------
+			case *CallExpr:
+				// infer the type arguments of generic function calls.
+				if g, idxs := genericCallOf(store, last, n); g != nil {
+					n.Func = inferGenericCall(store, last, n, g, idxs)
+				}
+
+			// TRANS_ENTER -----------------------
+			case *NameExpr:
+				if ftype != TRANS_COMPOSITE_KEY && lookupGeneric(store, last, n) != nil {
+					panic(fmt.Sprintf(
+						"cannot use generic %s without instantiation", n.Name))
+				}
+
+			// TRANS_ENTER -----------------------
+			case *SelectorExpr:
+				if lookupGeneric(store, last, n) != nil {
+					panic(fmt.Sprintf(
+						"cannot use generic %s without instantiation", n))
+				}
+
+			// TRANS_ENTER -----------------------
+			case *GoStmt:
+				panic("goroutines are not supported")
+
+			// TRANS_ENTER -----------------------
+			case *SelectStmt:
+				// without goroutines, only the default case
+				// can ever be selected.
+				for _, cs := range n.Cases {
+					if cs.Comm != nil {
+						panic("channel operations are not supported in select")
+					}
+				}
 			}
 
 			// TRANS_ENTER -----------------------
@@ -499,6 +562,7 @@ func Preprocess(store Store, ctx BlockNode, n Node) Node {
 			case *FileNode:
 				// only for imports.
 				pushInitBlock(n, &last, &stack)
+				registerGenerics(lastpn, n)
 				{
 					// This logic supports out-of-order
 					// declarations.  (this must happen
@@ -1940,6 +2004,13 @@ func Preprocess(store Store, ctx BlockNode, n Node) Node {
 					exists := false
 					if dt := store.GetTypeSafe(tid); dt != nil {
 						dst = dt.(*DeclaredType)
+						// the type may be declared again, e.g. by an
+						// upgraded package, but not changed.
+						if bt := baseOf(tmp); bt.TypeID() != dst.Base.TypeID() {
+							panic(fmt.Sprintf(
+								"cannot change type %s from %s to %s",
+								tid, dst.Base.String(), bt.String()))
+						}
 						last.GetValueRef(store, n.Name).SetType(dst)
 						exists = true
 					}
@@ -1958,6 +2029,9 @@ func Preprocess(store Store, ctx BlockNode, n Node) Node {
 					panic(fmt.Sprintf("unexpected type declaration type %v",
 						reflect.TypeOf(dst)))
 				}
+				// The declared type may have been replaced by
+				// the one in the store.
+				dst = last.GetValueRef(store, n.Name).GetType()
 				// We need to replace all references of the new
 				// Type with old Type, including in attributes.
 				n.Type.SetAttribute(ATTR_TYPE_VALUE, dst)
@@ -2729,6 +2803,10 @@ func findUndefined2(store Store, last BlockNode, x Expr, t Type) (un Name) {
 			// will be predefined later.
 			return
 		}
+		if isGenericName(last, cx.Name) {
+			// instantiated upon use.
+			return
+		}
 		return cx.Name
 	case *BasicLitExpr:
 		return
@@ -2902,6 +2980,17 @@ func findUndefined2(store Store, last BlockNode, x Expr, t Type) (un Name) {
 		if un != "" {
 			return
 		}
+	case *IndexListExpr:
+		un = findUndefined(store, last, cx.X)
+		if un != "" {
+			return
+		}
+		for i := range cx.Indices {
+			un = findUndefined(store, last, cx.Indices[i])
+			if un != "" {
+				return
+			}
+		}
 	case *constTypeExpr:
 		return
 	case *ConstExpr:
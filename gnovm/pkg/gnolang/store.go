@@ -11,8 +11,9 @@ import (
 	"github.com/gnolang/gno/tm2/pkg/store"
 )
 
-// return nil if package doesn't exist.
-type PackageGetter func(pkgPath string) (*PackageNode, *PackageValue)
+// return nil if package doesn't exist. store is the store getting the package,
+// which may be a fork or a transaction store.
+type PackageGetter func(pkgPath string, store Store) (*PackageNode, *PackageValue)
 
 // inject natives into a new or loaded package (value and node)
 type PackageInjector func(store Store, pn *PackageNode)
@@ -48,6 +49,11 @@ type Store interface {
 	// version 1.
 	AddMemPackage(memPkg *std.MemPackage)
 	GetMemPackage(path string) *std.MemPackage
+	// The names of the package block of an upgraded package keep the
+	// order of the initial version, which cannot be derived from its
+	// latest files upon restart.
+	GetPackageNames(path string) []Name
+	SetPackageNames(path string, names []Name)
 	GetMemFile(path string, name string) *std.MemFile
 	IterMemPackage() <-chan *std.MemPackage
 	ClearObjectCache()                                    // for each delivertx.
@@ -61,6 +67,15 @@ type Store interface {
 	LogSwitchRealm(rlmpath string) // to mark change of realm boundaries
 	ClearCache()
 	Print()
+	// for the messages of a tx.
+	BeginTransaction(baseStore, iavlStore store.Store) TransactionStore
+}
+
+// TransactionStore is the store of a transaction, see BeginTransaction.
+type TransactionStore interface {
+	Store
+	Write()
+	Discard()
 }
 
 // Used to keep track of in-mem objects during tx.
@@ -68,8 +83,8 @@ type defaultStore struct {
 	alloc            *Allocator    // for accounting for cached items
 	pkgGetter        PackageGetter // non-realm packages
 	cacheObjects     map[ObjectID]Object
-	cacheTypes       map[TypeID]Type
-	cacheNodes       map[Location]BlockNode
+	cacheTypes       *cacheMap[TypeID, Type]
+	cacheNodes       *cacheMap[Location, BlockNode]
 	cacheNativeTypes map[reflect.Type]Type // go spec: reflect.Type are comparable
 	baseStore        store.Store           // for objects, types, nodes
 	iavlStore        store.Store           // for escaped object hashes
@@ -80,6 +95,7 @@ type defaultStore struct {
 	// transient
 	opslog  []StoreOp           // for debugging and testing.
 	current map[string]struct{} // for detecting import cycles.
+	undos   []func()            // see onDiscard.
 }
 
 func NewStore(alloc *Allocator, baseStore, iavlStore store.Store) *defaultStore {
@@ -87,8 +103,8 @@ func NewStore(alloc *Allocator, baseStore, iavlStore store.Store) *defaultStore
 		alloc:            alloc,
 		pkgGetter:        nil,
 		cacheObjects:     make(map[ObjectID]Object),
-		cacheTypes:       make(map[TypeID]Type),
-		cacheNodes:       make(map[Location]BlockNode),
+		cacheTypes:       newCacheMap[TypeID, Type](),
+		cacheNodes:       newCacheMap[Location, BlockNode](),
 		cacheNativeTypes: make(map[reflect.Type]Type),
 		baseStore:        baseStore,
 		iavlStore:        iavlStore,
@@ -160,7 +176,7 @@ func (ds *defaultStore) GetPackage(pkgPath string, isImport bool) *PackageValue
 	}
 	// otherwise, fetch from pkgGetter.
 	if ds.pkgGetter != nil {
-		if pn, pv := ds.pkgGetter(pkgPath); pv != nil {
+		if pn, pv := ds.pkgGetter(pkgPath, ds); pv != nil {
 			// e.g. tests/imports_tests loads example/gno.land/r/... realms.
 			// if pv.IsRealm() {
 			// 	panic("realm packages cannot be gotten from pkgGetter")
@@ -379,7 +395,7 @@ func (ds *defaultStore) GetType(tid TypeID) Type {
 
 func (ds *defaultStore) GetTypeSafe(tid TypeID) Type {
 	// check cache.
-	if tt, exists := ds.cacheTypes[tid]; exists {
+	if tt, exists := ds.cacheTypes.Get(tid); exists {
 		return tt
 	}
 	// check backend.
@@ -396,7 +412,7 @@ func (ds *defaultStore) GetTypeSafe(tid TypeID) Type {
 				}
 			}
 			// set in cache.
-			ds.cacheTypes[tid] = tt
+			ds.cacheTypes.Set(tid, tt)
 			// after setting in cache, fill tt.
 			fillType(ds, tt)
 			return tt
@@ -407,7 +423,7 @@ func (ds *defaultStore) GetTypeSafe(tid TypeID) Type {
 
 func (ds *defaultStore) SetCacheType(tt Type) {
 	tid := tt.TypeID()
-	if tt2, exists := ds.cacheTypes[tid]; exists {
+	if tt2, exists := ds.cacheTypes.Get(tid); exists {
 		if tt != tt2 {
 			// NOTE: not sure why this would happen.
 			panic("should not happen")
@@ -415,14 +431,14 @@ func (ds *defaultStore) SetCacheType(tt Type) {
 			// already set.
 		}
 	} else {
-		ds.cacheTypes[tid] = tt
+		ds.cacheTypes.Set(tid, tt)
 	}
 }
 
 func (ds *defaultStore) SetType(tt Type) {
 	tid := tt.TypeID()
 	// return if tid already known.
-	if tt2, exists := ds.cacheTypes[tid]; exists {
+	if tt2, exists := ds.cacheTypes.Get(tid); exists {
 		if tt != tt2 {
 			// this can happen for a variety of reasons.
 			// TODO classify them and optimize.
@@ -437,7 +453,7 @@ func (ds *defaultStore) SetType(tt Type) {
 		ds.baseStore.Set([]byte(key), bz)
 	}
 	// save type to cache.
-	ds.cacheTypes[tid] = tt
+	ds.cacheTypes.Set(tid, tt)
 }
 
 func (ds *defaultStore) GetBlockNode(loc Location) BlockNode {
@@ -450,7 +466,7 @@ func (ds *defaultStore) GetBlockNode(loc Location) BlockNode {
 
 func (ds *defaultStore) GetBlockNodeSafe(loc Location) BlockNode {
 	// check cache.
-	if bn, exists := ds.cacheNodes[loc]; exists {
+	if bn, exists := ds.cacheNodes.Get(loc); exists {
 		return bn
 	}
 	// check backend.
@@ -466,7 +482,7 @@ func (ds *defaultStore) GetBlockNodeSafe(loc Location) BlockNode {
 						loc, bn.GetLocation()))
 				}
 			}
-			ds.cacheNodes[loc] = bn
+			ds.cacheNodes.Set(loc, bn)
 			return bn
 		}
 	}
@@ -485,7 +501,7 @@ func (ds *defaultStore) SetBlockNode(bn BlockNode) {
 		// ds.backend.Set([]byte(key), bz)
 	}
 	// save node to cache.
-	ds.cacheNodes[loc] = bn
+	ds.cacheNodes.Set(loc, bn)
 	// XXX duplicate?
 	// XXX
 }
@@ -522,13 +538,17 @@ func (ds *defaultStore) incGetPackageIndexCounter() uint64 {
 	}
 }
 
+// AddMemPackage saves memPkg. If a package already exists at the same path,
+// e.g. upon upgrade, it is replaced but keeps its index.
 func (ds *defaultStore) AddMemPackage(memPkg *std.MemPackage) {
 	memPkg.Validate() // NOTE: duplicate validation.
-	ctr := ds.incGetPackageIndexCounter()
-	idxkey := []byte(backendPackageIndexKey(ctr))
 	bz := amino.MustMarshal(memPkg)
-	ds.baseStore.Set(idxkey, []byte(memPkg.Path))
 	pathkey := []byte(backendPackagePathKey(memPkg.Path))
+	if !ds.iavlStore.Has(pathkey) {
+		ctr := ds.incGetPackageIndexCounter()
+		idxkey := []byte(backendPackageIndexKey(ctr))
+		ds.baseStore.Set(idxkey, []byte(memPkg.Path))
+	}
 	ds.iavlStore.Set(pathkey, bz)
 }
 
@@ -544,6 +564,23 @@ func (ds *defaultStore) GetMemPackage(path string) *std.MemPackage {
 	return memPkg
 }
 
+// GetPackageNames returns the names of the package block of the package at
+// path, or nil if the package was never upgraded.
+func (ds *defaultStore) GetPackageNames(path string) []Name {
+	bz := ds.baseStore.Get([]byte(backendPackageNamesKey(path)))
+	if bz == nil {
+		return nil
+	}
+	var names []Name
+	amino.MustUnmarshal(bz, &names)
+	return names
+}
+
+func (ds *defaultStore) SetPackageNames(path string, names []Name) {
+	bz := amino.MustMarshal(names)
+	ds.baseStore.Set([]byte(backendPackageNamesKey(path)), bz)
+}
+
 func (ds *defaultStore) GetMemFile(path string, name string) *std.MemFile {
 	memPkg := ds.GetMemPackage(path)
 	memFile := memPkg.GetFile(name)
@@ -613,6 +650,43 @@ func (ds *defaultStore) Fork() Store {
 	return ds2
 }
 
+// Unstable.
+// This function is used to run the messages of a transaction, on top of the
+// main store for delivertx, or of a fork for checktx and simulate. The types
+// and nodes cached by the transaction are staged, until it is written.
+func (ds *defaultStore) BeginTransaction(baseStore, iavlStore store.Store) TransactionStore {
+	ds2 := ds.Fork().(*defaultStore)
+	ds2.cacheTypes = ds.cacheTypes.stage()
+	ds2.cacheNodes = ds.cacheNodes.stage()
+	ds2.SwapStores(baseStore, iavlStore)
+	return ds2
+}
+
+// Write commits the types and nodes cached by the transaction to the store
+// it began from.
+func (ds *defaultStore) Write() {
+	ds.cacheTypes.write()
+	ds.cacheNodes.write()
+	ds.undos = nil
+}
+
+// Discard reverts the changes made by the transaction to the values cached
+// by the store it began from, such as the methods of upgraded types.
+func (ds *defaultStore) Discard() {
+	for i := len(ds.undos) - 1; i >= 0; i-- {
+		ds.undos[i]()
+	}
+	ds.undos = nil
+}
+
+// onDiscard registers undo to be called if the transaction of ds is
+// discarded. Outside of a transaction, changes are final.
+func (ds *defaultStore) onDiscard(undo func()) {
+	if ds.cacheTypes.parent != nil {
+		ds.undos = append(ds.undos, undo)
+	}
+}
+
 // TODO: consider a better/faster/simpler way of achieving the overall same goal?
 func (ds *defaultStore) SwapStores(baseStore, iavlStore store.Store) {
 	ds.baseStore = baseStore
@@ -638,6 +712,53 @@ func (ds *defaultStore) Flush() {
 	// XXX
 }
 
+// cacheMap is a cache of the types or nodes of a store. The cache of a
+// transaction stages its entries over the cache of its parent store, until
+// they are written to it.
+type cacheMap[K comparable, V any] struct {
+	parent *cacheMap[K, V] // nil if not staged.
+	m      map[K]V
+}
+
+func newCacheMap[K comparable, V any]() *cacheMap[K, V] {
+	return &cacheMap[K, V]{m: make(map[K]V)}
+}
+
+func (c *cacheMap[K, V]) Get(k K) (V, bool) {
+	if v, ok := c.m[k]; ok {
+		return v, true
+	}
+	if c.parent != nil {
+		return c.parent.Get(k)
+	}
+	var zero V
+	return zero, false
+}
+
+func (c *cacheMap[K, V]) Set(k K, v V) {
+	c.m[k] = v
+}
+
+func (c *cacheMap[K, V]) stage() *cacheMap[K, V] {
+	return &cacheMap[K, V]{parent: c, m: make(map[K]V)}
+}
+
+func (c *cacheMap[K, V]) write() {
+	for k, v := range c.m {
+		c.parent.m[k] = v
+	}
+	c.m = make(map[K]V)
+}
+
+func (c *cacheMap[K, V]) iterate(fn func(K, V)) {
+	if c.parent != nil {
+		c.parent.iterate(fn)
+	}
+	for k, v := range c.m {
+		fn(k, v)
+	}
+}
+
 // ----------------------------------------
 // StoreOp
 
@@ -708,8 +829,8 @@ func (ds *defaultStore) LogSwitchRealm(rlmpath string) {
 
 func (ds *defaultStore) ClearCache() {
 	ds.cacheObjects = make(map[ObjectID]Object)
-	ds.cacheTypes = make(map[TypeID]Type)
-	ds.cacheNodes = make(map[Location]BlockNode)
+	ds.cacheTypes = newCacheMap[TypeID, Type]()
+	ds.cacheNodes = newCacheMap[Location, BlockNode]()
 	ds.cacheNativeTypes = make(map[reflect.Type]Type)
 	// restore builtin types to cache.
 	InitStoreCaches(ds)
@@ -725,14 +846,14 @@ func (ds *defaultStore) Print() {
 	store.Print(ds.iavlStore)
 	fmt.Println("//----------------------------------------")
 	fmt.Println("defaultStore:cacheTypes...")
-	for tid, typ := range ds.cacheTypes {
+	ds.cacheTypes.iterate(func(tid TypeID, typ Type) {
 		fmt.Printf("- %v: %v\n", tid, typ)
-	}
+	})
 	fmt.Println("//----------------------------------------")
 	fmt.Println("defaultStore:cacheNodes...")
-	for loc, bn := range ds.cacheNodes {
+	ds.cacheNodes.iterate(func(loc Location, bn BlockNode) {
 		fmt.Printf("- %v: %v\n", loc, bn)
-	}
+	})
 }
 
 // ----------------------------------------
@@ -767,6 +888,10 @@ func backendPackagePathKey(path string) string {
 	return fmt.Sprintf("pkg:" + path)
 }
 
+func backendPackageNamesKey(path string) string {
+	return "pkgnames:" + path
+}
+
 // ----------------------------------------
 // builtin types and packages
 
@@ -1377,8 +1377,9 @@ type DeclaredType struct {
 	Base    Type         // not a DeclaredType
 	Methods []TypedValue // {T:*FuncType,V:*FuncValue}...
 
-	typeid TypeID
-	sealed bool // for ensuring correctness with recursive types.
+	typeid    TypeID
+	sealed    bool // for ensuring correctness with recursive types.
+	upgrading bool // if true, methods may be redefined.
 }
 
 // returns an unsealed *DeclaredType.
@@ -1473,6 +1474,16 @@ func (dt *DeclaredType) TryDefineMethod(fv *FuncValue) bool {
 		// In the future we may allow this, just like we
 		// allow package-level function overrides.
 
+		// Special case: allow redefining a method of an
+		// upgraded package, as long as its type is the same.
+		if dt.upgrading && fv.Type.TypeID() == ofv.Type.TypeID() {
+			dt.Methods[i] = TypedValue{
+				T: fv.Type,
+				V: fv,
+			}
+			return true
+		}
+
 		// Special case: if the type and location are the same,
 		// ignore and do not redefine.
 		// This is due to PreprocessAllFilesAndSaveBlocknodes,
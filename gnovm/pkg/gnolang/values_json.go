@@ -0,0 +1,243 @@
+package gnolang
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// JSONTypedValue is the machine-readable representation of a TypedValue,
+// for clients of the VM that need to consume results without parsing
+// the output of TypedValue.String().
+//
+// V is the value tree of the typed value:
+//   - bool, string and float values are JSON booleans, strings and numbers
+//   - integers up to 32 bits are JSON numbers; int, int64, uint, uint64,
+//     bigint and bigdec are decimal strings, so that no precision is lost
+//   - []byte and [N]byte are base64 encoded strings
+//   - arrays and slices are JSON arrays
+//   - structs are JSON objects keyed by field name
+//   - maps are JSON arrays of {"K": key, "V": value} entries, in map order
+//   - pointers are the value they point to; nil values are null
+//   - std.Coin and std.Coins are their string representation ("100ugnot")
+//   - values of interface types are nested JSONTypedValues
+//   - funcs, types and packages are their string representation
+//
+// Cyclic values are null at the point where the cycle is detected.
+type JSONTypedValue struct {
+	T string      `json:"T"` // type name
+	V interface{} `json:"V"` // value tree
+}
+
+// JSONTypedValues converts results to their machine-readable representation.
+// The store is used to load persisted objects referenced by the results.
+func JSONTypedValues(store Store, tvs []TypedValue) []JSONTypedValue {
+	jtvs := make([]JSONTypedValue, len(tvs))
+	for i := range tvs {
+		jtvs[i] = tvs[i].JSON(store)
+	}
+	return jtvs
+}
+
+// MarshalJSONTypedValues returns the JSON encoding of JSONTypedValues().
+func MarshalJSONTypedValues(store Store, tvs []TypedValue) ([]byte, error) {
+	return json.Marshal(JSONTypedValues(store, tvs))
+}
+
+// JSON returns the machine-readable representation of tv.
+// The store is used to load persisted objects referenced by tv.
+func (tv TypedValue) JSON(store Store) JSONTypedValue {
+	if tv.IsUndefined() {
+		return JSONTypedValue{T: undefinedStr}
+	}
+	return JSONTypedValue{
+		T: tv.T.String(),
+		V: jsonValue(store, &tv, newSeenValues()),
+	}
+}
+
+// jsonTypedValue returns the value tree of tv, whose static type is st.
+// Values of interface types are wrapped in a JSONTypedValue, so that
+// their dynamic type is not lost.
+func jsonTypedValue(store Store, tv *TypedValue, st Type, seen *seenValues) interface{} {
+	if st != nil && st.Kind() == InterfaceKind {
+		if tv.IsUndefined() {
+			return nil
+		}
+		return JSONTypedValue{
+			T: tv.T.String(),
+			V: jsonValue(store, tv, seen),
+		}
+	}
+	return jsonValue(store, tv, seen)
+}
+
+func jsonValue(store Store, tv *TypedValue, seen *seenValues) interface{} {
+	if tv.IsUndefined() {
+		return nil
+	}
+	tv = fillValueTV(store, tv)
+
+	// Recognized standard types.
+	if dt, ok := tv.T.(*DeclaredType); ok && dt.PkgPath == "std" && tv.V != nil {
+		switch dt.Name {
+		case "Coin":
+			return jsonCoinString(store, tv)
+		case "Coins":
+			sv := tv.V.(*SliceValue)
+			ss := make([]string, sv.Length)
+			for i := range ss {
+				etv := sv.GetPointerAtIndexInt2(store, i, dt.Base.Elem()).Deref()
+				ss[i] = jsonCoinString(store, &etv)
+			}
+			return strings.Join(ss, ",")
+		}
+	}
+
+	switch bt := baseOf(tv.T).(type) {
+	case PrimitiveType:
+		switch bt {
+		case UntypedBoolType, BoolType:
+			return tv.GetBool()
+		case UntypedStringType, StringType:
+			return tv.GetString()
+		case IntType:
+			return strconv.FormatInt(int64(tv.GetInt()), 10)
+		case Int8Type:
+			return tv.GetInt8()
+		case Int16Type:
+			return tv.GetInt16()
+		case UntypedRuneType, Int32Type:
+			return tv.GetInt32()
+		case Int64Type:
+			return strconv.FormatInt(tv.GetInt64(), 10)
+		case UintType:
+			return strconv.FormatUint(uint64(tv.GetUint()), 10)
+		case Uint8Type:
+			return tv.GetUint8()
+		case DataByteType:
+			return tv.GetDataByte()
+		case Uint16Type:
+			return tv.GetUint16()
+		case Uint32Type:
+			return tv.GetUint32()
+		case Uint64Type:
+			return strconv.FormatUint(tv.GetUint64(), 10)
+		case Float32Type:
+			return jsonFloat(float64(tv.GetFloat32()))
+		case Float64Type:
+			return jsonFloat(tv.GetFloat64())
+		case UntypedBigintType, BigintType:
+			return tv.V.(BigintValue).V.String()
+		case UntypedBigdecType, BigdecType:
+			return tv.V.(BigdecValue).V.String()
+		default:
+			panic("should not happen")
+		}
+	case *PointerType:
+		if tv.V == nil {
+			return nil
+		}
+		pv := tv.V.(PointerValue)
+		if pv.TV == nil || seen.Contains(pv) {
+			return nil
+		}
+		seen.Put(pv)
+		defer seen.Pop()
+		etv := pv.Deref()
+		return jsonTypedValue(store, &etv, bt.Elt, seen)
+	case *ArrayType:
+		av := tv.V.(*ArrayValue)
+		if av.Data != nil {
+			return base64.StdEncoding.EncodeToString(av.Data)
+		}
+		if seen.Contains(av) {
+			return nil
+		}
+		seen.Put(av)
+		defer seen.Pop()
+		list := make([]interface{}, len(av.List))
+		for i := range av.List {
+			list[i] = jsonTypedValue(store, &av.List[i], bt.Elt, seen)
+		}
+		return list
+	case *SliceType:
+		if tv.V == nil {
+			return nil
+		}
+		sv := tv.V.(*SliceValue)
+		if seen.Contains(sv) {
+			return nil
+		}
+		seen.Put(sv)
+		defer seen.Pop()
+		if av := sv.GetBase(store); av.Data != nil {
+			return base64.StdEncoding.EncodeToString(av.Data[sv.Offset : sv.Offset+sv.Length])
+		}
+		list := make([]interface{}, sv.Length)
+		for i := range list {
+			etv := sv.GetPointerAtIndexInt2(store, i, bt.Elt).Deref()
+			list[i] = jsonTypedValue(store, &etv, bt.Elt, seen)
+		}
+		return list
+	case *StructType:
+		sv := tv.V.(*StructValue)
+		if seen.Contains(sv) {
+			return nil
+		}
+		seen.Put(sv)
+		defer seen.Pop()
+		obj := make(map[string]interface{}, len(bt.Fields))
+		for i, ft := range bt.Fields {
+			ftv := sv.GetPointerToInt(store, i).Deref()
+			obj[string(ft.Name)] = jsonTypedValue(store, &ftv, ft.Type, seen)
+		}
+		return obj
+	case *MapType:
+		if tv.V == nil {
+			return nil
+		}
+		mv := tv.V.(*MapValue)
+		if seen.Contains(mv) {
+			return nil
+		}
+		seen.Put(mv)
+		defer seen.Pop()
+		entries := make([]map[string]interface{}, 0, mv.GetLength())
+		for cur := mv.List.Head; cur != nil; cur = cur.Next {
+			entries = append(entries, map[string]interface{}{
+				"K": jsonTypedValue(store, &cur.Key, bt.Key, seen),
+				"V": jsonTypedValue(store, &cur.Value, bt.Value, seen),
+			})
+		}
+		return entries
+	case *InterfaceType:
+		return nil
+	default:
+		// *FuncType, *TypeType, *PackageType, *NativeType, ...
+		if tv.V == nil {
+			return nil
+		}
+		return tv.V.String()
+	}
+}
+
+// jsonCoinString returns the string representation of a std.Coin.
+func jsonCoinString(store Store, tv *TypedValue) string {
+	sv := tv.V.(*StructValue)
+	denom := sv.GetPointerToInt(store, 0).Deref()
+	amount := sv.GetPointerToInt(store, 1).Deref()
+	return strconv.FormatInt(amount.GetInt64(), 10) + denom.GetString()
+}
+
+// jsonFloat returns f, or its string representation if
+// it is not a valid JSON number (NaN, +Inf, -Inf).
+func jsonFloat(f float64) interface{} {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return fmt.Sprintf("%v", f)
+	}
+	return f
+}
@@ -0,0 +1,45 @@
+package gnolang
+
+import (
+	"testing"
+
+	"github.com/jaekwon/testify/assert"
+)
+
+func TestTypedValueJSON(t *testing.T) {
+	t.Parallel()
+
+	m := NewMachine("test", nil)
+	c := `package test
+
+type Item struct {
+	Name  string
+	Count int
+	Tags  []string
+	Any   interface{}
+	Next  *Item
+}
+
+func values() (bool, int8, int64, float64, []byte, [2]uint32, map[string]int, *Item, error) {
+	item := &Item{Name: "foo", Count: 2, Tags: []string{"a", "b"}, Any: uint16(7)}
+	item.Next = item
+	return true, -1, 1 << 62, 1.5, []byte("hi"), [2]uint32{1, 2}, map[string]int{"b": 2, "a": 1}, item, nil
+}`
+	n := MustParseFile("main.go", c)
+	m.RunFiles(n)
+	res := m.Eval(Call("values"))
+
+	bz, err := MarshalJSONTypedValues(nil, res)
+	assert.NoError(t, err)
+	assert.Equal(t, `[`+
+		`{"T":"bool","V":true},`+
+		`{"T":"int8","V":-1},`+
+		`{"T":"int64","V":"4611686018427387904"},`+
+		`{"T":"float64","V":1.5},`+
+		`{"T":"[]uint8","V":"aGk="},`+
+		`{"T":"[2]uint32","V":[1,2]},`+
+		`{"T":"map[string]int","V":[{"K":"b","V":"2"},{"K":"a","V":"1"}]},`+
+		`{"T":"*test.Item","V":{"Any":{"T":"uint16","V":7},"Count":"2","Name":"foo","Next":null,"Tags":["a","b"]}},`+
+		`{"T":"undefined","V":null}`+
+		`]`, string(bz))
+}
@@ -51,36 +51,37 @@ func _() {
 	_ = x[LEQ-40]
 	_ = x[GEQ-41]
 	_ = x[DEFINE-42]
-	_ = x[BREAK-43]
-	_ = x[CASE-44]
-	_ = x[CHAN-45]
-	_ = x[CONST-46]
-	_ = x[CONTINUE-47]
-	_ = x[DEFAULT-48]
-	_ = x[DEFER-49]
-	_ = x[ELSE-50]
-	_ = x[FALLTHROUGH-51]
-	_ = x[FOR-52]
-	_ = x[FUNC-53]
-	_ = x[GO-54]
-	_ = x[GOTO-55]
-	_ = x[IF-56]
-	_ = x[IMPORT-57]
-	_ = x[INTERFACE-58]
-	_ = x[MAP-59]
-	_ = x[PACKAGE-60]
-	_ = x[RANGE-61]
-	_ = x[RETURN-62]
-	_ = x[SELECT-63]
-	_ = x[STRUCT-64]
-	_ = x[SWITCH-65]
-	_ = x[TYPE-66]
-	_ = x[VAR-67]
+	_ = x[TILDE-43]
+	_ = x[BREAK-44]
+	_ = x[CASE-45]
+	_ = x[CHAN-46]
+	_ = x[CONST-47]
+	_ = x[CONTINUE-48]
+	_ = x[DEFAULT-49]
+	_ = x[DEFER-50]
+	_ = x[ELSE-51]
+	_ = x[FALLTHROUGH-52]
+	_ = x[FOR-53]
+	_ = x[FUNC-54]
+	_ = x[GO-55]
+	_ = x[GOTO-56]
+	_ = x[IF-57]
+	_ = x[IMPORT-58]
+	_ = x[INTERFACE-59]
+	_ = x[MAP-60]
+	_ = x[PACKAGE-61]
+	_ = x[RANGE-62]
+	_ = x[RETURN-63]
+	_ = x[SELECT-64]
+	_ = x[STRUCT-65]
+	_ = x[SWITCH-66]
+	_ = x[TYPE-67]
+	_ = x[VAR-68]
 }
 
-const _Word_name = "ILLEGALNAMEINTFLOATIMAGCHARSTRINGADDSUBMULQUOREMBANDBORXORSHLSHRBAND_NOTADD_ASSIGNSUB_ASSIGNMUL_ASSIGNQUO_ASSIGNREM_ASSIGNBAND_ASSIGNBOR_ASSIGNXOR_ASSIGNSHL_ASSIGNSHR_ASSIGNBAND_NOT_ASSIGNLANDLORARROWINCDECEQLLSSGTRASSIGNNOTNEQLEQGEQDEFINEBREAKCASECHANCONSTCONTINUEDEFAULTDEFERELSEFALLTHROUGHFORFUNCGOGOTOIFIMPORTINTERFACEMAPPACKAGERANGERETURNSELECTSTRUCTSWITCHTYPEVAR"
+const _Word_name = "ILLEGALNAMEINTFLOATIMAGCHARSTRINGADDSUBMULQUOREMBANDBORXORSHLSHRBAND_NOTADD_ASSIGNSUB_ASSIGNMUL_ASSIGNQUO_ASSIGNREM_ASSIGNBAND_ASSIGNBOR_ASSIGNXOR_ASSIGNSHL_ASSIGNSHR_ASSIGNBAND_NOT_ASSIGNLANDLORARROWINCDECEQLLSSGTRASSIGNNOTNEQLEQGEQDEFINETILDEBREAKCASECHANCONSTCONTINUEDEFAULTDEFERELSEFALLTHROUGHFORFUNCGOGOTOIFIMPORTINTERFACEMAPPACKAGERANGERETURNSELECTSTRUCTSWITCHTYPEVAR"
 
-var _Word_index = [...]uint16{0, 7, 11, 14, 19, 23, 27, 33, 36, 39, 42, 45, 48, 52, 55, 58, 61, 64, 72, 82, 92, 102, 112, 122, 133, 143, 153, 163, 173, 188, 192, 195, 200, 203, 206, 209, 212, 215, 221, 224, 227, 230, 233, 239, 244, 248, 252, 257, 265, 272, 277, 281, 292, 295, 299, 301, 305, 307, 313, 322, 325, 332, 337, 343, 349, 355, 361, 365, 368}
+var _Word_index = [...]uint16{0, 7, 11, 14, 19, 23, 27, 33, 36, 39, 42, 45, 48, 52, 55, 58, 61, 64, 72, 82, 92, 102, 112, 122, 133, 143, 153, 163, 173, 188, 192, 195, 200, 203, 206, 209, 212, 215, 221, 224, 227, 230, 233, 239, 244, 249, 253, 257, 262, 270, 277, 282, 286, 297, 300, 304, 306, 310, 312, 318, 327, 330, 337, 342, 348, 354, 360, 366, 370, 373}
 
 func (i Word) String() string {
 	if i < 0 || i >= Word(len(_Word_index)-1) {
@@ -17,4 +17,5 @@ type ExecContext struct {
 	OrigSend      std.Coins
 	OrigSendSpent *std.Coins // mutable
 	Banker        BankerInterface
+	EventLogger   *sdk.EventLogger // for std.Emit; nil discards events
 }
@@ -0,0 +1,58 @@
+package std
+
+import (
+	gno "github.com/gnolang/gno/gnovm/pkg/gnolang"
+	abci "github.com/gnolang/gno/tm2/pkg/bft/abci/types"
+)
+
+// GnoEvent is an event emitted by a realm through std.Emit.
+type GnoEvent struct {
+	Type       string              `json:"type"`
+	PkgPath    string              `json:"pkg_path"`
+	Attributes []GnoEventAttribute `json:"attrs"`
+}
+
+func (GnoEvent) AssertABCIEvent() {}
+
+var _ abci.AttributedEvent = GnoEvent{}
+
+func (e GnoEvent) EventType() string { return e.Type }
+
+// EventAttributes returns the event attributes, along with the
+// path of the realm which emitted it as "pkg_path".
+func (e GnoEvent) EventAttributes() []abci.EventAttribute {
+	attrs := make([]abci.EventAttribute, 0, len(e.Attributes)+1)
+	attrs = append(attrs, abci.EventAttribute{Key: "pkg_path", Value: e.PkgPath})
+	for _, attr := range e.Attributes {
+		attrs = append(attrs, abci.EventAttribute{Key: attr.Key, Value: attr.Value})
+	}
+	return attrs
+}
+
+type GnoEventAttribute struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+func X_emit(m *gno.Machine, typ string, attrs []string) {
+	if len(attrs)%2 != 0 {
+		m.Panic(typedString("odd number of attributes; expected key-value pairs"))
+		return
+	}
+	ctx := m.Context.(ExecContext)
+	if ctx.EventLogger == nil {
+		// events are discarded outside of a transaction (e.g. in tests).
+		return
+	}
+	evt := GnoEvent{
+		Type:    typ,
+		PkgPath: CurrentRealmPath(m),
+	}
+	for i := 0; i < len(attrs); i += 2 {
+		evt.Attributes = append(evt.Attributes, GnoEventAttribute{
+			Key:   attrs[i],
+			Value: attrs[i+1],
+		})
+	}
+	ctx.EventLogger.EmitEvent(evt)
+}
@@ -119,6 +119,14 @@ func X_decodeBech32(addr string) (prefix string, bytes [20]byte, ok bool) {
 	return prefix, [20]byte(bz), true
 }
 
+func X_pubKeyAddress(pubKey string) (addr string, ok bool) {
+	pk, err := crypto.PubKeyFromBech32(pubKey)
+	if err != nil {
+		return "", false
+	}
+	return pk.Address().String(), true
+}
+
 func typedString(s string) gno.TypedValue {
 	tv := gno.TypedValue{T: gno.StringType}
 	tv.SetString(gno.StringValue(s))
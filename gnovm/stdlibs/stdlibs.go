@@ -7,7 +7,11 @@ import (
 	libsstd "github.com/gnolang/gno/gnovm/stdlibs/std"
 )
 
-type ExecContext = libsstd.ExecContext
+type (
+	ExecContext       = libsstd.ExecContext
+	GnoEvent          = libsstd.GnoEvent
+	GnoEventAttribute = libsstd.GnoEventAttribute
+)
 
 func NativeStore(pkgPath string, name gno.Name) func(*gno.Machine) {
 	for _, nf := range nativeFuncs {
@@ -65,6 +65,8 @@ type runFileTestOptions struct {
 	nativeLibs bool
 	logger     loggerFunc
 	syncWanted bool
+	coverage   *gno.Coverage
+	debugger   *gno.Debugger
 }
 
 // RunFileTestOptions specify changing options in [RunFileTest], deviating
@@ -89,6 +91,16 @@ func WithSyncWanted(v bool) RunFileTestOption {
 	return func(r *runFileTestOptions) { r.syncWanted = v }
 }
 
+// WithCoverage records the statements executed by the test in coverage.
+func WithCoverage(coverage *gno.Coverage) RunFileTestOption {
+	return func(r *runFileTestOptions) { r.coverage = coverage }
+}
+
+// WithDebugger runs the test under the interactive debugger.
+func WithDebugger(debugger *gno.Debugger) RunFileTestOption {
+	return func(r *runFileTestOptions) { r.debugger = debugger }
+}
+
 // RunFileTest executes the filetest at the given path, using rootDir as
 // the directory where to find the "stdlibs" directory.
 func RunFileTest(rootDir string, path string, opts ...RunFileTestOption) error {
@@ -112,6 +124,8 @@ func RunFileTest(rootDir string, path string, opts ...RunFileTestOption) error {
 	store := TestStore(rootDir, "./files", stdin, stdout, stderr, mode)
 	store.SetLogStoreOps(true)
 	m := testMachineCustom(store, pkgPath, stdout, maxAlloc, send)
+	m.Coverage = f.coverage
+	m.Debugger = f.debugger
 
 	// TODO support stdlib groups, but make testing safe;
 	// e.g. not be able to make network connections.
@@ -123,11 +137,23 @@ func RunFileTest(rootDir string, path string, opts ...RunFileTestOption) error {
 	if err != nil {
 		return err
 	}
+	if f.debugger != nil {
+		// realm filetests are run as the file main.gno.
+		name := path
+		if gno.IsRealmPath(pkgPath) {
+			name = "main.gno"
+		}
+		f.debugger.AddSource(pkgPath, name, path, string(bz))
+	}
 	{ // Validate result, errors, etc.
 		var pnc interface{}
 		func() {
 			defer func() {
 				if r := recover(); r != nil {
+					if f.debugger != nil && f.debugger.Exited() {
+						pnc = gno.ErrDebuggerExit
+						return
+					}
 					// print output.
 					fmt.Println("OUTPUT:\n", stdout.String())
 					// print stack if unexpected error.
@@ -232,6 +258,10 @@ func RunFileTest(rootDir string, path string, opts ...RunFileTestOption) error {
 				}
 			}
 		}()
+		if pnc == gno.ErrDebuggerExit {
+			// the results are not checked, nor synced.
+			return gno.ErrDebuggerExit
+		}
 
 		for _, directive := range directives {
 			switch directive {
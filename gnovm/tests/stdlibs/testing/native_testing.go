@@ -1,7 +1,23 @@
 package testing
 
-import "time"
+import (
+	"time"
+
+	gno "github.com/gnolang/gno/gnovm/pkg/gnolang"
+)
 
 func X_unixNano() int64 {
 	return time.Now().UnixNano()
 }
+
+func X_machineCycles(m *gno.Machine) int64 {
+	return m.Cycles
+}
+
+func X_machineAllocs(m *gno.Machine) (bytes, allocs int64) {
+	if m.Alloc == nil {
+		return 0, 0
+	}
+	_, bytes = m.Alloc.Status()
+	return bytes, m.Alloc.NumAllocs()
+}
@@ -165,6 +165,9 @@ type ResponseCheckTx struct {
 	ResponseBase
 	GasWanted int64 // nondeterministic
 	GasUsed   int64
+	Priority  int64          // higher is reaped first by a priority mempool
+	Sender    crypto.Address // txs of a sender are reaped in order by a priority mempool
+	Pending   bool           // the tx may become valid after other txs of the sender
 }
 
 type ResponseDeliverTx struct {
@@ -196,6 +199,14 @@ type Event interface {
 	AssertABCIEvent()
 }
 
+// AttributedEvent is an Event which exposes its type and key-value
+// attributes, allowing it to be indexed and searched by the node.
+type AttributedEvent interface {
+	Event
+	EventType() string
+	EventAttributes() []EventAttribute
+}
+
 type Header interface {
 	GetChainID() string
 	GetHeight() int64
@@ -225,6 +236,12 @@ func (err EventString) Event() string {
 	return string(err)
 }
 
+// EventAttribute is a key-value pair describing an AttributedEvent.
+type EventAttribute struct {
+	Key   string
+	Value string
+}
+
 // ----------------------------------------
 // Misc
 
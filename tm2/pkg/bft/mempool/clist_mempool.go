@@ -16,6 +16,7 @@ import (
 	cfg "github.com/gnolang/gno/tm2/pkg/bft/mempool/config"
 	"github.com/gnolang/gno/tm2/pkg/bft/types"
 	"github.com/gnolang/gno/tm2/pkg/clist"
+	"github.com/gnolang/gno/tm2/pkg/crypto"
 	"github.com/gnolang/gno/tm2/pkg/errors"
 	"github.com/gnolang/gno/tm2/pkg/log"
 	osm "github.com/gnolang/gno/tm2/pkg/os"
@@ -60,6 +61,12 @@ type CListMempool struct {
 	// This reduces the pressure on the proxyApp.
 	cache txCache
 
+	// Txs signed ahead of the sequence of their sender, held until the
+	// txs before them are received.
+	// queued: sender -> queued txs, in the order they were received
+	queued      map[string][]*queuedTx
+	queuedCount int
+
 	// A log of mempool txs
 	wal *auto.AutoFile
 
@@ -91,6 +98,7 @@ func NewCListMempool(
 		rechecking:    0,
 		recheckCursor: nil,
 		recheckEnd:    nil,
+		queued:        make(map[string][]*queuedTx),
 		logger:        log.NewNoopLogger(),
 	}
 	if config.CacheSize > 0 {
@@ -185,6 +193,9 @@ func (mem *CListMempool) Flush() {
 
 	mem.txsMap = sync.Map{}
 	_ = atomic.SwapInt64(&mem.txsBytes, 0)
+
+	mem.queued = make(map[string][]*queuedTx)
+	mem.queuedCount = 0
 }
 
 // TxsFront returns the first transaction in the ordered list for peer
@@ -222,8 +233,17 @@ func (mem *CListMempool) CheckTxWithInfo(tx types.Tx, cb func(abci.Response), tx
 		txSize   = len(tx)
 	)
 
-	// Check max pending txs bytes
-	if memSize >= mem.config.Size ||
+	// Check max pending txs bytes.
+	// A priority mempool may instead make room for the tx once it is checked,
+	// by evicting txs with a lower priority.
+	if mem.isPriority() {
+		if int64(txSize) > mem.config.MaxPendingTxsBytes {
+			return MempoolIsFullError{
+				memSize, mem.config.Size,
+				txsBytes, mem.config.MaxPendingTxsBytes,
+			}
+		}
+	} else if memSize >= mem.config.Size ||
 		int64(txSize)+txsBytes > mem.config.MaxPendingTxsBytes {
 		return MempoolIsFullError{
 			memSize, mem.config.Size,
@@ -317,10 +337,13 @@ func (mem *CListMempool) reqResCb(tx []byte, peerID uint16, externalCb func(abci
 			panic("recheck cursor is not nil in reqResCb")
 		}
 
-		mem.resCbFirstTime(tx, peerID, res)
+		res = mem.resCbFirstTime(tx, peerID, res)
 
 		// Passed in by the caller of CheckTx, eg. the RPC.
 		// The external callback cannot modify the result.
+		// NOTE: the result holds an error if the tx was valid but could not
+		// be added to a full priority mempool, and holds none if the tx was
+		// queued until the previous txs of its sender are received.
 		if externalCb != nil {
 			externalCb(res)
 		}
@@ -353,15 +376,28 @@ func (mem *CListMempool) removeTx(tx types.Tx, elem *clist.CElement, removeFromC
 //
 // The case where the app checks the tx for the second and subsequent times is
 // handled by the resCbRecheck callback.
-func (mem *CListMempool) resCbFirstTime(tx []byte, peerID uint16, res abci.Response) {
+//
+// It returns the response, with an error if the tx could not be added to a
+// full priority mempool.
+func (mem *CListMempool) resCbFirstTime(tx []byte, peerID uint16, res abci.Response) abci.Response {
 	switch res := res.(type) {
 	case abci.ResponseCheckTx:
 		if res.Error == nil {
 			memTx := &mempoolTx{
 				height:    mem.height,
 				gasWanted: res.GasWanted,
+				priority:  res.Priority,
+				sender:    res.Sender,
 				tx:        tx,
 			}
+			if mem.isPriority() {
+				if err := mem.makeRoomFor(memTx); err != nil {
+					mem.logger.Info("Rejected transaction, mempool is full", "tx", txID(tx), "err", err)
+					mem.cache.Remove(tx)
+					res.Error = abci.ABCIErrorOrStringError(err)
+					return res
+				}
+			}
 			memTx.senders.Store(peerID, true)
 			mem.addTx(memTx)
 			mem.logger.Info("Added good transaction",
@@ -371,14 +407,26 @@ func (mem *CListMempool) resCbFirstTime(tx []byte, peerID uint16, res abci.Respo
 				"total", mem.Size(),
 			)
 			mem.notifyTxsAvailable()
+
+			// The tx may fill the sequence gap of queued txs.
+			if !memTx.sender.IsZero() {
+				mem.promoteQueued(memTx.sender)
+			}
+		} else if res.Pending && mem.queueTx(res.Sender, &queuedTx{height: mem.height, peerID: peerID, tx: tx}) {
+			// keep the tx in the cache while it is queued
+			mem.logger.Info("Queued transaction signed ahead of its sequence", "tx", txID(tx), "sender", res.Sender)
+			res.Error = nil
+			res.Log = "queued until the previous transactions of the sender are received"
 		} else {
 			// ignore bad transaction
 			mem.logger.Info("Rejected bad transaction", "tx", txID(tx), "res", res, "err", res.Error)
 			// remove from cache (it might be good later)
 			mem.cache.Remove(tx)
 		}
+		return res
 	default:
 		// ignore other messages
+		return res
 	}
 }
 
@@ -462,8 +510,7 @@ func (mem *CListMempool) ReapMaxBytesMaxGas(maxDataBytes, maxGas int64) types.Tx
 	// size per tx, and set the initial capacity based off of that.
 	// txs := make([]types.Tx, 0, min(mem.txs.Len(), max/mem.avgTxSize))
 	txs := make([]types.Tx, 0, mem.txs.Len())
-	for e := mem.txs.Front(); e != nil; e = e.Next() {
-		memTx := e.Value.(*mempoolTx)
+	for _, memTx := range mem.reapOrder() {
 		// Check total size requirement
 		if maxDataBytes > -1 && totalBytes+int64(len(memTx.tx)) > maxDataBytes {
 			return txs
@@ -497,8 +544,10 @@ func (mem *CListMempool) ReapMaxTxs(max int) types.Txs {
 	}
 
 	txs := make([]types.Tx, 0, min(mem.txs.Len(), max))
-	for e := mem.txs.Front(); e != nil && len(txs) <= max; e = e.Next() {
-		memTx := e.Value.(*mempoolTx)
+	for _, memTx := range mem.reapOrder() {
+		if len(txs) > max {
+			break
+		}
 		txs = append(txs, memTx.tx)
 	}
 	return txs
@@ -560,6 +609,13 @@ func (mem *CListMempool) Update(
 		}
 	}
 
+	// Queued txs may have become valid with the committed txs.
+	// NOTE: if txs are being rechecked asynchronously, they are only promoted
+	// with the next tx of their sender or the next block.
+	if mem.queuedCount > 0 && mem.recheckCursor == nil {
+		mem.promoteAllQueued()
+	}
+
 	return nil
 }
 
@@ -602,9 +658,11 @@ func (mem *CListMempool) recheckTxs() {
 
 // mempoolTx is a transaction that successfully ran
 type mempoolTx struct {
-	height    int64    // height that this tx had been validated in
-	gasWanted int64    // amount of gas this tx states it will require
-	tx        types.Tx //
+	height    int64          // height that this tx had been validated in
+	gasWanted int64          // amount of gas this tx states it will require
+	priority  int64          // priority of this tx in a priority mempool
+	sender    crypto.Address // account that sent this tx, if known
+	tx        types.Tx       //
 
 	// ids of peers who've sent us this tx (as a map for quick lookups).
 	// senders: PeerID -> bool
@@ -14,8 +14,17 @@ type MempoolConfig struct {
 	Size               int    `toml:"size" comment:"Maximum number of transactions in the mempool"`
 	MaxPendingTxsBytes int64  `toml:"max_pending_txs_bytes" comment:"Limit the total size of all txs in the mempool.\n This only accounts for raw transactions (e.g. given 1MB transactions and\n max_txs_bytes=5MB, mempool will only accept 5 transactions)."`
 	CacheSize          int    `toml:"cache_size" comment:"Size of the cache (used to filter transactions we saw earlier) in transactions"`
+	SequenceQueueSize  int    `toml:"sequence_queue_size" comment:"Maximum number of transactions signed ahead of their account sequence that\n are held per account, until the missing transactions are received.\n 0 rejects them instead"`
+	SequenceQueueTTL   int64  `toml:"sequence_queue_ttl" comment:"Number of blocks a transaction signed ahead of its account sequence is held for"`
+	Type               string `toml:"type" comment:"Ordering of the transactions in the mempool, one of:\n \"fifo\": transactions are reaped in the order they were received\n \"priority\": transactions are reaped by decreasing gas price, keeping the\n order of the transactions of each sender, and the lowest priced ones are\n evicted when the mempool is full"`
 }
 
+// Mempool types.
+const (
+	TypeFIFO     = "fifo"
+	TypePriority = "priority"
+)
+
 // DefaultMempoolConfig returns a default configuration for the Tendermint mempool
 func DefaultMempoolConfig() *MempoolConfig {
 	return &MempoolConfig{
@@ -27,6 +36,9 @@ func DefaultMempoolConfig() *MempoolConfig {
 		Size:               5000,
 		MaxPendingTxsBytes: 1024 * 1024 * 1024, // 1GB
 		CacheSize:          10000,
+		SequenceQueueSize:  0,
+		SequenceQueueTTL:   10,
+		Type:               TypeFIFO,
 	}
 }
 
@@ -59,5 +71,16 @@ func (cfg *MempoolConfig) ValidateBasic() error {
 	if cfg.CacheSize < 0 {
 		return errors.New("cache_size can't be negative")
 	}
+	if cfg.SequenceQueueSize < 0 {
+		return errors.New("sequence_queue_size can't be negative")
+	}
+	if cfg.SequenceQueueTTL < 0 {
+		return errors.New("sequence_queue_ttl can't be negative")
+	}
+	switch cfg.Type {
+	case "", TypeFIFO, TypePriority:
+	default:
+		return errors.New("unknown type %q", cfg.Type)
+	}
 	return nil
 }
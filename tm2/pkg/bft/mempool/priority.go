@@ -0,0 +1,184 @@
+package mempool
+
+import (
+	"container/heap"
+
+	cfg "github.com/gnolang/gno/tm2/pkg/bft/mempool/config"
+	"github.com/gnolang/gno/tm2/pkg/clist"
+)
+
+// isPriority returns true if the mempool orders txs by priority.
+func (mem *CListMempool) isPriority() bool {
+	return mem.config.Type == cfg.TypePriority
+}
+
+// reapOrder returns the txs of the mempool in the order they are to be reaped.
+//
+// A FIFO mempool reaps txs in the order they were added. A priority mempool
+// reaps txs by decreasing priority, except that the txs of a sender are
+// reaped in the order they were added, so that their sequences stay ordered.
+// Txs with the same priority are reaped in the order they were added.
+func (mem *CListMempool) reapOrder() []*mempoolTx {
+	if !mem.isPriority() {
+		memTxs := make([]*mempoolTx, 0, mem.txs.Len())
+		for e := mem.txs.Front(); e != nil; e = e.Next() {
+			memTxs = append(memTxs, e.Value.(*mempoolTx))
+		}
+		return memTxs
+	}
+
+	queues := mem.senderQueues()
+	h := make(queueHeap, 0, len(queues))
+	for _, q := range queues {
+		h = append(h, q)
+	}
+	heap.Init(&h)
+
+	memTxs := make([]*mempoolTx, 0, mem.txs.Len())
+	for h.Len() > 0 {
+		q := h[0]
+		memTxs = append(memTxs, q.elems[0].Value.(*mempoolTx))
+		q.elems, q.indexes = q.elems[1:], q.indexes[1:]
+		if len(q.elems) == 0 {
+			heap.Pop(&h)
+		} else {
+			heap.Fix(&h, 0)
+		}
+	}
+	return memTxs
+}
+
+// makeRoomFor evicts txs from a full priority mempool so that memTx fits.
+//
+// Only txs with a lower priority than memTx are evicted, lowest first, and
+// always the last tx of their sender, so that no sequence gap is left behind.
+// The txs of the sender of memTx are never evicted. If not enough txs can be
+// evicted, nothing is and a MempoolIsFullError is returned.
+func (mem *CListMempool) makeRoomFor(memTx *mempoolTx) error {
+	var (
+		memSize  = mem.Size()
+		txsBytes = mem.TxsBytes()
+		txSize   = int64(len(memTx.tx))
+	)
+	if memSize < mem.config.Size && txSize+txsBytes <= mem.config.MaxPendingTxsBytes {
+		return nil
+	}
+
+	var (
+		queues  = mem.senderQueues()
+		evicted []*clist.CElement
+		size    = memSize
+		bytes   = txsBytes
+	)
+	if !memTx.sender.IsZero() {
+		delete(queues, string(memTx.sender[:]))
+	}
+	for size >= mem.config.Size || txSize+bytes > mem.config.MaxPendingTxsBytes {
+		// Find the last tx of a sender with the lowest priority,
+		// the most recently added one first.
+		var lowest *senderQueue
+		for _, q := range queues {
+			if lowest == nil || q.lastPriority() < lowest.lastPriority() ||
+				q.lastPriority() == lowest.lastPriority() && q.lastIndex() > lowest.lastIndex() {
+				lowest = q
+			}
+		}
+		if lowest == nil || lowest.lastPriority() >= memTx.priority {
+			return MempoolIsFullError{
+				memSize, mem.config.Size,
+				txsBytes, mem.config.MaxPendingTxsBytes,
+			}
+		}
+
+		last := len(lowest.elems) - 1
+		elem := lowest.elems[last]
+		evicted = append(evicted, elem)
+		size--
+		bytes -= int64(len(elem.Value.(*mempoolTx).tx))
+
+		lowest.elems, lowest.indexes = lowest.elems[:last], lowest.indexes[:last]
+		if len(lowest.elems) == 0 {
+			delete(queues, lowest.key)
+		}
+	}
+
+	for _, elem := range evicted {
+		evictedTx := elem.Value.(*mempoolTx)
+		mem.logger.Info("Evicted transaction",
+			"tx", txID(evictedTx.tx),
+			"priority", evictedTx.priority,
+			"for", txID(memTx.tx),
+		)
+		// NOTE: we remove tx from the cache because it might be resubmitted
+		// with a higher fee.
+		mem.removeTx(evictedTx.tx, elem, true)
+	}
+	return nil
+}
+
+// senderQueues groups the txs of the mempool by sender, in the order they
+// were added. Txs without a sender each get a queue of their own.
+func (mem *CListMempool) senderQueues() map[string]*senderQueue {
+	queues := make(map[string]*senderQueue)
+	var index int
+	for e := mem.txs.Front(); e != nil; e = e.Next() {
+		memTx := e.Value.(*mempoolTx)
+		key := string(memTx.sender[:])
+		if memTx.sender.IsZero() {
+			txk := txKey(memTx.tx)
+			key = string(txk[:])
+		}
+		q, ok := queues[key]
+		if !ok {
+			q = &senderQueue{key: key}
+			queues[key] = q
+		}
+		q.elems = append(q.elems, e)
+		q.indexes = append(q.indexes, index)
+		index++
+	}
+	return queues
+}
+
+// senderQueue holds the txs of a sender, in the order they were added,
+// along with their position in the mempool.
+type senderQueue struct {
+	key     string
+	elems   []*clist.CElement
+	indexes []int
+}
+
+func (q *senderQueue) lastPriority() int64 {
+	return q.elems[len(q.elems)-1].Value.(*mempoolTx).priority
+}
+
+func (q *senderQueue) lastIndex() int {
+	return q.indexes[len(q.indexes)-1]
+}
+
+// queueHeap is a max-heap of sender queues, by priority of their first tx.
+type queueHeap []*senderQueue
+
+var _ heap.Interface = (*queueHeap)(nil)
+
+func (h queueHeap) Len() int { return len(h) }
+
+func (h queueHeap) Less(i, j int) bool {
+	pi := h[i].elems[0].Value.(*mempoolTx).priority
+	pj := h[j].elems[0].Value.(*mempoolTx).priority
+	if pi != pj {
+		return pi > pj
+	}
+	return h[i].indexes[0] < h[j].indexes[0]
+}
+
+func (h queueHeap) Swap(i, j int) { h[i], h[j] = h[j], h[i] }
+
+func (h *queueHeap) Push(x any) { *h = append(*h, x.(*senderQueue)) }
+
+func (h *queueHeap) Pop() any {
+	old := *h
+	q := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return q
+}
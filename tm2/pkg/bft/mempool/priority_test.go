@@ -0,0 +1,131 @@
+package mempool
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	abci "github.com/gnolang/gno/tm2/pkg/bft/abci/types"
+	cfg "github.com/gnolang/gno/tm2/pkg/bft/mempool/config"
+	"github.com/gnolang/gno/tm2/pkg/bft/proxy"
+	"github.com/gnolang/gno/tm2/pkg/bft/types"
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+)
+
+// priorityApp accepts txs of the form {sender, priority, nonce}.
+// A zero sender means the tx has no known sender.
+type priorityApp struct {
+	abci.BaseApplication
+}
+
+func (priorityApp) CheckTx(req abci.RequestCheckTx) (res abci.ResponseCheckTx) {
+	res.Priority = int64(req.Tx[1])
+	if req.Tx[0] != 0 {
+		res.Sender = crypto.Address{req.Tx[0]}
+	}
+	return
+}
+
+func newPriorityMempool(t *testing.T, size int) *CListMempool {
+	t.Helper()
+
+	config := cfg.TestMempoolConfig()
+	config.Type = cfg.TypePriority
+	config.Size = size
+	mempool, cleanup := newMempoolWithAppAndConfig(proxy.NewLocalClientCreator(priorityApp{}), config)
+	t.Cleanup(cleanup)
+	return mempool
+}
+
+func checkTxResponse(t *testing.T, mempool Mempool, tx types.Tx) abci.ResponseCheckTx {
+	t.Helper()
+
+	var res abci.ResponseCheckTx
+	require.NoError(t, mempool.CheckTx(tx, func(r abci.Response) {
+		res = r.(abci.ResponseCheckTx)
+	}))
+	return res
+}
+
+func TestPriorityMempoolReap(t *testing.T) {
+	t.Parallel()
+
+	mempool := newPriorityMempool(t, 100)
+	for _, tx := range []types.Tx{
+		{1, 1, 0},
+		{2, 5, 0},
+		{1, 9, 1}, // reaped after {1, 1, 0}
+		{0, 3, 0},
+		{3, 5, 0}, // reaped after {2, 5, 0}, which came first
+		{0, 7, 0},
+	} {
+		res := checkTxResponse(t, mempool, tx)
+		require.Nil(t, res.Error)
+	}
+
+	expected := types.Txs{
+		{0, 7, 0},
+		{2, 5, 0},
+		{3, 5, 0},
+		{0, 3, 0},
+		{1, 1, 0},
+		{1, 9, 1},
+	}
+	assert.Equal(t, expected, mempool.ReapMaxTxs(-1))
+	assert.Equal(t, expected[:3], mempool.ReapMaxBytesMaxGas(9, -1))
+}
+
+func TestPriorityMempoolEviction(t *testing.T) {
+	t.Parallel()
+
+	mempool := newPriorityMempool(t, 3)
+	for _, tx := range []types.Tx{
+		{1, 2, 0},
+		{1, 8, 1},
+		{2, 5, 0},
+	} {
+		res := checkTxResponse(t, mempool, tx)
+		require.Nil(t, res.Error)
+	}
+
+	// The lowest priority tx of sender 1 is not its last one: the tx of
+	// sender 2 is evicted.
+	res := checkTxResponse(t, mempool, types.Tx{3, 6, 0})
+	require.Nil(t, res.Error)
+	assert.Equal(t, types.Txs{{3, 6, 0}, {1, 2, 0}, {1, 8, 1}}, mempool.ReapMaxTxs(-1))
+
+	// An evicted tx is no longer cached, but is rejected as long as its
+	// priority is too low.
+	res = checkTxResponse(t, mempool, types.Tx{2, 5, 0})
+	require.NotNil(t, res.Error)
+	assert.Contains(t, res.Error.Error(), "mempool is full")
+
+	// The txs of the sender of the tx are not evicted.
+	res = checkTxResponse(t, mempool, types.Tx{3, 7, 1})
+	require.NotNil(t, res.Error)
+	assert.Equal(t, 3, mempool.Size())
+
+	res = checkTxResponse(t, mempool, types.Tx{4, 7, 0})
+	require.Nil(t, res.Error)
+	assert.Equal(t, types.Txs{{4, 7, 0}, {1, 2, 0}, {1, 8, 1}}, mempool.ReapMaxTxs(-1))
+}
+
+func TestPriorityMempoolMaxPendingTxsBytes(t *testing.T) {
+	t.Parallel()
+
+	mempool := newPriorityMempool(t, 100)
+	mempool.config.MaxPendingTxsBytes = 6
+
+	// A tx larger than the mempool is rejected right away.
+	err := mempool.CheckTx(types.Tx{1, 9, 0, 0, 0, 0, 0}, nil)
+	assert.IsType(t, MempoolIsFullError{}, err)
+
+	require.Nil(t, checkTxResponse(t, mempool, types.Tx{1, 1, 0}).Error)
+	require.Nil(t, checkTxResponse(t, mempool, types.Tx{2, 2, 0}).Error)
+
+	// Several txs are evicted to make room for a larger one.
+	require.Nil(t, checkTxResponse(t, mempool, types.Tx{3, 3, 0, 0}).Error)
+	assert.Equal(t, types.Txs{{3, 3, 0, 0}}, mempool.ReapMaxTxs(-1))
+	assert.EqualValues(t, 4, mempool.TxsBytes())
+}
@@ -0,0 +1,102 @@
+package mempool
+
+import (
+	abci "github.com/gnolang/gno/tm2/pkg/bft/abci/types"
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+)
+
+// queuedTx is a transaction that the app reported as pending, ie. signed
+// ahead of the sequence of its sender. It is held until the txs of the sender
+// that come before it are added to the mempool.
+type queuedTx struct {
+	height int64  // height that this tx was queued at
+	peerID uint16 // peer who sent us this tx
+	tx     []byte
+}
+
+// queueTx holds a pending tx of sender, unless the sequence queue is disabled
+// or full. It returns true if the tx was queued.
+func (mem *CListMempool) queueTx(sender crypto.Address, qtx *queuedTx) bool {
+	if mem.config.SequenceQueueSize == 0 || sender.IsZero() {
+		return false
+	}
+	key := string(sender[:])
+	if len(mem.queued[key]) >= mem.config.SequenceQueueSize ||
+		mem.queuedCount >= mem.config.Size {
+		return false
+	}
+	mem.queued[key] = append(mem.queued[key], qtx)
+	mem.queuedCount++
+	return true
+}
+
+// promoteQueued checks again the queued txs of sender, in the order they were
+// received. The ones that became valid are added to the mempool, which in
+// turn promotes the next ones.
+//
+// NOTE: this must not be called while rechecking txs, nor from the global
+// callback.
+func (mem *CListMempool) promoteQueued(sender crypto.Address) {
+	key := string(sender[:])
+	qtxs, ok := mem.queued[key]
+	if !ok {
+		return
+	}
+	delete(mem.queued, key)
+	mem.queuedCount -= len(qtxs)
+
+	for _, qtx := range qtxs {
+		if err := mem.proxyAppConn.Error(); err != nil {
+			mem.logger.Error("Dropped queued transaction", "tx", txID(qtx.tx), "err", err)
+			mem.cache.Remove(qtx.tx)
+			continue
+		}
+		reqRes := mem.proxyAppConn.CheckTxAsync(abci.RequestCheckTx{Tx: qtx.tx})
+		reqRes.SetCallback(mem.resCbQueued(qtx))
+	}
+}
+
+// promoteAllQueued drops the queued txs that expired, and checks again the
+// others.
+func (mem *CListMempool) promoteAllQueued() {
+	for key, qtxs := range mem.queued {
+		kept := qtxs[:0]
+		for _, qtx := range qtxs {
+			if mem.height-qtx.height > mem.config.SequenceQueueTTL {
+				mem.logger.Info("Dropped expired queued transaction", "tx", txID(qtx.tx), "height", qtx.height)
+				mem.cache.Remove(qtx.tx)
+				mem.queuedCount--
+				continue
+			}
+			kept = append(kept, qtx)
+		}
+		if len(kept) == 0 {
+			delete(mem.queued, key)
+		} else {
+			mem.queued[key] = kept
+		}
+	}
+
+	senders := make([]crypto.Address, 0, len(mem.queued))
+	for key := range mem.queued {
+		var sender crypto.Address
+		copy(sender[:], key)
+		senders = append(senders, sender)
+	}
+	for _, sender := range senders {
+		mem.promoteQueued(sender)
+	}
+}
+
+// resCbQueued returns the callback of a queued tx being checked again. A tx
+// that is still pending is queued again, keeping its height.
+func (mem *CListMempool) resCbQueued(qtx *queuedTx) func(res abci.Response) {
+	return func(res abci.Response) {
+		if res, ok := res.(abci.ResponseCheckTx); ok && res.Error != nil && res.Pending {
+			if mem.queueTx(res.Sender, qtx) {
+				return
+			}
+		}
+		mem.resCbFirstTime(qtx.tx, qtx.peerID, res)
+	}
+}
@@ -0,0 +1,119 @@
+package mempool
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	abci "github.com/gnolang/gno/tm2/pkg/bft/abci/types"
+	cfg "github.com/gnolang/gno/tm2/pkg/bft/mempool/config"
+	"github.com/gnolang/gno/tm2/pkg/bft/proxy"
+	"github.com/gnolang/gno/tm2/pkg/bft/types"
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+)
+
+// sequenceApp accepts txs of the form {sender, sequence}, in sequence order.
+type sequenceApp struct {
+	abci.BaseApplication
+
+	sequences map[byte]byte
+}
+
+func (app *sequenceApp) CheckTx(req abci.RequestCheckTx) (res abci.ResponseCheckTx) {
+	sender, seq := req.Tx[0], req.Tx[1]
+	res.Sender = crypto.Address{sender}
+	switch {
+	case seq < app.sequences[sender]:
+		res.Error = abci.StringError("sequence too low")
+	case seq > app.sequences[sender]:
+		res.Error = abci.StringError("sequence too high")
+		res.Pending = true
+	default:
+		app.sequences[sender]++
+	}
+	return
+}
+
+func newSequenceMempool(t *testing.T, queueSize int) *CListMempool {
+	t.Helper()
+
+	config := cfg.TestMempoolConfig()
+	config.SequenceQueueSize = queueSize
+	config.SequenceQueueTTL = 1
+	app := &sequenceApp{sequences: make(map[byte]byte)}
+	mempool, cleanup := newMempoolWithAppAndConfig(proxy.NewLocalClientCreator(app), config)
+	t.Cleanup(cleanup)
+	return mempool
+}
+
+func TestSequenceQueueDisabled(t *testing.T) {
+	t.Parallel()
+
+	mempool := newSequenceMempool(t, 0)
+
+	res := checkTxResponse(t, mempool, types.Tx{1, 1})
+	require.NotNil(t, res.Error)
+	assert.Equal(t, 0, mempool.Size())
+	assert.Equal(t, 0, mempool.queuedCount)
+}
+
+func TestSequenceQueuePromotion(t *testing.T) {
+	t.Parallel()
+
+	mempool := newSequenceMempool(t, 2)
+
+	// Txs ahead of the sequence of their sender are queued.
+	for _, tx := range []types.Tx{{1, 2}, {1, 1}} {
+		res := checkTxResponse(t, mempool, tx)
+		require.Nil(t, res.Error)
+		assert.True(t, res.Pending)
+	}
+	assert.Equal(t, 0, mempool.Size())
+	assert.Equal(t, 2, mempool.queuedCount)
+
+	// A queued tx is in the cache.
+	assert.Equal(t, ErrTxInCache, mempool.CheckTx(types.Tx{1, 1}, nil))
+
+	// The queue of a sender is bounded.
+	res := checkTxResponse(t, mempool, types.Tx{1, 3})
+	require.NotNil(t, res.Error)
+
+	// Txs of other senders are not affected.
+	res = checkTxResponse(t, mempool, types.Tx{2, 0})
+	require.Nil(t, res.Error)
+	assert.Equal(t, 2, mempool.queuedCount)
+
+	// Filling the gap promotes the queued txs, in sequence order.
+	res = checkTxResponse(t, mempool, types.Tx{1, 0})
+	require.Nil(t, res.Error)
+	assert.False(t, res.Pending)
+	assert.Equal(t, types.Txs{{2, 0}, {1, 0}, {1, 1}, {1, 2}}, mempool.ReapMaxTxs(-1))
+	assert.Equal(t, 0, mempool.queuedCount)
+}
+
+func TestSequenceQueueExpiry(t *testing.T) {
+	t.Parallel()
+
+	mempool := newSequenceMempool(t, 2)
+
+	res := checkTxResponse(t, mempool, types.Tx{1, 1})
+	require.Nil(t, res.Error)
+	assert.Equal(t, 1, mempool.queuedCount)
+
+	require.NoError(t, mempool.Update(1, types.Txs{}, abciResponses(0, nil), nil, 0))
+	assert.Equal(t, 1, mempool.queuedCount)
+
+	// The queued tx expires, and leaves the cache.
+	require.NoError(t, mempool.Update(2, types.Txs{}, abciResponses(0, nil), nil, 0))
+	assert.Equal(t, 0, mempool.queuedCount)
+
+	res = checkTxResponse(t, mempool, types.Tx{1, 0})
+	require.Nil(t, res.Error)
+	assert.Equal(t, types.Txs{{1, 0}}, mempool.ReapMaxTxs(-1))
+
+	res = checkTxResponse(t, mempool, types.Tx{1, 1})
+	require.Nil(t, res.Error)
+	assert.False(t, res.Pending)
+	assert.Equal(t, types.Txs{{1, 0}, {1, 1}}, mempool.ReapMaxTxs(-1))
+}
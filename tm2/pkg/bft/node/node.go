@@ -14,6 +14,7 @@ import (
 
 	"github.com/gnolang/gno/tm2/pkg/bft/appconn"
 	"github.com/gnolang/gno/tm2/pkg/bft/state/eventstore/file"
+	"github.com/gnolang/gno/tm2/pkg/bft/state/eventstore/kv"
 	"github.com/rs/cors"
 
 	"github.com/gnolang/gno/tm2/pkg/amino"
@@ -199,6 +200,7 @@ func createAndStartProxyAppConns(clientCreator proxy.ClientCreator, logger *slog
 func createAndStartEventStoreService(
 	cfg *cfg.Config,
 	evsw events.EventSwitch,
+	dbProvider DBProvider,
 	logger *slog.Logger,
 ) (*eventstore.Service, eventstore.TxEventStore, error) {
 	var (
@@ -214,6 +216,14 @@ func createAndStartEventStoreService(
 		if err != nil {
 			return nil, nil, fmt.Errorf("unable to create file tx event store, %w", err)
 		}
+	case kv.EventStoreType:
+		// Transaction events should be indexed in a database
+		txIndexDB, err := dbProvider(&DBContext{"tx_index", cfg})
+		if err != nil {
+			return nil, nil, fmt.Errorf("unable to create kv tx event store db, %w", err)
+		}
+
+		txEventStore = kv.NewTxEventStore(txIndexDB)
 	default:
 		// Transaction event storing should be omitted
 		txEventStore = null.NewNullEventStore()
@@ -455,7 +465,7 @@ func NewNode(config *cfg.Config,
 	})
 
 	// Transaction event storing
-	eventStoreService, txEventStore, err := createAndStartEventStoreService(config, evsw, logger)
+	eventStoreService, txEventStore, err := createAndStartEventStoreService(config, evsw, dbProvider, logger)
 	if err != nil {
 		return nil, err
 	}
@@ -689,6 +699,7 @@ func (n *Node) Ready() <-chan struct{} {
 func (n *Node) configureRPC() {
 	rpccore.SetStateDB(n.stateDB)
 	rpccore.SetBlockStore(n.blockStore)
+	rpccore.SetTxEventStore(n.txEventStore)
 	rpccore.SetConsensusState(n.consensusState)
 	rpccore.SetMempool(n.mempool)
 	rpccore.SetP2PPeers(n.sw)
@@ -726,8 +737,7 @@ func (n *Node) startRPC() ([]net.Listener, error) {
 		wmLogger := rpcLogger.With("protocol", "websocket")
 		wm := rpcserver.NewWebsocketManager(rpccore.Routes,
 			rpcserver.OnDisconnect(func(remoteAddr string) {
-				// any cleanup...
-				// (we used to unsubscribe from all event subscriptions)
+				rpccore.UnsubscribeClient(remoteAddr)
 			}),
 			rpcserver.ReadLimit(config.MaxBodyBytes),
 		)
@@ -1,12 +1,14 @@
 package client
 
 import (
+	"context"
 	"net/http"
 
 	ctypes "github.com/gnolang/gno/tm2/pkg/bft/rpc/core/types"
 	rpcclient "github.com/gnolang/gno/tm2/pkg/bft/rpc/lib/client"
 	"github.com/gnolang/gno/tm2/pkg/bft/types"
 	"github.com/gnolang/gno/tm2/pkg/errors"
+	"github.com/gnolang/gno/tm2/pkg/service"
 )
 
 /*
@@ -26,6 +28,7 @@ type HTTP struct {
 	rpc    *rpcclient.JSONRPCClient
 
 	*baseRPCClient
+	*WSEvents
 }
 
 // BatchHTTP provides the same interface as `HTTP`, but allows for batching of
@@ -90,10 +93,37 @@ func NewHTTPWithClient(remote, wsEndpoint string, client *http.Client) *HTTP {
 		rpc:           rc,
 		remote:        remote,
 		baseRPCClient: &baseRPCClient{caller: rc},
+		WSEvents:      NewWSEvents(remote, wsEndpoint),
 	}
 }
 
-var _ Client = (*HTTP)(nil)
+var (
+	_ Client       = (*HTTP)(nil)
+	_ EventsClient = (*HTTP)(nil)
+)
+
+// Subscribe subscribes to the node events matching the given query.
+// The WebSocket connection is opened on the first subscription,
+// and closed by Close. See WSEvents.Subscribe
+func (c *HTTP) Subscribe(ctx context.Context, query string) (<-chan ctypes.ResultEvent, error) {
+	if !c.WSEvents.IsRunning() {
+		if err := c.WSEvents.Start(); err != nil && err != service.ErrAlreadyStarted {
+			return nil, errors.Wrap(err, "unable to connect to websocket")
+		}
+	}
+
+	return c.WSEvents.Subscribe(ctx, query)
+}
+
+// Close closes the WebSocket connection, if any,
+// and all the subscription channels
+func (c *HTTP) Close() error {
+	if !c.WSEvents.IsRunning() {
+		return nil
+	}
+
+	return c.WSEvents.Stop()
+}
 
 // NewBatch creates a new batch client for this HTTP client.
 func (c *HTTP) NewBatch() *BatchHTTP {
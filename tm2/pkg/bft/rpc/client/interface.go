@@ -21,16 +21,15 @@ implementation.
 */
 
 import (
+	"context"
+
 	ctypes "github.com/gnolang/gno/tm2/pkg/bft/rpc/core/types"
 	"github.com/gnolang/gno/tm2/pkg/bft/types"
 )
 
 // Client wraps most important rpc calls a client would make.
 //
-// NOTE: Events cannot be subscribed to from the RPC APIs. For events
-// subscriptions and filters and queries, an external API must be used that
-// first synchronously consumes the events from the node's synchronous event
-// switch, or reads logged events from the filesystem.
+// NOTE: Events can only be subscribed to over WebSocket, see EventsClient.
 type Client interface {
 	// service.Service
 	ABCIClient
@@ -66,6 +65,8 @@ type SignClient interface {
 	BlockResults(height *int64) (*ctypes.ResultBlockResults, error)
 	Commit(height *int64) (*ctypes.ResultCommit, error)
 	Validators(height *int64) (*ctypes.ResultValidators, error)
+	Tx(hash []byte, prove bool) (*ctypes.ResultTx, error)
+	TxSearch(query string, prove bool, page, perPage int) (*ctypes.ResultTxSearch, error)
 }
 
 // HistoryClient provides access to data from genesis to now in large chunks.
@@ -94,3 +95,11 @@ type MempoolClient interface {
 	UnconfirmedTxs(limit int) (*ctypes.ResultUnconfirmedTxs, error)
 	NumUnconfirmedTxs() (*ctypes.ResultUnconfirmedTxs, error)
 }
+
+// EventsClient allows subscribing to node events over WebSocket.
+// See the rpc/core Subscribe route for the query syntax.
+type EventsClient interface {
+	Subscribe(ctx context.Context, query string) (<-chan ctypes.ResultEvent, error)
+	Unsubscribe(ctx context.Context, query string) error
+	UnsubscribeAll(ctx context.Context) error
+}
@@ -150,3 +150,11 @@ func (c Client) Commit(height *int64) (*ctypes.ResultCommit, error) {
 func (c Client) Validators(height *int64) (*ctypes.ResultValidators, error) {
 	return core.Validators(&rpctypes.Context{}, height)
 }
+
+func (c Client) Tx(hash []byte, prove bool) (*ctypes.ResultTx, error) {
+	return core.Tx(&rpctypes.Context{}, hash, prove)
+}
+
+func (c Client) TxSearch(query string, prove bool, page, perPage int) (*ctypes.ResultTxSearch, error) {
+	return core.TxSearch(&rpctypes.Context{}, query, prove, page, perPage)
+}
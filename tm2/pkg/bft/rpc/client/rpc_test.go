@@ -1,10 +1,13 @@
 package client_test
 
 import (
+	"context"
+	"fmt"
 	"net/http"
 	"strings"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -594,3 +597,57 @@ func TestConcurrentJSONRPCBatching(t *testing.T) {
 	}
 	wg.Wait()
 }
+
+func TestSubscribe(t *testing.T) {
+	c := getHTTPClient()
+	defer c.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// Subscribe to new block headers
+	headers, err := c.Subscribe(ctx, "tm.event = 'NewBlockHeader'")
+	require.NoError(t, err)
+
+	select {
+	case event := <-headers:
+		_, ok := event.Event.(types.EventNewBlockHeader)
+		assert.True(t, ok)
+	case <-ctx.Done():
+		t.Fatal("timed out waiting for a new block header")
+	}
+
+	// Subscribe to a specific tx, with a non-canonical query
+	_, _, tx := MakeTxKV()
+
+	txs, err := c.Subscribe(ctx, fmt.Sprintf(" tm.event='Tx'  AND tx.hash =  '%X'", types.Tx(tx).Hash()))
+	require.NoError(t, err)
+
+	_, err = c.BroadcastTxAsync(tx)
+	require.NoError(t, err)
+
+	select {
+	case event := <-txs:
+		txEvent, ok := event.Event.(types.EventTx)
+		require.True(t, ok)
+		assert.Equal(t, types.Tx(tx), txEvent.Result.Tx)
+	case <-ctx.Done():
+		t.Fatal("timed out waiting for the tx")
+	}
+
+	// Invalid and duplicate subscriptions
+	_, err = c.Subscribe(ctx, "tm.event")
+	assert.Error(t, err)
+
+	_, err = c.Subscribe(ctx, "tm.event='NewBlockHeader'")
+	assert.Error(t, err)
+
+	// Unsubscribe closes the channels
+	require.NoError(t, c.Unsubscribe(ctx, "tm.event= 'NewBlockHeader'"))
+	for range headers {
+	}
+
+	require.NoError(t, c.UnsubscribeAll(ctx))
+	for range txs {
+	}
+}
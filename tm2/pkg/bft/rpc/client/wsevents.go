@@ -0,0 +1,285 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/gnolang/gno/tm2/pkg/amino"
+	ctypes "github.com/gnolang/gno/tm2/pkg/bft/rpc/core/types"
+	rpcclient "github.com/gnolang/gno/tm2/pkg/bft/rpc/lib/client"
+	rpctypes "github.com/gnolang/gno/tm2/pkg/bft/rpc/lib/types"
+	"github.com/gnolang/gno/tm2/pkg/bft/state/eventstore/query"
+	"github.com/gnolang/gno/tm2/pkg/errors"
+	"github.com/gnolang/gno/tm2/pkg/service"
+)
+
+// eventsBufferSize is the capacity of each subscription channel.
+// Events are dropped if the subscriber doesn't keep up with them
+const eventsBufferSize = 100
+
+// eventIDSuffix is appended by the node to the subscribe request ID,
+// to form the ID of the pushed events
+const eventIDSuffix = "#event"
+
+var _ EventsClient = (*WSEvents)(nil)
+
+// WSEvents is an EventsClient implementation that subscribes to node events
+// over a WebSocket connection. Subscriptions are restored when the
+// connection is re-established.
+type WSEvents struct {
+	service.BaseService
+
+	ws *rpcclient.WSClient
+
+	mtx           sync.Mutex
+	subscriptions map[string]chan ctypes.ResultEvent   // query -> events
+	pending       map[string]chan rpctypes.RPCResponse // request ID -> response
+	requestNonce  uint64
+}
+
+// NewWSEvents creates a new WSEvents client, connecting to the
+// given remote endpoint in the form <protocol>://<host>:<port>,
+// and the websocket path (usually "/websocket").
+// The function panics if the provided remote is invalid
+func NewWSEvents(remote, endpoint string) *WSEvents {
+	w := &WSEvents{
+		subscriptions: make(map[string]chan ctypes.ResultEvent),
+		pending:       make(map[string]chan rpctypes.RPCResponse),
+	}
+
+	w.ws = rpcclient.NewWSClient(remote, endpoint, rpcclient.OnReconnect(w.redoSubscriptions))
+	w.BaseService = *service.NewBaseService(nil, "WSEvents", w)
+
+	return w
+}
+
+// OnStart implements service.Service by connecting to the node
+func (w *WSEvents) OnStart() error {
+	w.ws.SetLogger(w.Logger)
+
+	if err := w.ws.Start(); err != nil {
+		return err
+	}
+
+	go w.eventListener()
+
+	return nil
+}
+
+// OnStop implements service.Service by disconnecting from the node,
+// and closing all the subscription channels
+func (w *WSEvents) OnStop() {
+	if err := w.ws.Stop(); err != nil {
+		w.Logger.Error("unable to stop websocket client", "err", err)
+	}
+
+	w.mtx.Lock()
+	defer w.mtx.Unlock()
+
+	for q, ch := range w.subscriptions {
+		close(ch)
+		delete(w.subscriptions, q)
+	}
+}
+
+// Subscribe subscribes to the node events matching the given query,
+// and returns a channel on which they are delivered.
+// The channel is closed on Unsubscribe, UnsubscribeAll or Stop
+func (w *WSEvents) Subscribe(ctx context.Context, queryStr string) (<-chan ctypes.ResultEvent, error) {
+	// subscriptions are keyed by the canonical query,
+	// which is also the one the node sends back with the events
+	query, err := canonicalQuery(queryStr)
+	if err != nil {
+		return nil, errors.Wrap(err, "Subscribe")
+	}
+
+	ch := make(chan ctypes.ResultEvent, eventsBufferSize)
+
+	// register the channel before subscribing,
+	// so no event is missed
+	w.mtx.Lock()
+	if _, ok := w.subscriptions[query]; ok {
+		w.mtx.Unlock()
+
+		return nil, fmt.Errorf("already subscribed to %q", query)
+	}
+	w.subscriptions[query] = ch
+	w.mtx.Unlock()
+
+	if err := w.call(ctx, "subscribe", map[string]interface{}{"query": query}); err != nil {
+		w.mtx.Lock()
+		delete(w.subscriptions, query)
+		w.mtx.Unlock()
+
+		return nil, errors.Wrap(err, "Subscribe")
+	}
+
+	return ch, nil
+}
+
+// Unsubscribe cancels the subscription with the given query
+func (w *WSEvents) Unsubscribe(ctx context.Context, queryStr string) error {
+	query, err := canonicalQuery(queryStr)
+	if err != nil {
+		return errors.Wrap(err, "Unsubscribe")
+	}
+
+	if err := w.call(ctx, "unsubscribe", map[string]interface{}{"query": query}); err != nil {
+		return errors.Wrap(err, "Unsubscribe")
+	}
+
+	w.mtx.Lock()
+	defer w.mtx.Unlock()
+
+	if ch, ok := w.subscriptions[query]; ok {
+		close(ch)
+		delete(w.subscriptions, query)
+	}
+
+	return nil
+}
+
+// UnsubscribeAll cancels all the active subscriptions
+func (w *WSEvents) UnsubscribeAll(ctx context.Context) error {
+	if err := w.call(ctx, "unsubscribe_all", map[string]interface{}{}); err != nil {
+		return errors.Wrap(err, "UnsubscribeAll")
+	}
+
+	w.mtx.Lock()
+	defer w.mtx.Unlock()
+
+	for q, ch := range w.subscriptions {
+		close(ch)
+		delete(w.subscriptions, q)
+	}
+
+	return nil
+}
+
+// canonicalQuery returns the canonical form of the given query string
+func canonicalQuery(queryStr string) (string, error) {
+	q, err := query.New(queryStr)
+	if err != nil {
+		return "", err
+	}
+
+	return q.String(), nil
+}
+
+// call sends the given request to the node, and waits for its response
+func (w *WSEvents) call(ctx context.Context, method string, params map[string]interface{}) error {
+	respCh := make(chan rpctypes.RPCResponse, 1)
+
+	w.mtx.Lock()
+	w.requestNonce++
+	id := fmt.Sprintf("%s#%d", method, w.requestNonce)
+	w.pending[id] = respCh
+	w.mtx.Unlock()
+
+	defer func() {
+		w.mtx.Lock()
+		delete(w.pending, id)
+		w.mtx.Unlock()
+	}()
+
+	request, err := rpctypes.MapToRequest(rpctypes.JSONRPCStringID(id), method, params)
+	if err != nil {
+		return err
+	}
+
+	if err := w.ws.Send(ctx, request); err != nil {
+		return err
+	}
+
+	select {
+	case resp := <-respCh:
+		if resp.Error != nil {
+			return resp.Error
+		}
+
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-w.Quit():
+		return errors.New("client stopped")
+	}
+}
+
+// redoSubscriptions re-subscribes to all the active queries,
+// after the connection has been re-established
+func (w *WSEvents) redoSubscriptions() {
+	w.mtx.Lock()
+	queries := make([]string, 0, len(w.subscriptions))
+	for q := range w.subscriptions {
+		queries = append(queries, q)
+	}
+	w.mtx.Unlock()
+
+	for _, q := range queries {
+		if err := w.call(context.Background(), "subscribe", map[string]interface{}{"query": q}); err != nil {
+			w.Logger.Error("unable to resubscribe", "query", q, "err", err)
+		}
+	}
+}
+
+// eventListener dispatches the node responses to
+// the pending requests and the subscription channels
+func (w *WSEvents) eventListener() {
+	for {
+		select {
+		case resp, ok := <-w.ws.ResponsesCh:
+			if !ok {
+				return
+			}
+
+			w.handleResponse(resp)
+		case <-w.Quit():
+			return
+		}
+	}
+}
+
+func (w *WSEvents) handleResponse(resp rpctypes.RPCResponse) {
+	id, _ := resp.ID.(rpctypes.JSONRPCStringID)
+
+	w.mtx.Lock()
+	defer w.mtx.Unlock()
+
+	if respCh, ok := w.pending[string(id)]; ok {
+		respCh <- resp
+
+		return
+	}
+
+	if !strings.HasSuffix(string(id), eventIDSuffix) {
+		w.Logger.Debug("unexpected response", "id", resp.ID)
+
+		return
+	}
+
+	if resp.Error != nil {
+		w.Logger.Error("event error", "err", resp.Error)
+
+		return
+	}
+
+	var event ctypes.ResultEvent
+	if err := amino.UnmarshalJSON(resp.Result, &event); err != nil {
+		w.Logger.Error("unable to parse event", "err", err, "result", string(resp.Result))
+
+		return
+	}
+
+	ch, ok := w.subscriptions[event.Query]
+	if !ok {
+		return
+	}
+
+	select {
+	case ch <- event:
+	default:
+		w.Logger.Info("subscriber too slow, dropping event", "query", event.Query)
+	}
+}
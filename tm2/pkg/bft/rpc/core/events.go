@@ -0,0 +1,289 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+
+	ctypes "github.com/gnolang/gno/tm2/pkg/bft/rpc/core/types"
+	rpctypes "github.com/gnolang/gno/tm2/pkg/bft/rpc/lib/types"
+	"github.com/gnolang/gno/tm2/pkg/bft/state/eventstore"
+	"github.com/gnolang/gno/tm2/pkg/bft/state/eventstore/query"
+	"github.com/gnolang/gno/tm2/pkg/bft/types"
+	"github.com/gnolang/gno/tm2/pkg/events"
+)
+
+// Reserved subscription query keys
+const (
+	// EventTypeKey is the type of the fired event
+	EventTypeKey = "tm.event"
+
+	// BlockHeightKey is the height of a new block
+	BlockHeightKey = "block.height"
+)
+
+// Event types that can be subscribed to, using EventTypeKey
+const (
+	EventTypeNewBlockHeader      = "NewBlockHeader"
+	EventTypeTx                  = "Tx"
+	EventTypeValidatorSetUpdates = "ValidatorSetUpdates"
+)
+
+// maxSubscriptionsPerClient is the maximum number of
+// active subscriptions a single websocket client can have
+const maxSubscriptionsPerClient = 100
+
+var (
+	errNotWebsocket         = errors.New("subscriptions are only available over websocket")
+	errAlreadySubscribed    = errors.New("already subscribed")
+	errSubscriptionNotFound = errors.New("subscription not found")
+	errTooManySubscriptions = fmt.Errorf("max subscriptions per client (%d) reached", maxSubscriptionsPerClient)
+)
+
+// subscriptions keeps track of the active event switch
+// listeners, per websocket client remote address and query
+var subscriptions = struct {
+	sync.Mutex
+
+	clients map[string]map[string]string // remote address -> query -> listener ID
+}{
+	clients: make(map[string]map[string]string),
+}
+
+// Subscribe for events via WebSocket.
+//
+// Events are filtered using a query (see the eventstore/query package for the
+// syntax). The reserved `tm.event` key selects the event type:
+//   - `NewBlockHeader`, with the `block.height` key
+//   - `Tx`, with the `tx.hash`, `tx.height`, `tx.signer` keys and
+//     `<event type>.<attribute key>` for each event emitted by the transaction
+//   - `ValidatorSetUpdates`
+//
+// Every matching event is pushed to the client as a JSON-RPC response with
+// the ID `<subscribe request ID>#event`. Events are dropped if the client
+// doesn't keep up with them.
+//
+//	```go
+//	client := client.NewHTTP("tcp://0.0.0.0:26657", "/websocket")
+//	defer client.Close()
+//
+//	q := "tm.event = 'Tx' AND tx.signer = 'g1jg8mtutu9khhfwc4nxmuhcpftf0pajdhfvsqf5'"
+//	events, err := client.Subscribe(context.Background(), q)
+//	if err != nil {
+//	  // handle error
+//	}
+//
+//	for e := range events {
+//	  // handle event
+//	}
+//	```
+//
+// > The above command returns JSON structured like this:
+//
+//	```json
+//	{
+//		"error": "",
+//		"result": {},
+//		"id": "",
+//		"jsonrpc": "2.0"
+//	}
+//	```
+//
+// ### Query Parameters
+//
+// | Parameter | Type   | Default | Required | Description |
+// |-----------+--------+---------+----------+-------------|
+// | query     | string | ""      | true     | Query       |
+func Subscribe(ctx *rpctypes.Context, queryStr string) (*ctypes.ResultSubscribe, error) {
+	if ctx.WSConn == nil {
+		return nil, errNotWebsocket
+	}
+
+	q, err := query.New(queryStr)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		conn       = ctx.WSConn
+		remoteAddr = conn.GetRemoteAddr()
+		listenerID = fmt.Sprintf("rpc-subscription#%s#%s", remoteAddr, q.String())
+		eventID    = rpctypes.JSONRPCStringID(fmt.Sprintf("%v#event", ctx.JSONReq.ID))
+	)
+
+	subscriptions.Lock()
+	defer subscriptions.Unlock()
+
+	clientSubs := subscriptions.clients[remoteAddr]
+	if _, ok := clientSubs[q.String()]; ok {
+		return nil, errAlreadySubscribed
+	}
+
+	if len(clientSubs) >= maxSubscriptionsPerClient {
+		return nil, errTooManySubscriptions
+	}
+
+	if clientSubs == nil {
+		clientSubs = make(map[string]string)
+		subscriptions.clients[remoteAddr] = clientSubs
+	}
+
+	clientSubs[q.String()] = listenerID
+
+	// NOTE: the callback is invoked synchronously by the event switch,
+	// so it must not block
+	evsw.AddListener(listenerID, func(event events.Event) {
+		attrs := eventAttributes(event)
+		if attrs == nil || !q.Matches(attrs) {
+			return
+		}
+
+		resp := rpctypes.NewRPCSuccessResponse(eventID, &ctypes.ResultEvent{
+			Query: q.String(),
+			Event: event,
+		})
+
+		if !conn.TryWriteRPCResponse(resp) {
+			logger.Info(
+				"unable to push event to subscriber",
+				"remote", remoteAddr,
+				"query", q.String(),
+			)
+		}
+	})
+
+	return &ctypes.ResultSubscribe{}, nil
+}
+
+// Unsubscribe from events via WebSocket.
+//
+//	```go
+//	client := client.NewHTTP("tcp://0.0.0.0:26657", "/websocket")
+//	defer client.Close()
+//
+//	err := client.Unsubscribe(context.Background(), "tm.event = 'Tx'")
+//	```
+//
+// > The above command returns JSON structured like this:
+//
+//	```json
+//	{
+//		"error": "",
+//		"result": {},
+//		"id": "",
+//		"jsonrpc": "2.0"
+//	}
+//	```
+//
+// ### Query Parameters
+//
+// | Parameter | Type   | Default | Required | Description |
+// |-----------+--------+---------+----------+-------------|
+// | query     | string | ""      | true     | Query       |
+func Unsubscribe(ctx *rpctypes.Context, queryStr string) (*ctypes.ResultUnsubscribe, error) {
+	if ctx.WSConn == nil {
+		return nil, errNotWebsocket
+	}
+
+	q, err := query.New(queryStr)
+	if err != nil {
+		return nil, err
+	}
+
+	remoteAddr := ctx.RemoteAddr()
+
+	subscriptions.Lock()
+	defer subscriptions.Unlock()
+
+	clientSubs := subscriptions.clients[remoteAddr]
+
+	listenerID, ok := clientSubs[q.String()]
+	if !ok {
+		return nil, errSubscriptionNotFound
+	}
+
+	evsw.RemoveListener(listenerID)
+	delete(clientSubs, q.String())
+
+	if len(clientSubs) == 0 {
+		delete(subscriptions.clients, remoteAddr)
+	}
+
+	return &ctypes.ResultUnsubscribe{}, nil
+}
+
+// Unsubscribe from all events via WebSocket.
+//
+//	```go
+//	client := client.NewHTTP("tcp://0.0.0.0:26657", "/websocket")
+//	defer client.Close()
+//
+//	err := client.UnsubscribeAll(context.Background())
+//	```
+//
+// > The above command returns JSON structured like this:
+//
+//	```json
+//	{
+//		"error": "",
+//		"result": {},
+//		"id": "",
+//		"jsonrpc": "2.0"
+//	}
+//	```
+func UnsubscribeAll(ctx *rpctypes.Context) (*ctypes.ResultUnsubscribe, error) {
+	if ctx.WSConn == nil {
+		return nil, errNotWebsocket
+	}
+
+	if !UnsubscribeClient(ctx.RemoteAddr()) {
+		return nil, errSubscriptionNotFound
+	}
+
+	return &ctypes.ResultUnsubscribe{}, nil
+}
+
+// UnsubscribeClient removes all the subscriptions of the websocket client
+// with the given remote address, and returns true if there were any.
+// It is meant to be called when the client disconnects
+func UnsubscribeClient(remoteAddr string) bool {
+	subscriptions.Lock()
+	defer subscriptions.Unlock()
+
+	clientSubs, ok := subscriptions.clients[remoteAddr]
+	if !ok {
+		return false
+	}
+
+	for _, listenerID := range clientSubs {
+		evsw.RemoveListener(listenerID)
+	}
+
+	delete(subscriptions.clients, remoteAddr)
+
+	return true
+}
+
+// eventAttributes returns the queryable attributes of the given event,
+// or nil if the event type can't be subscribed to
+func eventAttributes(event events.Event) map[string][]string {
+	switch ev := event.(type) {
+	case types.EventNewBlockHeader:
+		return map[string][]string{
+			EventTypeKey:   {EventTypeNewBlockHeader},
+			BlockHeightKey: {strconv.FormatInt(ev.Header.Height, 10)},
+		}
+	case types.EventTx:
+		attrs := eventstore.TxResultAttributes(ev.Result)
+		attrs[EventTypeKey] = []string{EventTypeTx}
+
+		return attrs
+	case types.EventValidatorSetUpdates:
+		return map[string][]string{
+			EventTypeKey: {EventTypeValidatorSetUpdates},
+		}
+	default:
+		return nil
+	}
+}
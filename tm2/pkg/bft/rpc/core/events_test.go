@@ -0,0 +1,168 @@
+package core
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gnolang/gno/tm2/pkg/amino"
+	abci "github.com/gnolang/gno/tm2/pkg/bft/abci/types"
+	ctypes "github.com/gnolang/gno/tm2/pkg/bft/rpc/core/types"
+	rpctypes "github.com/gnolang/gno/tm2/pkg/bft/rpc/lib/types"
+	"github.com/gnolang/gno/tm2/pkg/bft/types"
+	"github.com/gnolang/gno/tm2/pkg/events"
+	"github.com/gnolang/gno/tm2/pkg/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mockWSConn is a rpctypes.WSRPCConnection that records written responses
+type mockWSConn struct {
+	remoteAddr string
+	responses  []rpctypes.RPCResponse
+}
+
+func (m *mockWSConn) GetRemoteAddr() string { return m.remoteAddr }
+
+func (m *mockWSConn) WriteRPCResponse(resp rpctypes.RPCResponse) {
+	m.responses = append(m.responses, resp)
+}
+
+func (m *mockWSConn) TryWriteRPCResponse(resp rpctypes.RPCResponse) bool {
+	m.responses = append(m.responses, resp)
+
+	return true
+}
+
+func (m *mockWSConn) Context() context.Context { return context.Background() }
+
+// spoofedTxEvent is an attributed event of type "tx",
+// which tries to override the reserved transaction attributes
+type spoofedTxEvent struct {
+	Attrs []abci.EventAttribute
+}
+
+func (spoofedTxEvent) AssertABCIEvent()                         {}
+func (spoofedTxEvent) EventType() string                        { return "tx" }
+func (e spoofedTxEvent) EventAttributes() []abci.EventAttribute { return e.Attrs }
+
+func TestSubscribeUnsubscribe(t *testing.T) {
+	sw := events.NewEventSwitch()
+	require.NoError(t, sw.Start())
+	defer sw.Stop()
+
+	evsw = sw
+	SetLogger(log.NewNoopLogger())
+
+	conn := &mockWSConn{remoteAddr: "127.0.0.1:1234"}
+	ctx := &rpctypes.Context{
+		JSONReq: &rpctypes.RPCRequest{ID: rpctypes.JSONRPCStringID("sub")},
+		WSConn:  conn,
+	}
+
+	// Subscriptions are only available over websocket
+	_, err := Subscribe(&rpctypes.Context{}, "tm.event = 'Tx'")
+	assert.ErrorIs(t, err, errNotWebsocket)
+
+	// Invalid query
+	_, err = Subscribe(ctx, "tm.event")
+	assert.Error(t, err)
+
+	const headerQuery = "tm.event = 'NewBlockHeader' AND block.height > 1"
+
+	_, err = Subscribe(ctx, headerQuery)
+	require.NoError(t, err)
+
+	_, err = Subscribe(ctx, headerQuery)
+	assert.ErrorIs(t, err, errAlreadySubscribed)
+
+	_, err = Subscribe(ctx, "tm.event = 'Tx' AND tx.height = 2")
+	require.NoError(t, err)
+
+	for height := int64(1); height <= 3; height++ {
+		sw.FireEvent(types.EventNewBlockHeader{Header: types.Header{Height: height}})
+		sw.FireEvent(types.EventTx{Result: types.TxResult{Height: height, Tx: types.Tx("tx")}})
+	}
+
+	// Not subscribable
+	sw.FireEvent(types.EventVote{})
+
+	require.Len(t, conn.responses, 3)
+
+	for _, resp := range conn.responses {
+		assert.Equal(t, rpctypes.JSONRPCStringID("sub#event"), resp.ID)
+		assert.Nil(t, resp.Error)
+	}
+
+	var event ctypes.ResultEvent
+	require.NoError(t, amino.UnmarshalJSON(conn.responses[0].Result, &event))
+	assert.Equal(t, headerQuery, event.Query)
+	assert.Equal(t, int64(2), event.Event.(types.EventNewBlockHeader).Header.Height)
+
+	// Unsubscribe
+	_, err = Unsubscribe(ctx, headerQuery)
+	require.NoError(t, err)
+
+	_, err = Unsubscribe(ctx, headerQuery)
+	assert.ErrorIs(t, err, errSubscriptionNotFound)
+
+	sw.FireEvent(types.EventNewBlockHeader{Header: types.Header{Height: 4}})
+	assert.Len(t, conn.responses, 3)
+
+	// Unsubscribe all
+	_, err = UnsubscribeAll(ctx)
+	require.NoError(t, err)
+
+	_, err = UnsubscribeAll(ctx)
+	assert.ErrorIs(t, err, errSubscriptionNotFound)
+
+	sw.FireEvent(types.EventTx{Result: types.TxResult{Height: 2, Tx: types.Tx("tx")}})
+	assert.Len(t, conn.responses, 3)
+}
+
+func TestSubscribe_SpoofedTxAttributes(t *testing.T) {
+	sw := events.NewEventSwitch()
+	require.NoError(t, sw.Start())
+	defer sw.Stop()
+
+	evsw = sw
+	SetLogger(log.NewNoopLogger())
+
+	conn := &mockWSConn{remoteAddr: "127.0.0.1:1234"}
+	ctx := &rpctypes.Context{
+		JSONReq: &rpctypes.RPCRequest{ID: rpctypes.JSONRPCStringID("sub")},
+		WSConn:  conn,
+	}
+
+	for _, q := range []string{
+		"tm.event = 'Tx' AND tx.signer = 'g1victim'",
+		"tm.event = 'Tx' AND tx.hash = 'AB'",
+		"tm.event = 'Tx' AND tx.height = 42",
+	} {
+		_, err := Subscribe(ctx, q)
+		require.NoError(t, err)
+	}
+
+	result := types.TxResult{
+		Height: 1,
+		Tx:     types.Tx("tx"),
+		Response: abci.ResponseDeliverTx{
+			ResponseBase: abci.ResponseBase{
+				Events: []abci.Event{
+					spoofedTxEvent{
+						Attrs: []abci.EventAttribute{
+							{Key: "signer", Value: "g1victim"},
+							{Key: "hash", Value: "AB"},
+							{Key: "height", Value: "42"},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	sw.FireEvent(types.EventTx{Result: result})
+	assert.Empty(t, conn.responses)
+
+	_, err := UnsubscribeAll(ctx)
+	require.NoError(t, err)
+}
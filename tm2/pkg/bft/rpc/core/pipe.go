@@ -10,6 +10,7 @@ import (
 	mempl "github.com/gnolang/gno/tm2/pkg/bft/mempool"
 	cfg "github.com/gnolang/gno/tm2/pkg/bft/rpc/config"
 	sm "github.com/gnolang/gno/tm2/pkg/bft/state"
+	"github.com/gnolang/gno/tm2/pkg/bft/state/eventstore"
 	"github.com/gnolang/gno/tm2/pkg/bft/types"
 	"github.com/gnolang/gno/tm2/pkg/crypto"
 	dbm "github.com/gnolang/gno/tm2/pkg/db"
@@ -59,6 +60,7 @@ var (
 	// interfaces defined in types and above
 	stateDB        dbm.DB
 	blockStore     sm.BlockStore
+	txEventStore   eventstore.TxEventStore
 	consensusState Consensus
 	p2pPeers       peers
 	p2pTransport   transport
@@ -84,6 +86,10 @@ func SetBlockStore(bs sm.BlockStore) {
 	blockStore = bs
 }
 
+func SetTxEventStore(es eventstore.TxEventStore) {
+	txEventStore = es
+}
+
 func SetMempool(mem mempl.Mempool) {
 	mempool = mem
 }
@@ -154,6 +160,15 @@ func validatePage(page, perPage, totalCount int) (int, error) {
 	return page, nil
 }
 
+func validateSkipCount(page, perPage int) int {
+	skipCount := (page - 1) * perPage
+	if skipCount < 0 {
+		return 0
+	}
+
+	return skipCount
+}
+
 func validatePerPage(perPage int) int {
 	if perPage < 1 {
 		return defaultPerPage
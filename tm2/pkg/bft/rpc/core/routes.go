@@ -8,16 +8,16 @@ import (
 // NOTE: Amino is registered in rpc/core/types/codec.go.
 var Routes = map[string]*rpc.RPCFunc{
 	// info API
-	"health":        rpc.NewRPCFunc(Health, ""),
-	"status":        rpc.NewRPCFunc(Status, ""),
-	"net_info":      rpc.NewRPCFunc(NetInfo, ""),
-	"blockchain":    rpc.NewRPCFunc(BlockchainInfo, "minHeight,maxHeight"),
-	"genesis":       rpc.NewRPCFunc(Genesis, ""),
-	"block":         rpc.NewRPCFunc(Block, "height"),
-	"block_results": rpc.NewRPCFunc(BlockResults, "height"),
-	"commit":        rpc.NewRPCFunc(Commit, "height"),
-	//"tx":                   rpc.NewRPCFunc(Tx, "hash,prove"),
-	//"tx_search":            rpc.NewRPCFunc(TxSearch, "query,prove,page,per_page"),
+	"health":               rpc.NewRPCFunc(Health, ""),
+	"status":               rpc.NewRPCFunc(Status, ""),
+	"net_info":             rpc.NewRPCFunc(NetInfo, ""),
+	"blockchain":           rpc.NewRPCFunc(BlockchainInfo, "minHeight,maxHeight"),
+	"genesis":              rpc.NewRPCFunc(Genesis, ""),
+	"block":                rpc.NewRPCFunc(Block, "height"),
+	"block_results":        rpc.NewRPCFunc(BlockResults, "height"),
+	"commit":               rpc.NewRPCFunc(Commit, "height"),
+	"tx":                   rpc.NewRPCFunc(Tx, "hash,prove"),
+	"tx_search":            rpc.NewRPCFunc(TxSearch, "query,prove,page,per_page"),
 	"validators":           rpc.NewRPCFunc(Validators, "height"),
 	"dump_consensus_state": rpc.NewRPCFunc(DumpConsensusState, ""),
 	"consensus_state":      rpc.NewRPCFunc(ConsensusState, ""),
@@ -25,6 +25,11 @@ var Routes = map[string]*rpc.RPCFunc{
 	"unconfirmed_txs":      rpc.NewRPCFunc(UnconfirmedTxs, "limit"),
 	"num_unconfirmed_txs":  rpc.NewRPCFunc(NumUnconfirmedTxs, ""),
 
+	// events API
+	"subscribe":       rpc.NewWSRPCFunc(Subscribe, "query"),
+	"unsubscribe":     rpc.NewWSRPCFunc(Unsubscribe, "query"),
+	"unsubscribe_all": rpc.NewWSRPCFunc(UnsubscribeAll, ""),
+
 	// tx broadcast API
 	"broadcast_tx_commit": rpc.NewRPCFunc(BroadcastTxCommit, "tx"),
 	"broadcast_tx_sync":   rpc.NewRPCFunc(BroadcastTxSync, "tx"),
@@ -0,0 +1,57 @@
+package core
+
+import (
+	"fmt"
+	"testing"
+
+	rpctypes "github.com/gnolang/gno/tm2/pkg/bft/rpc/lib/types"
+	"github.com/gnolang/gno/tm2/pkg/bft/state/eventstore/kv"
+	"github.com/gnolang/gno/tm2/pkg/bft/state/eventstore/null"
+	"github.com/gnolang/gno/tm2/pkg/bft/types"
+	"github.com/gnolang/gno/tm2/pkg/db/memdb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTxAndTxSearch(t *testing.T) {
+	// Indexing disabled
+	SetTxEventStore(null.NewNullEventStore())
+
+	_, err := Tx(&rpctypes.Context{}, []byte("hash"), false)
+	assert.ErrorIs(t, err, errTxIndexingDisabled)
+
+	_, err = TxSearch(&rpctypes.Context{}, "tx.height = 1", false, 1, 30)
+	assert.ErrorIs(t, err, errTxIndexingDisabled)
+
+	// Indexing enabled
+	es := kv.NewTxEventStore(memdb.NewMemDB())
+	SetTxEventStore(es)
+
+	txs := make([]types.TxResult, 5)
+	for i := range txs {
+		txs[i] = types.TxResult{
+			Height: int64(i + 1),
+			Tx:     types.Tx(fmt.Sprintf("tx-%d", i)),
+		}
+
+		require.NoError(t, es.Append(txs[i]))
+	}
+
+	res, err := Tx(&rpctypes.Context{}, txs[2].Tx.Hash(), false)
+	require.NoError(t, err)
+	assert.Equal(t, txs[2].Tx, res.Tx)
+	assert.Equal(t, txs[2].Height, res.Height)
+
+	_, err = Tx(&rpctypes.Context{}, []byte("missing"), false)
+	assert.Error(t, err)
+
+	// Paginated search
+	search, err := TxSearch(&rpctypes.Context{}, "tx.height > 1", false, 2, 3)
+	require.NoError(t, err)
+	assert.Equal(t, 4, search.TotalCount)
+	require.Len(t, search.Txs, 1)
+	assert.Equal(t, txs[4].Tx, search.Txs[0].Tx)
+
+	_, err = TxSearch(&rpctypes.Context{}, "tx.height", false, 1, 30)
+	assert.Error(t, err)
+}
@@ -199,9 +199,12 @@ type (
 	ResultUnsafeFlushMempool struct{}
 	ResultUnsafeProfile      struct{}
 	ResultHealth             struct{}
+	ResultSubscribe          struct{}
+	ResultUnsubscribe        struct{}
 )
 
 // Event data from a subscription
 type ResultEvent struct {
+	Query string        `json:"query"`
 	Event types.TMEvent `json:"event"`
 }
@@ -0,0 +1,84 @@
+package eventstore
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/gnolang/gno/tm2/pkg/amino"
+	abci "github.com/gnolang/gno/tm2/pkg/bft/abci/types"
+	"github.com/gnolang/gno/tm2/pkg/bft/types"
+	"github.com/gnolang/gno/tm2/pkg/std"
+)
+
+// Reserved composite keys for transaction attributes
+const (
+	TxHashKey   = "tx.hash"
+	TxHeightKey = "tx.height"
+	TxSignerKey = "tx.signer"
+)
+
+// TxResultAttributes returns the searchable attributes of the
+// given transaction result, keyed by their composite key:
+//   - tx.hash, the uppercase hex hash of the transaction
+//   - tx.height, the height of the block the transaction is in
+//   - tx.signer, the addresses of the transaction signers, if it's a std.Tx
+//   - <event type>.<attribute key>, for every abci.AttributedEvent
+//
+// Event attributes can't override the reserved keys above
+func TxResultAttributes(result types.TxResult) map[string][]string {
+	attrs := map[string][]string{
+		TxHashKey:   {fmt.Sprintf("%X", result.Tx.Hash())},
+		TxHeightKey: {strconv.FormatInt(result.Height, 10)},
+	}
+
+	var tx std.Tx
+	if err := amino.Unmarshal(result.Tx, &tx); err == nil {
+		for _, signer := range tx.GetSigners() {
+			attrs[TxSignerKey] = append(attrs[TxSignerKey], signer.String())
+		}
+	}
+
+	for key, values := range EventsAttributes(result.Response.Events) {
+		attrs[key] = append(attrs[key], values...)
+	}
+
+	return attrs
+}
+
+// EventsAttributes returns the attributes of the given events,
+// keyed by "<event type>.<attribute key>". Events which are not
+// an abci.AttributedEvent are skipped, as are attributes whose
+// composite key is reserved (tx.hash, tx.height, tx.signer), since
+// their values are set by the emitter and can't be trusted
+func EventsAttributes(events []abci.Event) map[string][]string {
+	attrs := make(map[string][]string)
+
+	for _, event := range events {
+		ae, ok := event.(abci.AttributedEvent)
+		if !ok {
+			continue
+		}
+
+		for _, attr := range ae.EventAttributes() {
+			key := ae.EventType() + "." + attr.Key
+			if isReservedKey(key) {
+				continue
+			}
+
+			attrs[key] = append(attrs[key], attr.Value)
+		}
+	}
+
+	return attrs
+}
+
+// isReservedKey returns a flag indicating if the given
+// composite key is reserved for the transaction attributes
+func isReservedKey(key string) bool {
+	switch key {
+	case TxHashKey, TxHeightKey, TxSignerKey:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,307 @@
+package kv
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gnolang/gno/tm2/pkg/amino"
+	"github.com/gnolang/gno/tm2/pkg/bft/state/eventstore"
+	"github.com/gnolang/gno/tm2/pkg/bft/state/eventstore/query"
+	"github.com/gnolang/gno/tm2/pkg/bft/types"
+	dbm "github.com/gnolang/gno/tm2/pkg/db"
+)
+
+var _ eventstore.TxIndexer = (*TxEventStore)(nil)
+
+const (
+	EventStoreType = "kv"
+
+	// txPrefix prefixes the transaction results, keyed by hash
+	txPrefix = "tx/"
+
+	// indexPrefix prefixes the attribute indexes, which are keyed
+	// as idx/<composite key>\x00<value>\x00<height>/<index>
+	// and point to the transaction hash
+	indexPrefix = "idx/"
+	separator   = "\x00"
+)
+
+// TxEventStore is the implementation of a transaction event store
+// that indexes transaction results in a key-value database,
+// allowing them to be looked up and searched
+type TxEventStore struct {
+	db dbm.DB
+}
+
+// NewTxEventStore creates a new kv-based tx event store,
+// backed by the given database
+func NewTxEventStore(db dbm.DB) *TxEventStore {
+	return &TxEventStore{
+		db: db,
+	}
+}
+
+// Start starts the kv transaction event store (no-op)
+func (t *TxEventStore) Start() error {
+	return nil
+}
+
+// Stop stops the kv transaction event store, by closing the database
+func (t *TxEventStore) Stop() error {
+	t.db.Close()
+
+	return nil
+}
+
+// GetType returns the kv transaction event store type
+func (t *TxEventStore) GetType() string {
+	return EventStoreType
+}
+
+// Append stores the transaction result, and indexes it
+// by all of its attributes (hash, height, signers and events)
+func (t *TxEventStore) Append(result types.TxResult) error {
+	raw, err := amino.Marshal(result)
+	if err != nil {
+		return fmt.Errorf("unable to marshal transaction, %w", err)
+	}
+
+	hash := result.Tx.Hash()
+
+	batch := t.db.NewBatch()
+	defer batch.Close()
+
+	batch.Set(txKey(hash), raw)
+
+	for key, values := range eventstore.TxResultAttributes(result) {
+		if key == eventstore.TxHashKey {
+			// transactions are already keyed by hash
+			continue
+		}
+
+		for _, value := range values {
+			batch.Set(indexKey(key, value, result.Height, result.Index), hash)
+		}
+	}
+
+	batch.WriteSync()
+
+	return nil
+}
+
+// GetTx returns the transaction result with the given hash, if any
+func (t *TxEventStore) GetTx(hash []byte) (*types.TxResult, error) {
+	raw := t.db.Get(txKey(hash))
+	if raw == nil {
+		return nil, nil
+	}
+
+	var result types.TxResult
+	if err := amino.Unmarshal(raw, &result); err != nil {
+		return nil, fmt.Errorf("unable to unmarshal transaction, %w", err)
+	}
+
+	return &result, nil
+}
+
+// Search returns at most limit transaction results matching all the query
+// conditions, skipping the first skip ones, along with the total number of
+// matching transactions. Only the returned results are loaded from the
+// database
+func (t *TxEventStore) Search(q *query.Query, skip, limit int) ([]*types.TxResult, int, error) {
+	conditions := append([]query.Condition(nil), q.Conditions()...)
+
+	// Equality conditions only scan the index entries of their value,
+	// so they are matched first to narrow down the candidates
+	sort.SliceStable(conditions, func(i, j int) bool {
+		return conditions[i].Op == query.OpEqual && conditions[j].Op != query.OpEqual
+	})
+
+	var matches map[string]txPosition
+
+	for _, c := range conditions {
+		if c.Key == eventstore.TxHashKey && c.Op != query.OpEqual {
+			return nil, 0, fmt.Errorf("unsupported operator %s for %s", c.Op, eventstore.TxHashKey)
+		}
+
+		condMatches, err := t.match(c)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		if matches == nil {
+			matches = condMatches
+		} else {
+			// intersect with the previous conditions
+			for hash := range matches {
+				if _, ok := condMatches[hash]; !ok {
+					delete(matches, hash)
+				}
+			}
+		}
+
+		if len(matches) == 0 {
+			return []*types.TxResult{}, 0, nil
+		}
+	}
+
+	hashes := make([]string, 0, len(matches))
+	for hash := range matches {
+		hashes = append(hashes, hash)
+	}
+
+	sort.Slice(hashes, func(i, j int) bool {
+		return matches[hashes[i]].less(matches[hashes[j]])
+	})
+
+	total := len(hashes)
+	if skip > total {
+		skip = total
+	}
+
+	hashes = hashes[skip:]
+	if limit < len(hashes) {
+		hashes = hashes[:limit]
+	}
+
+	results := make([]*types.TxResult, 0, len(hashes))
+
+	for _, hash := range hashes {
+		result, err := t.GetTx([]byte(hash))
+		if err != nil {
+			return nil, 0, err
+		}
+
+		if result != nil {
+			results = append(results, result)
+		}
+	}
+
+	return results, total, nil
+}
+
+// txPosition is the position of a transaction in the chain
+type txPosition struct {
+	height int64
+	index  uint32
+}
+
+// less returns true if the position is before the other one
+func (p txPosition) less(other txPosition) bool {
+	if p.height == other.height {
+		return p.index < other.index
+	}
+
+	return p.height < other.height
+}
+
+// match returns the hashes of the transactions matching the condition,
+// along with their position
+func (t *TxEventStore) match(c query.Condition) (map[string]txPosition, error) {
+	hashes := make(map[string]txPosition)
+
+	if c.Key == eventstore.TxHashKey {
+		hash, err := hex.DecodeString(c.Operand)
+		if err != nil {
+			return nil, fmt.Errorf("invalid transaction hash, %w", err)
+		}
+
+		result, err := t.GetTx(hash)
+		if err != nil {
+			return nil, err
+		}
+
+		if result != nil {
+			hashes[string(hash)] = txPosition{
+				height: result.Height,
+				index:  result.Index,
+			}
+		}
+
+		return hashes, nil
+	}
+
+	prefix := []byte(indexPrefix + c.Key + separator)
+	if c.Op == query.OpEqual {
+		// only the entries of the operand value can match
+		value := c.Operand
+		if c.IsNumber {
+			value = strconv.FormatInt(c.Number, 10)
+		}
+
+		prefix = append(prefix, []byte(value+separator)...)
+	}
+
+	it := t.db.Iterator(prefix, prefixEnd(prefix))
+	defer it.Close()
+
+	for ; it.Valid(); it.Next() {
+		value, pos, ok := parseIndexKey(it.Key(), []byte(indexPrefix+c.Key+separator))
+		if !ok || !c.Matches(value) {
+			continue
+		}
+
+		hashes[string(it.Value())] = pos
+	}
+
+	return hashes, nil
+}
+
+// txKey returns the key of the transaction result with the given hash
+func txKey(hash []byte) []byte {
+	return []byte(txPrefix + fmt.Sprintf("%X", hash))
+}
+
+// indexKey returns the index key for the given attribute of a transaction
+func indexKey(key, value string, height int64, index uint32) []byte {
+	return []byte(
+		fmt.Sprintf(
+			"%s%s%s%s%s%020d/%010d",
+			indexPrefix,
+			key,
+			separator,
+			value,
+			separator,
+			height,
+			index,
+		),
+	)
+}
+
+// parseIndexKey extracts the attribute value and the transaction
+// position from the given index key
+func parseIndexKey(key, prefix []byte) (string, txPosition, bool) {
+	rest := string(bytes.TrimPrefix(key, prefix))
+
+	idx := strings.LastIndex(rest, separator)
+	if idx < 0 {
+		return "", txPosition{}, false
+	}
+
+	var pos txPosition
+	if _, err := fmt.Sscanf(rest[idx+1:], "%d/%d", &pos.height, &pos.index); err != nil {
+		return "", txPosition{}, false
+	}
+
+	return rest[:idx], pos, true
+}
+
+// prefixEnd returns the end key (exclusive) of the prefix iteration
+func prefixEnd(prefix []byte) []byte {
+	end := make([]byte, len(prefix))
+	copy(end, prefix)
+
+	for i := len(end) - 1; i >= 0; i-- {
+		if end[i] < 0xff {
+			end[i]++
+
+			return end[:i+1]
+		}
+	}
+
+	return nil
+}
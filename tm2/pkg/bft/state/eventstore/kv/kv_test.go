@@ -0,0 +1,234 @@
+package kv
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/gnolang/gno/tm2/pkg/amino"
+	abci "github.com/gnolang/gno/tm2/pkg/bft/abci/types"
+	"github.com/gnolang/gno/tm2/pkg/bft/state/eventstore/query"
+	"github.com/gnolang/gno/tm2/pkg/bft/types"
+	"github.com/gnolang/gno/tm2/pkg/db/memdb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testEvent is a simple attributed event
+type testEvent struct {
+	Attrs []abci.EventAttribute
+}
+
+func (testEvent) AssertABCIEvent()                         {}
+func (testEvent) EventType() string                        { return "Transfer" }
+func (e testEvent) EventAttributes() []abci.EventAttribute { return e.Attrs }
+
+// spoofedTxEvent is an attributed event of type "tx",
+// which tries to override the reserved transaction attributes
+type spoofedTxEvent struct {
+	Attrs []abci.EventAttribute
+}
+
+func (spoofedTxEvent) AssertABCIEvent()                         {}
+func (spoofedTxEvent) EventType() string                        { return "tx" }
+func (e spoofedTxEvent) EventAttributes() []abci.EventAttribute { return e.Attrs }
+
+func init() {
+	amino.RegisterPackage(
+		amino.NewPackage(
+			reflect.TypeOf(testEvent{}).PkgPath(),
+			"kv_test",
+			amino.GetCallersDirname(),
+		).
+			WithTypes(testEvent{}, spoofedTxEvent{}),
+	)
+}
+
+// generateTestTransactions generates transaction results,
+// one per height, with a Transfer event
+func generateTestTransactions(count int) []types.TxResult {
+	txs := make([]types.TxResult, count)
+
+	for i := 0; i < count; i++ {
+		txs[i] = types.TxResult{
+			Height: int64(i + 1),
+			Index:  0,
+			Tx:     types.Tx(fmt.Sprintf("tx-%d", i)),
+			Response: abci.ResponseDeliverTx{
+				ResponseBase: abci.ResponseBase{
+					Events: []abci.Event{
+						testEvent{
+							Attrs: []abci.EventAttribute{
+								{Key: "to", Value: fmt.Sprintf("g1%d", i%2)},
+							},
+						},
+					},
+				},
+			},
+		}
+	}
+
+	return txs
+}
+
+func TestTxEventStore_GetTx(t *testing.T) {
+	t.Parallel()
+
+	txs := generateTestTransactions(3)
+	es := NewTxEventStore(memdb.NewMemDB())
+
+	for _, tx := range txs {
+		require.NoError(t, es.Append(tx))
+	}
+
+	for _, tx := range txs {
+		result, err := es.GetTx(tx.Tx.Hash())
+		require.NoError(t, err)
+		require.NotNil(t, result)
+
+		assert.Equal(t, tx.Height, result.Height)
+		assert.Equal(t, tx.Tx, result.Tx)
+	}
+
+	// Missing transaction
+	result, err := es.GetTx(types.Tx("missing").Hash())
+	require.NoError(t, err)
+	assert.Nil(t, result)
+}
+
+func TestTxEventStore_Search(t *testing.T) {
+	t.Parallel()
+
+	txs := generateTestTransactions(10)
+	es := NewTxEventStore(memdb.NewMemDB())
+
+	for _, tx := range txs {
+		require.NoError(t, es.Append(tx))
+	}
+
+	testTable := []struct {
+		query           string
+		expectedHeights []int64
+	}{
+		{
+			fmt.Sprintf("tx.hash = '%X'", txs[4].Tx.Hash()),
+			[]int64{5},
+		},
+		{
+			"tx.height = 3",
+			[]int64{3},
+		},
+		{
+			"tx.height > 7",
+			[]int64{8, 9, 10},
+		},
+		{
+			"Transfer.to = 'g11' AND tx.height <= 6",
+			[]int64{2, 4, 6},
+		},
+		{
+			"Transfer.to = 'g12'",
+			[]int64{},
+		},
+	}
+
+	for _, testCase := range testTable {
+		testCase := testCase
+
+		t.Run(testCase.query, func(t *testing.T) {
+			t.Parallel()
+
+			results, total, err := es.Search(query.MustNew(testCase.query), 0, 100)
+			require.NoError(t, err)
+			assert.Equal(t, len(testCase.expectedHeights), total)
+
+			heights := make([]int64, 0, len(results))
+			for _, result := range results {
+				heights = append(heights, result.Height)
+			}
+
+			assert.Equal(t, testCase.expectedHeights, heights)
+		})
+	}
+}
+
+func TestTxEventStore_Search_Pagination(t *testing.T) {
+	t.Parallel()
+
+	txs := generateTestTransactions(10)
+	es := NewTxEventStore(memdb.NewMemDB())
+
+	for _, tx := range txs {
+		require.NoError(t, es.Append(tx))
+	}
+
+	results, total, err := es.Search(query.MustNew("tx.height > 2"), 3, 2)
+	require.NoError(t, err)
+
+	assert.Equal(t, 8, total)
+	require.Len(t, results, 2)
+	assert.Equal(t, int64(6), results[0].Height)
+	assert.Equal(t, int64(7), results[1].Height)
+
+	results, total, err = es.Search(query.MustNew("tx.height > 2"), 20, 2)
+	require.NoError(t, err)
+
+	assert.Equal(t, 8, total)
+	assert.Empty(t, results)
+}
+
+func TestTxEventStore_Search_InvalidHashOperator(t *testing.T) {
+	t.Parallel()
+
+	es := NewTxEventStore(memdb.NewMemDB())
+
+	_, _, err := es.Search(query.MustNew("tx.hash > 'AB'"), 0, 10)
+	assert.Error(t, err)
+}
+
+func TestTxEventStore_Search_SpoofedAttributes(t *testing.T) {
+	t.Parallel()
+
+	es := NewTxEventStore(memdb.NewMemDB())
+
+	tx := types.TxResult{
+		Height: 1,
+		Index:  0,
+		Tx:     types.Tx("spoofed-tx"),
+		Response: abci.ResponseDeliverTx{
+			ResponseBase: abci.ResponseBase{
+				Events: []abci.Event{
+					spoofedTxEvent{
+						Attrs: []abci.EventAttribute{
+							{Key: "signer", Value: "g1victim"},
+							{Key: "height", Value: "42"},
+							{Key: "hash", Value: "AB"},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	require.NoError(t, es.Append(tx))
+
+	for _, q := range []string{
+		"tx.signer = 'g1victim'",
+		"tx.height = 42",
+		"tx.hash = 'AB'",
+	} {
+		results, total, err := es.Search(query.MustNew(q), 0, 100)
+		require.NoError(t, err)
+
+		assert.Zero(t, total, q)
+		assert.Empty(t, results, q)
+	}
+
+	// The transaction is still indexed by its real height
+	results, total, err := es.Search(query.MustNew("tx.height = 1"), 0, 100)
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, total)
+	require.Len(t, results, 1)
+	assert.Equal(t, tx.Tx, results[0].Tx)
+}
@@ -0,0 +1,405 @@
+// Package query implements a minimal query language used to search and
+// filter transaction results and events, such as:
+//
+//	tx.height > 5 AND Transfer.to = 'g1jg8mtutu9khhfwc4nxmuhcpftf0pajdhfvsqf5'
+//
+// A query is a list of conditions joined by AND. Each condition is made of a
+// composite key, an operator and an operand. Operands are either strings,
+// enclosed in single quotes, or integers. Supported operators are
+// =, <, <=, >, >=, CONTAINS and EXISTS (which takes no operand).
+//
+// Within quoted strings, quotes and backslashes are escaped with a
+// backslash (see Quote). Keys containing spaces, quotes or operators
+// must be quoted too.
+package query
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+var (
+	errEmptyQuery      = errors.New("empty query")
+	errInvalidOperand  = errors.New("invalid operand")
+	errMissingOperator = errors.New("missing operator")
+	errUnterminatedStr = errors.New("unterminated quoted string")
+)
+
+// Operator is a comparison operator of a query condition
+type Operator string
+
+const (
+	OpEqual          Operator = "="
+	OpLess           Operator = "<"
+	OpLessEqual      Operator = "<="
+	OpGreater        Operator = ">"
+	OpGreaterEqual   Operator = ">="
+	OpContains       Operator = "CONTAINS"
+	OpExists         Operator = "EXISTS"
+	conditionJoinStr          = " AND "
+)
+
+// operators are ordered so that longer operators are matched first
+var operators = []Operator{
+	OpLessEqual,
+	OpGreaterEqual,
+	OpEqual,
+	OpLess,
+	OpGreater,
+	OpContains,
+	OpExists,
+}
+
+// Condition is a single "key op operand" element of a query
+type Condition struct {
+	Key     string
+	Op      Operator
+	Operand string
+
+	// Number is set if the operand is an integer
+	Number   int64
+	IsNumber bool
+}
+
+// Query is a parsed query, made of conditions which must all be matched
+type Query struct {
+	conditions []Condition
+}
+
+// New parses the given query string
+func New(s string) (*Query, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return nil, errEmptyQuery
+	}
+
+	parts, err := splitConditions(s)
+	if err != nil {
+		return nil, err
+	}
+
+	conditions := make([]Condition, 0, len(parts))
+
+	for _, part := range parts {
+		c, err := parseCondition(strings.TrimSpace(part))
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse condition %q, %w", part, err)
+		}
+
+		conditions = append(conditions, c)
+	}
+
+	return &Query{
+		conditions: conditions,
+	}, nil
+}
+
+// MustNew parses the given query string, and panics on error
+func MustNew(s string) *Query {
+	q, err := New(s)
+	if err != nil {
+		panic(err)
+	}
+
+	return q
+}
+
+// Quote returns s as a quoted query string, escaping its quotes
+// and backslashes. It can be used for both keys and operands
+func Quote(s string) string {
+	var sb strings.Builder
+
+	sb.WriteByte('\'')
+
+	for _, r := range s {
+		if r == '\'' || r == '\\' {
+			sb.WriteByte('\\')
+		}
+
+		sb.WriteRune(r)
+	}
+
+	sb.WriteByte('\'')
+
+	return sb.String()
+}
+
+// splitConditions splits the query string on the condition
+// separator, ignoring the separators within quoted strings
+func splitConditions(s string) ([]string, error) {
+	var (
+		parts   []string
+		start   int
+		inQuote bool
+	)
+
+	for i := 0; i < len(s); i++ {
+		switch {
+		case inQuote && s[i] == '\\':
+			i++ // skip the escaped character
+		case s[i] == '\'':
+			inQuote = !inQuote
+		case !inQuote && strings.HasPrefix(s[i:], conditionJoinStr):
+			parts = append(parts, s[start:i])
+			start = i + len(conditionJoinStr)
+			i = start - 1
+		}
+	}
+
+	if inQuote {
+		return nil, errUnterminatedStr
+	}
+
+	return append(parts, s[start:]), nil
+}
+
+// parseQuoted parses the quoted string at the start of s,
+// and returns its unescaped value and the remaining of s
+func parseQuoted(s string) (string, string, error) {
+	var sb strings.Builder
+
+	for i := 1; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+			if i == len(s) {
+				return "", "", errUnterminatedStr
+			}
+		case '\'':
+			return sb.String(), s[i+1:], nil
+		}
+
+		sb.WriteByte(s[i])
+	}
+
+	return "", "", errUnterminatedStr
+}
+
+// parseCondition parses a single query condition
+func parseCondition(s string) (Condition, error) {
+	if strings.HasPrefix(s, "'") {
+		// quoted key, followed by the operator
+		key, rest, err := parseQuoted(s)
+		if err != nil {
+			return Condition{}, err
+		}
+
+		if strings.TrimSpace(rest) == string(OpExists) {
+			return Condition{Key: key, Op: OpExists}, nil
+		}
+
+		return parseOperation(key, rest)
+	}
+
+	// EXISTS is a postfix operator
+	if strings.HasSuffix(s, " "+string(OpExists)) {
+		key := strings.TrimSpace(strings.TrimSuffix(s, string(OpExists)))
+		if key == "" {
+			return Condition{}, errors.New("missing key")
+		}
+
+		return Condition{Key: key, Op: OpExists}, nil
+	}
+
+	// find the leftmost operator, so operators
+	// within the operand are not considered
+	opIdx := -1
+
+	for _, candidate := range operators {
+		if candidate == OpExists {
+			continue
+		}
+
+		sep := string(candidate)
+		if candidate == OpContains {
+			sep = " " + sep + " "
+		}
+
+		idx := strings.Index(s, sep)
+		if idx < 0 || (opIdx >= 0 && idx >= opIdx) {
+			continue
+		}
+
+		opIdx = idx
+	}
+
+	if opIdx < 0 {
+		return Condition{}, errMissingOperator
+	}
+
+	key := strings.TrimSpace(s[:opIdx])
+	if key == "" {
+		return Condition{}, errors.New("missing key")
+	}
+
+	return parseOperation(key, s[opIdx:])
+}
+
+// parseOperation parses the "op operand" part of the condition with the
+// given key
+func parseOperation(key, s string) (Condition, error) {
+	s = strings.TrimSpace(s)
+
+	// operators are ordered so that longer operators are matched first
+	for _, op := range operators {
+		if op == OpExists || !strings.HasPrefix(s, string(op)) {
+			continue
+		}
+
+		operand := s[len(op):]
+		if op == OpContains {
+			if !strings.HasPrefix(operand, " ") {
+				continue
+			}
+		}
+
+		c := Condition{
+			Key: key,
+			Op:  op,
+		}
+
+		if err := c.parseOperand(strings.TrimSpace(operand)); err != nil {
+			return Condition{}, err
+		}
+
+		return c, nil
+	}
+
+	return Condition{}, errMissingOperator
+}
+
+// parseOperand parses a quoted string or integer operand
+func (c *Condition) parseOperand(s string) error {
+	if strings.HasPrefix(s, "'") {
+		operand, rest, err := parseQuoted(s)
+		if err != nil || rest != "" {
+			return errInvalidOperand
+		}
+
+		c.Operand = operand
+
+		return nil
+	}
+
+	if c.Op == OpContains {
+		// CONTAINS only supports strings
+		return errInvalidOperand
+	}
+
+	n, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return errInvalidOperand
+	}
+
+	c.Operand = strconv.FormatInt(n, 10)
+	c.Number = n
+	c.IsNumber = true
+
+	return nil
+}
+
+// String returns the canonical form of the condition
+func (c Condition) String() string {
+	key := c.Key
+	if strings.ContainsAny(key, " '\\=<>") {
+		key = Quote(key)
+	}
+
+	if c.Op == OpExists {
+		return key + " " + string(OpExists)
+	}
+
+	operand := c.Operand
+	if !c.IsNumber {
+		operand = Quote(operand)
+	}
+
+	return key + " " + string(c.Op) + " " + operand
+}
+
+// Conditions returns the conditions of the query
+func (q *Query) Conditions() []Condition {
+	return q.conditions
+}
+
+// String returns the canonical form of the query, which is the same for
+// all the query strings with the same conditions, regardless of their
+// formatting
+func (q *Query) String() string {
+	conditions := make([]string, 0, len(q.conditions))
+	for _, c := range q.conditions {
+		conditions = append(conditions, c.String())
+	}
+
+	return strings.Join(conditions, conditionJoinStr)
+}
+
+// Matches returns true if the given attributes satisfy
+// all the query conditions. attrs maps a composite key (ex. "tx.height")
+// to all of its values
+func (q *Query) Matches(attrs map[string][]string) bool {
+	for _, c := range q.conditions {
+		if !c.MatchesAny(attrs[c.Key]) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// MatchesAny returns true if at least one of the given values
+// satisfies the condition
+func (c Condition) MatchesAny(values []string) bool {
+	if c.Op == OpExists {
+		return len(values) > 0
+	}
+
+	for _, value := range values {
+		if c.Matches(value) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Matches returns true if the given value satisfies the condition
+func (c Condition) Matches(value string) bool {
+	switch c.Op {
+	case OpExists:
+		return true
+	case OpContains:
+		return strings.Contains(value, c.Operand)
+	}
+
+	if c.IsNumber {
+		n, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return false
+		}
+
+		return compare(c.Op, n, c.Number)
+	}
+
+	return compare(c.Op, value, c.Operand)
+}
+
+// compare compares the two values using the given operator
+func compare[T int64 | string](op Operator, a, b T) bool {
+	switch op {
+	case OpEqual:
+		return a == b
+	case OpLess:
+		return a < b
+	case OpLessEqual:
+		return a <= b
+	case OpGreater:
+		return a > b
+	case OpGreaterEqual:
+		return a >= b
+	default:
+		return false
+	}
+}
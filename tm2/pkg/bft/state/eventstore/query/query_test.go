@@ -0,0 +1,184 @@
+package query
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQuery_New(t *testing.T) {
+	t.Parallel()
+
+	testTable := []struct {
+		name       string
+		query      string
+		conditions []Condition
+		shouldErr  bool
+	}{
+		{
+			"empty query",
+			"",
+			nil,
+			true,
+		},
+		{
+			"missing operator",
+			"tx.height",
+			nil,
+			true,
+		},
+		{
+			"invalid operand",
+			"tx.height = abc",
+			nil,
+			true,
+		},
+		{
+			"string equality",
+			"tx.hash = 'ABCD'",
+			[]Condition{
+				{Key: "tx.hash", Op: OpEqual, Operand: "ABCD"},
+			},
+			false,
+		},
+		{
+			"numeric comparisons",
+			"tx.height>=5 AND tx.height < 10",
+			[]Condition{
+				{Key: "tx.height", Op: OpGreaterEqual, Operand: "5", Number: 5, IsNumber: true},
+				{Key: "tx.height", Op: OpLess, Operand: "10", Number: 10, IsNumber: true},
+			},
+			false,
+		},
+		{
+			"operator within operand",
+			"Transfer.memo < 'a=b'",
+			[]Condition{
+				{Key: "Transfer.memo", Op: OpLess, Operand: "a=b"},
+			},
+			false,
+		},
+		{
+			"escaped quotes",
+			`Transfer.memo = 'it\'s a \\ AND \'b\'' AND 'my key=' EXISTS`,
+			[]Condition{
+				{Key: "Transfer.memo", Op: OpEqual, Operand: `it's a \ AND 'b'`},
+				{Key: "my key=", Op: OpExists},
+			},
+			false,
+		},
+		{
+			"unterminated string",
+			"Transfer.memo = 'abc",
+			nil,
+			true,
+		},
+		{
+			"contains and exists",
+			"Transfer.memo CONTAINS 'hello' AND Transfer.to EXISTS",
+			[]Condition{
+				{Key: "Transfer.memo", Op: OpContains, Operand: "hello"},
+				{Key: "Transfer.to", Op: OpExists},
+			},
+			false,
+		},
+	}
+
+	for _, testCase := range testTable {
+		testCase := testCase
+
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			q, err := New(testCase.query)
+			if testCase.shouldErr {
+				assert.Error(t, err)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, testCase.conditions, q.Conditions())
+		})
+	}
+}
+
+func TestQuery_String(t *testing.T) {
+	t.Parallel()
+
+	testTable := []struct {
+		query     string
+		canonical string
+	}{
+		{
+			"tx.height>=05  AND   Transfer.to='g1'",
+			"tx.height >= 5 AND Transfer.to = 'g1'",
+		},
+		{
+			"  Transfer.memo CONTAINS 'it\\'s' AND Transfer.to EXISTS ",
+			"Transfer.memo CONTAINS 'it\\'s' AND Transfer.to EXISTS",
+		},
+		{
+			"'a b' = 'c'",
+			"'a b' = 'c'",
+		},
+	}
+
+	for _, testCase := range testTable {
+		testCase := testCase
+
+		t.Run(testCase.query, func(t *testing.T) {
+			t.Parallel()
+
+			q := MustNew(testCase.query)
+			assert.Equal(t, testCase.canonical, q.String())
+
+			// the canonical form is stable
+			assert.Equal(t, testCase.canonical, MustNew(q.String()).String())
+		})
+	}
+}
+
+func TestQuote(t *testing.T) {
+	t.Parallel()
+
+	value := `x' AND tm.event = 'Tx \`
+	q := MustNew("Transfer.memo = " + Quote(value))
+
+	require.Len(t, q.Conditions(), 1)
+	assert.Equal(t, value, q.Conditions()[0].Operand)
+}
+
+func TestQuery_Matches(t *testing.T) {
+	t.Parallel()
+
+	attrs := map[string][]string{
+		"tx.height":   {"7"},
+		"tx.signer":   {"g1a", "g1b"},
+		"Transfer.to": {"g1c"},
+	}
+
+	testTable := []struct {
+		query   string
+		matches bool
+	}{
+		{"tx.height = 7", true},
+		{"tx.height > 7", false},
+		{"tx.height <= 7 AND tx.signer = 'g1b'", true},
+		{"tx.signer = 'g1d'", false},
+		{"Transfer.to CONTAINS 'g1'", true},
+		{"Transfer.to EXISTS", true},
+		{"Transfer.from EXISTS", false},
+	}
+
+	for _, testCase := range testTable {
+		testCase := testCase
+
+		t.Run(testCase.query, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, testCase.matches, MustNew(testCase.query).Matches(attrs))
+		})
+	}
+}
@@ -1,6 +1,9 @@
 package eventstore
 
-import "github.com/gnolang/gno/tm2/pkg/bft/types"
+import (
+	"github.com/gnolang/gno/tm2/pkg/bft/state/eventstore/query"
+	"github.com/gnolang/gno/tm2/pkg/bft/types"
+)
 
 const (
 	StatusOn  = "on"
@@ -22,3 +25,18 @@ type TxEventStore interface {
 	// to the event store
 	Append(result types.TxResult) error
 }
+
+// TxIndexer is a TxEventStore that indexes the appended transactions,
+// so they can later be fetched and searched for
+type TxIndexer interface {
+	TxEventStore
+
+	// GetTx returns the transaction result with the given hash.
+	// Returns nil if the transaction is not found
+	GetTx(hash []byte) (*types.TxResult, error)
+
+	// Search returns at most limit transaction results matching
+	// the given query, ordered by height and index and skipping
+	// the first skip ones, along with the total number of matches
+	Search(q *query.Query, skip, limit int) ([]*types.TxResult, int, error)
+}
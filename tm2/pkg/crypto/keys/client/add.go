@@ -5,28 +5,28 @@ import (
 	"errors"
 	"flag"
 	"fmt"
-	"sort"
 
 	"github.com/gnolang/gno/tm2/pkg/commands"
 	"github.com/gnolang/gno/tm2/pkg/crypto"
 	"github.com/gnolang/gno/tm2/pkg/crypto/bip39"
 	"github.com/gnolang/gno/tm2/pkg/crypto/keys"
-	"github.com/gnolang/gno/tm2/pkg/crypto/multisig"
 )
 
 type AddCfg struct {
 	RootCfg *BaseCfg
 
+	PublicKey string
+	UseLedger bool
+	Recover   bool
+	NoBackup  bool
+	DryRun    bool
+	Account   uint64
+	Index     uint64
+
+	// XXX(deprecated): use add multisig instead
 	Multisig          commands.StringArr
 	MultisigThreshold int
 	NoSort            bool
-	PublicKey         string
-	UseLedger         bool
-	Recover           bool
-	NoBackup          bool
-	DryRun            bool
-	Account           uint64
-	Index             uint64
 }
 
 func NewAddCmd(rootCfg *BaseCfg, io commands.IO) *commands.Command {
@@ -34,7 +34,7 @@ func NewAddCmd(rootCfg *BaseCfg, io commands.IO) *commands.Command {
 		RootCfg: rootCfg,
 	}
 
-	return commands.NewCommand(
+	cmd := commands.NewCommand(
 		commands.Metadata{
 			Name:       "add",
 			ShortUsage: "add [flags] <key-name>",
@@ -45,29 +45,15 @@ func NewAddCmd(rootCfg *BaseCfg, io commands.IO) *commands.Command {
 			return execAdd(cfg, args, io)
 		},
 	)
-}
-
-func (c *AddCfg) RegisterFlags(fs *flag.FlagSet) {
-	fs.Var(
-		&c.Multisig,
-		"multisig",
-		"construct and store a multisig public key (implies --pubkey)",
-	)
 
-	fs.IntVar(
-		&c.MultisigThreshold,
-		"threshold",
-		1,
-		"K out of N required signatures. For use in conjunction with --multisig",
+	cmd.AddSubCommands(
+		NewAddMultisigCmd(cfg, io),
 	)
 
-	fs.BoolVar(
-		&c.NoSort,
-		"nosort",
-		false,
-		"keys passed to --multisig are taken in the order they're supplied",
-	)
+	return cmd
+}
 
+func (c *AddCfg) RegisterFlags(fs *flag.FlagSet) {
 	fs.StringVar(
 		&c.PublicKey,
 		"pubkey",
@@ -116,6 +102,32 @@ func (c *AddCfg) RegisterFlags(fs *flag.FlagSet) {
 		0,
 		"address index number for HD derivation",
 	)
+
+	// The add flags are also registered with the add multisig subcommand,
+	// which defines its own multisig flags.
+	if fs.Lookup("multisig") != nil {
+		return
+	}
+
+	fs.Var(
+		&c.Multisig,
+		"multisig",
+		"deprecated: use add multisig instead - construct and store a multisig public key",
+	)
+
+	fs.IntVar(
+		&c.MultisigThreshold,
+		"threshold",
+		1,
+		"deprecated: use add multisig instead - K out of N required signatures",
+	)
+
+	fs.BoolVar(
+		&c.NoSort,
+		"nosort",
+		false,
+		"deprecated: use add multisig instead - keys passed to --multisig are taken in the order they're supplied",
+	)
 }
 
 // DryRunKeyPass contains the default key password for genesis transactions
@@ -142,6 +154,16 @@ func execAdd(cfg *AddCfg, args []string, io commands.IO) error {
 		return flag.ErrHelp
 	}
 
+	if len(cfg.Multisig) != 0 {
+		io.ErrPrintln("Warning: add -multisig is deprecated, use add multisig instead")
+		return execAddMultisig(&AddMultisigCfg{
+			RootCfg:           cfg,
+			Multisig:          cfg.Multisig,
+			MultisigThreshold: cfg.MultisigThreshold,
+			NoSort:            cfg.NoSort,
+		}, args, io)
+	}
+
 	name := args[0]
 	showMnemonic := !cfg.NoBackup
 
@@ -167,39 +189,6 @@ func execAdd(cfg *AddCfg, args []string, io commands.IO) error {
 			}
 		}
 
-		multisigKeys := cfg.Multisig
-		if len(multisigKeys) != 0 {
-			var pks []crypto.PubKey
-
-			multisigThreshold := cfg.MultisigThreshold
-			if err := keys.ValidateMultisigThreshold(multisigThreshold, len(multisigKeys)); err != nil {
-				return err
-			}
-
-			for _, keyname := range multisigKeys {
-				k, err := kb.GetByName(keyname)
-				if err != nil {
-					return err
-				}
-				pks = append(pks, k.GetPubKey())
-			}
-
-			// Handle --nosort
-			if !cfg.NoSort {
-				sort.Slice(pks, func(i, j int) bool {
-					return pks[i].Address().Compare(pks[j].Address()) < 0
-				})
-			}
-
-			pk := multisig.NewPubKeyMultisigThreshold(multisigThreshold, pks)
-			if _, err := kb.CreateMulti(name, pk); err != nil {
-				return err
-			}
-
-			io.Printfln("Key %q saved to disk.\n", name)
-			return nil
-		}
-
 		// ask for a password when generating a local key
 		if cfg.PublicKey == "" && !cfg.UseLedger {
 			encryptPassword, err = io.GetCheckPassword(
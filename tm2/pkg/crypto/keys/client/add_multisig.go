@@ -0,0 +1,135 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"sort"
+
+	"github.com/gnolang/gno/tm2/pkg/commands"
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+	"github.com/gnolang/gno/tm2/pkg/crypto/keys"
+	"github.com/gnolang/gno/tm2/pkg/crypto/multisig"
+)
+
+type AddMultisigCfg struct {
+	RootCfg *AddCfg
+
+	Multisig          commands.StringArr
+	MultisigThreshold int
+	NoSort            bool
+}
+
+func NewAddMultisigCmd(rootCfg *AddCfg, io commands.IO) *commands.Command {
+	cfg := &AddMultisigCfg{
+		RootCfg: rootCfg,
+	}
+
+	return commands.NewCommand(
+		commands.Metadata{
+			Name:       "multisig",
+			ShortUsage: "add multisig [flags] <key-name>",
+			ShortHelp:  "adds a multisig key reference to the keybase",
+			LongHelp: "Adds a reference to a K out of N multisig key, built from the public keys " +
+				"of its members, to the keybase. The members are given as names or addresses of " +
+				"keys in the keybase, or as bech32 public keys.",
+		},
+		cfg,
+		func(_ context.Context, args []string) error {
+			return execAddMultisig(cfg, args, io)
+		},
+	)
+}
+
+func (c *AddMultisigCfg) RegisterFlags(fs *flag.FlagSet) {
+	fs.Var(
+		&c.Multisig,
+		"multisig",
+		"key name, address or bech32 public key of a member of the multisig",
+	)
+
+	fs.IntVar(
+		&c.MultisigThreshold,
+		"threshold",
+		1,
+		"K out of N required signatures",
+	)
+
+	fs.BoolVar(
+		&c.NoSort,
+		"nosort",
+		false,
+		"members passed to --multisig are taken in the order they're supplied",
+	)
+}
+
+func execAddMultisig(cfg *AddMultisigCfg, args []string, io commands.IO) error {
+	if len(args) != 1 {
+		return flag.ErrHelp
+	}
+
+	name := args[0]
+
+	if err := keys.ValidateMultisigThreshold(cfg.MultisigThreshold, len(cfg.Multisig)); err != nil {
+		return err
+	}
+
+	kb, err := keys.NewKeyBaseFromDir(cfg.RootCfg.RootCfg.Home)
+	if err != nil {
+		return err
+	}
+
+	if has, err := kb.HasByName(name); err == nil && has {
+		// account exists, ask for user confirmation
+		response, err := io.GetConfirmation(fmt.Sprintf("Override the existing name %s", name))
+		if err != nil {
+			return err
+		}
+		if !response {
+			return errors.New("aborted")
+		}
+	}
+
+	pks := make([]crypto.PubKey, 0, len(cfg.Multisig))
+	for _, member := range cfg.Multisig {
+		pk, err := getMemberPubKey(kb, member)
+		if err != nil {
+			return err
+		}
+		pks = append(pks, pk)
+	}
+
+	// Handle --nosort
+	if !cfg.NoSort {
+		sort.Slice(pks, func(i, j int) bool {
+			return pks[i].Address().Compare(pks[j].Address()) < 0
+		})
+	}
+
+	pk := multisig.NewPubKeyMultisigThreshold(cfg.MultisigThreshold, pks)
+	info, err := kb.CreateMulti(name, pk)
+	if err != nil {
+		return err
+	}
+
+	io.Printfln("Key %q saved to disk.", name)
+	printNewInfo(info, io)
+
+	return nil
+}
+
+// getMemberPubKey returns the public key of a multisig member, given as the
+// name or address of a key in the keybase, or as a bech32 public key.
+func getMemberPubKey(kb keys.Keybase, member string) (crypto.PubKey, error) {
+	if pk, err := crypto.PubKeyFromBech32(member); err == nil {
+		return pk, nil
+	}
+
+	info, err := kb.GetByNameOrAddress(member)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get multisig member %q: %w", member, err)
+	}
+
+	return info.GetPubKey(), nil
+}
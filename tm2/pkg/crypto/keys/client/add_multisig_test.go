@@ -0,0 +1,101 @@
+package client
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gnolang/gno/tm2/pkg/commands"
+	"github.com/gnolang/gno/tm2/pkg/crypto/keys"
+	"github.com/gnolang/gno/tm2/pkg/crypto/multisig"
+	"github.com/gnolang/gno/tm2/pkg/testutils"
+)
+
+func Test_execAddMultisig(t *testing.T) {
+	t.Parallel()
+
+	kbHome, kbCleanUp := testutils.NewTestCaseDir(t)
+	assert.NotNil(t, kbHome)
+	defer kbCleanUp()
+
+	kb, err := keys.NewKeyBaseFromDir(kbHome)
+	require.NoError(t, err)
+	member1, err := kb.CreateAccount("member1", testMnemonic, "", "", 0, 0)
+	require.NoError(t, err)
+	member2, err := kb.CreateAccount("member2", testMnemonic, "", "", 0, 1)
+	require.NoError(t, err)
+
+	cfg := &AddMultisigCfg{
+		RootCfg: &AddCfg{
+			RootCfg: &BaseCfg{
+				BaseOptions: BaseOptions{
+					Home: kbHome,
+				},
+			},
+		},
+		// members by name, address and bech32 public key
+		Multisig:          commands.StringArr{"member1", member2.GetAddress().String(), test2PubkeyBech32},
+		MultisigThreshold: 2,
+	}
+
+	io := commands.NewTestIO()
+	require.NoError(t, execAddMultisig(cfg, []string{"multi"}, io))
+
+	info, err := kb.GetByName("multi")
+	require.NoError(t, err)
+	assert.Equal(t, keys.TypeMulti, info.GetType())
+
+	pub, ok := info.GetPubKey().(multisig.PubKeyMultisigThreshold)
+	require.True(t, ok)
+	assert.EqualValues(t, 2, pub.K)
+	require.Len(t, pub.PubKeys, 3)
+	assert.Contains(t, pub.PubKeys, member1.GetPubKey())
+	assert.Contains(t, pub.PubKeys, member2.GetPubKey())
+
+	// invalid threshold
+	cfg.MultisigThreshold = 4
+	assert.Error(t, execAddMultisig(cfg, []string{"multi2"}, io))
+
+	// unknown member
+	cfg.MultisigThreshold = 1
+	cfg.Multisig = commands.StringArr{"unknown"}
+	assert.Error(t, execAddMultisig(cfg, []string{"multi2"}, io))
+}
+
+func Test_execAddDeprecatedMultisig(t *testing.T) {
+	t.Parallel()
+
+	kbHome, kbCleanUp := testutils.NewTestCaseDir(t)
+	assert.NotNil(t, kbHome)
+	defer kbCleanUp()
+
+	kb, err := keys.NewKeyBaseFromDir(kbHome)
+	require.NoError(t, err)
+	_, err = kb.CreateAccount("member1", testMnemonic, "", "", 0, 0)
+	require.NoError(t, err)
+	_, err = kb.CreateAccount("member2", testMnemonic, "", "", 0, 1)
+	require.NoError(t, err)
+
+	cfg := &AddCfg{
+		RootCfg: &BaseCfg{
+			BaseOptions: BaseOptions{
+				Home: kbHome,
+			},
+		},
+		Multisig:          commands.StringArr{"member1", "member2"},
+		MultisigThreshold: 2,
+	}
+
+	io := commands.NewTestIO()
+	require.NoError(t, execAdd(cfg, []string{"multi"}, io))
+
+	info, err := kb.GetByName("multi")
+	require.NoError(t, err)
+	assert.Equal(t, keys.TypeMulti, info.GetType())
+
+	pub, ok := info.GetPubKey().(multisig.PubKeyMultisigThreshold)
+	require.True(t, ok)
+	assert.EqualValues(t, 2, pub.K)
+	assert.Len(t, pub.PubKeys, 2)
+}
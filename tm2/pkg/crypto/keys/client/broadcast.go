@@ -36,7 +36,7 @@ func NewBroadcastCmd(rootCfg *BaseCfg, io commands.IO) *commands.Command {
 		},
 		cfg,
 		func(_ context.Context, args []string) error {
-			return execBroadcast(cfg, args, commands.NewDefaultIO())
+			return execBroadcast(cfg, args, io)
 		},
 	)
 }
@@ -119,6 +119,9 @@ func SimulateTx(cli client.ABCIClient, tx []byte) (*ctypes.ResultBroadcastTxComm
 	if err != nil {
 		return nil, errors.Wrap(err, "simulate tx")
 	}
+	if bres.Response.Error != nil {
+		return nil, errors.Wrap(bres.Response.Error, "simulate tx failed: log:%s", bres.Response.Log)
+	}
 
 	var result abci.ResponseDeliverTx
 	err = amino.Unmarshal(bres.Response.Value, &result)
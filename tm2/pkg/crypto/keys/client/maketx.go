@@ -3,22 +3,37 @@ package client
 import (
 	"flag"
 	"fmt"
+	"math"
+	"strconv"
 
 	"github.com/gnolang/gno/tm2/pkg/amino"
+	"github.com/gnolang/gno/tm2/pkg/bft/rpc/client"
 	types "github.com/gnolang/gno/tm2/pkg/bft/rpc/core/types"
 	"github.com/gnolang/gno/tm2/pkg/commands"
 	"github.com/gnolang/gno/tm2/pkg/crypto/keys"
 	"github.com/gnolang/gno/tm2/pkg/errors"
+	"github.com/gnolang/gno/tm2/pkg/sdk/auth"
 	"github.com/gnolang/gno/tm2/pkg/std"
 )
 
+// DefaultGasAdjustment is the default multiplier of the simulated gas used,
+// when the gas wanted is estimated.
+const DefaultGasAdjustment = 1.3
+
+// ErrNoGasFee is returned when estimating the fee of a tx without gas fee, on
+// a chain without min gas prices.
+var ErrNoGasFee = errors.New("gas-fee not specified, and no min gas prices set on chain")
+
 type MakeTxCfg struct {
 	RootCfg *BaseCfg
 
-	GasWanted int64
-	GasFee    string
-	Memo      string
+	GasWanted     int64
+	GasAuto       bool // set by -gas-wanted auto
+	GasAdjustment float64
+	GasFee        string
+	Memo          string
 
+	Simulate  bool
 	Broadcast bool
 	ChainID   string
 }
@@ -40,24 +55,43 @@ func NewMakeTxCmd(rootCfg *BaseCfg, io commands.IO) *commands.Command {
 
 	cmd.AddSubCommands(
 		NewMakeSendCmd(cfg, io),
+		NewMakeSetParamCmd(cfg, io),
+		NewMakeWithdrawCmd(cfg, io),
 	)
 
 	return cmd
 }
 
 func (c *MakeTxCfg) RegisterFlags(fs *flag.FlagSet) {
-	fs.Int64Var(
-		&c.GasWanted,
+	fs.Func(
 		"gas-wanted",
-		0,
-		"gas requested for tx",
+		"gas requested for tx, or \"auto\" to estimate it by simulating the tx",
+		func(s string) error {
+			if s == "auto" {
+				c.GasAuto = true
+				return nil
+			}
+			gas, err := strconv.ParseInt(s, 10, 64)
+			if err != nil {
+				return errors.New("invalid gas-wanted %q", s)
+			}
+			c.GasWanted = gas
+			return nil
+		},
+	)
+
+	fs.Float64Var(
+		&c.GasAdjustment,
+		"gas-adjustment",
+		DefaultGasAdjustment,
+		"multiplier of the simulated gas used (only useful if -gas-wanted auto)",
 	)
 
 	fs.StringVar(
 		&c.GasFee,
 		"gas-fee",
 		"",
-		"gas payment fee",
+		"gas payment fee (derived from the min gas prices if -gas-wanted auto)",
 	)
 
 	fs.StringVar(
@@ -67,6 +101,13 @@ func (c *MakeTxCfg) RegisterFlags(fs *flag.FlagSet) {
 		"any descriptive text",
 	)
 
+	fs.BoolVar(
+		&c.Simulate,
+		"simulate",
+		false,
+		"simulate the tx and print the estimated gas and fee, without signing it",
+	)
+
 	fs.BoolVar(
 		&c.Broadcast,
 		"broadcast",
@@ -82,6 +123,150 @@ func (c *MakeTxCfg) RegisterFlags(fs *flag.FlagSet) {
 	)
 }
 
+// ParseFee returns the fee of the tx from the gas-wanted and gas-fee flags.
+// When the gas is to be estimated, unset values are left to EstimateGas.
+func (c *MakeTxCfg) ParseFee() (std.Fee, error) {
+	estimate := c.GasAuto || c.Simulate
+	if c.GasWanted == 0 && !estimate {
+		return std.Fee{}, errors.New("gas-wanted not specified")
+	}
+	if c.GasFee == "" {
+		if !estimate {
+			return std.Fee{}, errors.New("gas-fee not specified")
+		}
+		return std.Fee{GasWanted: c.GasWanted}, nil
+	}
+
+	gasfee, err := std.ParseCoin(c.GasFee)
+	if err != nil {
+		return std.Fee{}, errors.Wrap(err, "parsing gas fee coin")
+	}
+	return std.NewFee(c.GasWanted, gasfee), nil
+}
+
+// ExecMakeTx estimates the gas of tx if requested, then either prints the
+// estimation, signs and broadcasts tx, or prints tx.
+func ExecMakeTx(cfg *MakeTxCfg, args []string, tx std.Tx, io commands.IO) error {
+	if cfg.GasAuto || cfg.Simulate {
+		gasUsed, fee, err := EstimateGas(cfg, args[0], tx)
+		if err != nil {
+			return err
+		}
+		if cfg.Simulate {
+			io.Println("GAS USED:  ", gasUsed)
+			io.Println("GAS WANTED:", fee.GasWanted)
+			io.Println("GAS FEE:   ", fee.GasFee)
+			return nil
+		}
+		tx.Fee.GasWanted = fee.GasWanted
+		if cfg.GasFee == "" {
+			tx.Fee.GasFee = fee.GasFee
+		}
+	}
+
+	if cfg.Broadcast {
+		return ExecSignAndBroadcast(cfg, args, tx, io)
+	}
+	io.Println(string(amino.MustMarshalJSON(tx)))
+	return nil
+}
+
+// EstimateGas simulates tx as signed by the key nameOrBech32, and returns the
+// gas it used along with a suggested fee, as EstimateFee does.
+func EstimateGas(cfg *MakeTxCfg, nameOrBech32 string, tx std.Tx) (int64, std.Fee, error) {
+	kb, err := keys.NewKeyBaseFromDir(cfg.RootCfg.Home)
+	if err != nil {
+		return 0, std.Fee{}, err
+	}
+	info, err := kb.GetByNameOrAddress(nameOrBech32)
+	if err != nil {
+		return 0, std.Fee{}, err
+	}
+
+	gasPrices, err := queryMinGasPrices(cfg.RootCfg)
+	if err != nil {
+		return 0, std.Fee{}, err
+	}
+
+	cli := client.NewHTTP(cfg.RootCfg.Remote, "/websocket")
+	return EstimateFee(cli, tx, info, gasPrices, cfg.GasAdjustment)
+}
+
+// EstimateFee simulates tx as signed by the key signer, and returns the gas
+// it used along with a suggested fee: the gas used times adjustment (or
+// DefaultGasAdjustment if not positive), and, unless the gas fee of tx is set,
+// the fee paying for it at the first of gasPrices.
+func EstimateFee(
+	cli client.ABCIClient,
+	tx std.Tx,
+	signer keys.Info,
+	gasPrices []std.GasPrice,
+	adjustment float64,
+) (int64, std.Fee, error) {
+	fee := tx.Fee
+	setFee := fee.GasFee.Denom == ""
+	if setFee {
+		if len(gasPrices) == 0 {
+			return 0, std.Fee{}, ErrNoGasFee
+		}
+		// Simulate paying a fee, the amount does not matter.
+		fee.GasFee = std.NewCoin(gasPrices[0].Price.Denom, 1)
+	}
+
+	// The simulated tx only needs the pubkey of the signer.
+	signers := tx.GetSigners()
+	sigs := make([]std.Signature, len(signers))
+	for i, addr := range signers {
+		if addr == signer.GetAddress() {
+			sigs[i].PubKey = signer.GetPubKey()
+		}
+	}
+	simTx := std.NewTx(tx.Msgs, fee, sigs, tx.Memo)
+	bz, err := amino.Marshal(simTx)
+	if err != nil {
+		return 0, std.Fee{}, errors.Wrap(err, "marshaling tx binary bytes")
+	}
+
+	res, err := SimulateTx(cli, bz)
+	if err != nil {
+		return 0, std.Fee{}, err
+	}
+	if res.DeliverTx.IsErr() {
+		return 0, std.Fee{}, errors.Wrap(res.DeliverTx.Error, "simulate transaction failed: log:%s", res.DeliverTx.Log)
+	}
+
+	gasUsed := res.DeliverTx.GasUsed
+	if adjustment <= 0 {
+		adjustment = DefaultGasAdjustment
+	}
+	fee.GasWanted = int64(math.Ceil(float64(gasUsed) * adjustment))
+	if setFee {
+		fee.GasFee = gasPrices[0].Fee(fee.GasWanted)
+	}
+	return gasUsed, fee, nil
+}
+
+// queryMinGasPrices returns the min gas prices set on chain, if any.
+func queryMinGasPrices(baseCfg *BaseCfg) ([]std.GasPrice, error) {
+	qres, err := QueryHandler(&QueryCfg{
+		RootCfg: baseCfg,
+		Path:    "params/" + auth.MinGasPricesKey,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "query min gas prices")
+	}
+	if qres.Response.Error != nil || len(qres.Response.Data) == 0 {
+		// the chain may not have on-chain params.
+		return nil, nil
+	}
+
+	var gasPrices []std.GasPrice
+	if err := amino.UnmarshalJSON(qres.Response.Data, &gasPrices); err != nil {
+		return nil, errors.Wrap(err, "unmarshaling min gas prices")
+	}
+	return gasPrices, nil
+}
+
 func SignAndBroadcastHandler(
 	cfg *MakeTxCfg,
 	nameOrBech32 string,
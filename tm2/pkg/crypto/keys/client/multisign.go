@@ -0,0 +1,189 @@
+package client
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/gnolang/gno/tm2/pkg/amino"
+	"github.com/gnolang/gno/tm2/pkg/commands"
+	"github.com/gnolang/gno/tm2/pkg/crypto/keys"
+	"github.com/gnolang/gno/tm2/pkg/crypto/multisig"
+	"github.com/gnolang/gno/tm2/pkg/errors"
+	"github.com/gnolang/gno/tm2/pkg/std"
+)
+
+type MultisignCfg struct {
+	RootCfg *BaseCfg
+
+	TxPath        string
+	ChainID       string
+	AccountNumber uint64
+	Sequence      uint64
+	Signatures    commands.StringArr
+	NameOrBech32  string
+	TxJSON        []byte
+}
+
+func NewMultisignCmd(rootCfg *BaseCfg, io commands.IO) *commands.Command {
+	cfg := &MultisignCfg{
+		RootCfg: rootCfg,
+	}
+
+	return commands.NewCommand(
+		commands.Metadata{
+			Name:       "multisign",
+			ShortUsage: "multisign [flags] <multisig key-name or address>",
+			ShortHelp:  "combines partial signatures into a multisig signature of the tx",
+			LongHelp: "Combines the partial signatures of the tx produced by its members with " +
+				"`sign -multisig`, and outputs the tx signed by the multisig key.",
+		},
+		cfg,
+		func(_ context.Context, args []string) error {
+			return execMultisign(cfg, args, io)
+		},
+	)
+}
+
+func (c *MultisignCfg) RegisterFlags(fs *flag.FlagSet) {
+	fs.StringVar(
+		&c.TxPath,
+		"txpath",
+		"-",
+		"path to file of tx to sign",
+	)
+
+	fs.StringVar(
+		&c.ChainID,
+		"chainid",
+		"dev",
+		"chainid the tx was signed for",
+	)
+
+	fs.Uint64Var(
+		&c.AccountNumber,
+		"number",
+		0,
+		"account number the tx was signed with (required)",
+	)
+
+	fs.Uint64Var(
+		&c.Sequence,
+		"sequence",
+		0,
+		"sequence the tx was signed with (required)",
+	)
+
+	fs.Var(
+		&c.Signatures,
+		"signature",
+		"path to file of a partial signature of the tx",
+	)
+}
+
+func execMultisign(cfg *MultisignCfg, args []string, io commands.IO) error {
+	var err error
+
+	if len(args) != 1 {
+		return flag.ErrHelp
+	}
+
+	cfg.NameOrBech32 = args[0]
+
+	// read tx to sign
+	txpath := cfg.TxPath
+	if txpath == "-" { // from stdin.
+		txjsonstr, err := io.GetString(
+			"Enter tx to sign, terminated by a newline.",
+		)
+		if err != nil {
+			return err
+		}
+		cfg.TxJSON = []byte(txjsonstr)
+	} else { // from file
+		cfg.TxJSON, err = os.ReadFile(txpath)
+		if err != nil {
+			return err
+		}
+	}
+
+	signedTx, err := MultisignHandler(cfg)
+	if err != nil {
+		return err
+	}
+
+	signedJSON, err := amino.MarshalJSON(signedTx)
+	if err != nil {
+		return err
+	}
+	io.Println(string(signedJSON))
+
+	return nil
+}
+
+// MultisignHandler combines the partial signatures of the tx by the members of
+// the multisig key into its signature, and returns the signed tx.
+func MultisignHandler(cfg *MultisignCfg) (*std.Tx, error) {
+	kb, err := keys.NewKeyBaseFromDir(cfg.RootCfg.Home)
+	if err != nil {
+		return nil, err
+	}
+
+	info, err := kb.GetByNameOrAddress(cfg.NameOrBech32)
+	if err != nil {
+		return nil, err
+	}
+	multiPub, ok := info.GetPubKey().(multisig.PubKeyMultisigThreshold)
+	if !ok {
+		return nil, fmt.Errorf("%s is not a multisig key", cfg.NameOrBech32)
+	}
+
+	tx, err := decodeTxToSign(cfg.TxJSON)
+	if err != nil {
+		return nil, err
+	}
+	signbz := tx.GetSignBytes(cfg.ChainID, cfg.AccountNumber, cfg.Sequence)
+
+	// combine the partial signatures.
+	mSig := multisig.NewMultisig(len(multiPub.PubKeys))
+	for _, path := range cfg.Signatures {
+		sigjson, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+
+		var sig std.Signature
+		if err := amino.UnmarshalJSON(sigjson, &sig); err != nil {
+			return nil, errors.Wrap(err, "unable to decode signature %s", path)
+		}
+		if sig.PubKey == nil || !sig.PubKey.VerifyBytes(signbz, sig.Signature) {
+			return nil, fmt.Errorf("invalid signature %s; verify correct account, sequence, and chain-id", path)
+		}
+		if err := mSig.AddSignatureFromPubKey(sig.Signature, sig.PubKey, multiPub.PubKeys); err != nil {
+			return nil, err
+		}
+	}
+	if len(mSig.Sigs) < int(multiPub.K) {
+		return nil, fmt.Errorf("got %d signatures, %d required", len(mSig.Sigs), multiPub.K)
+	}
+
+	addr := multiPub.Address()
+	found := false
+	signers := tx.GetSigners()
+	for i := range tx.Signatures {
+		// override signature for matching slot.
+		if signers[i] == addr {
+			found = true
+			tx.Signatures[i] = std.Signature{
+				PubKey:    multiPub,
+				Signature: mSig.Marshal(),
+			}
+		}
+	}
+	if !found {
+		return nil, fmt.Errorf("addr %v (%s) not in signer set", addr, cfg.NameOrBech32)
+	}
+
+	return tx, nil
+}
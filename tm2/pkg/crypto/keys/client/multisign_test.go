@@ -0,0 +1,130 @@
+package client
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gnolang/gno/tm2/pkg/amino"
+	"github.com/gnolang/gno/tm2/pkg/commands"
+	"github.com/gnolang/gno/tm2/pkg/crypto/keys"
+	sdkutils "github.com/gnolang/gno/tm2/pkg/sdk/testutils"
+	"github.com/gnolang/gno/tm2/pkg/std"
+	"github.com/gnolang/gno/tm2/pkg/testutils"
+)
+
+func Test_execMultisign(t *testing.T) {
+	t.Parallel()
+
+	kbHome, kbCleanUp := testutils.NewTestCaseDir(t)
+	assert.NotNil(t, kbHome)
+	defer kbCleanUp()
+
+	baseCfg := &BaseCfg{
+		BaseOptions: BaseOptions{
+			Home:                  kbHome,
+			InsecurePasswordStdin: true,
+		},
+	}
+	encPassword := "12345678"
+
+	// add the members and the 2-of-3 multisig key to the keybase.
+	kb, err := keys.NewKeyBaseFromDir(kbHome)
+	require.NoError(t, err)
+	for i, name := range []string{"member1", "member2", "member3", "outsider"} {
+		_, err := kb.CreateAccount(name, testMnemonic, "", encPassword, 0, uint32(i))
+		require.NoError(t, err)
+	}
+	require.NoError(t, execAddMultisig(&AddMultisigCfg{
+		RootCfg:           &AddCfg{RootCfg: baseCfg},
+		Multisig:          commands.StringArr{"member1", "member2", "member3"},
+		MultisigThreshold: 2,
+	}, []string{"multi"}, commands.NewTestIO()))
+	multi, err := kb.GetByName("multi")
+	require.NoError(t, err)
+
+	// create a tx to sign by the multisig key.
+	msg := sdkutils.NewTestMsg(multi.GetAddress())
+	fee := std.NewFee(1, std.NewCoin("ugnot", 1000000))
+	tx := std.NewTx([]std.Msg{msg}, fee, nil, "")
+	txPath := filepath.Join(kbHome, "tx.json")
+	require.NoError(t, os.WriteFile(txPath, amino.MustMarshalJSON(tx), 0o644))
+
+	signPart := func(member string) (string, error) {
+		var out bytes.Buffer
+		io := commands.NewTestIO()
+		io.SetIn(strings.NewReader(encPassword + "\n"))
+		io.SetOut(commands.WriteNopCloser(&out))
+		err := execSign(&SignCfg{
+			RootCfg:       baseCfg,
+			TxPath:        txPath,
+			ChainID:       "dev",
+			AccountNumber: 1,
+			Sequence:      2,
+			Multisig:      "multi",
+		}, []string{member}, io)
+		if err != nil {
+			return "", err
+		}
+		sigPath := filepath.Join(kbHome, member+".sig.json")
+		return sigPath, os.WriteFile(sigPath, out.Bytes(), 0o644)
+	}
+
+	multisign := func(sigPaths ...string) (*std.Tx, error) {
+		var out bytes.Buffer
+		io := commands.NewTestIO()
+		io.SetOut(commands.WriteNopCloser(&out))
+		err := execMultisign(&MultisignCfg{
+			RootCfg:       baseCfg,
+			TxPath:        txPath,
+			ChainID:       "dev",
+			AccountNumber: 1,
+			Sequence:      2,
+			Signatures:    sigPaths,
+		}, []string{"multi"}, io)
+		if err != nil {
+			return nil, err
+		}
+		var signedTx std.Tx
+		return &signedTx, amino.UnmarshalJSON(out.Bytes(), &signedTx)
+	}
+
+	// only members can produce a partial signature.
+	_, err = signPart("outsider")
+	assert.ErrorContains(t, err, "not a member")
+
+	sig1, err := signPart("member1")
+	require.NoError(t, err)
+	sig3, err := signPart("member3")
+	require.NoError(t, err)
+
+	// the threshold must be met.
+	_, err = multisign(sig1)
+	assert.ErrorContains(t, err, "got 1 signatures, 2 required")
+
+	signedTx, err := multisign(sig1, sig3)
+	require.NoError(t, err)
+	require.Len(t, signedTx.Signatures, 1)
+	assert.Equal(t, multi.GetPubKey(), signedTx.Signatures[0].PubKey)
+	signbz := signedTx.GetSignBytes("dev", 1, 2)
+	assert.True(t, multi.GetPubKey().VerifyBytes(signbz, signedTx.Signatures[0].Signature))
+
+	// partial signatures must be of the same sign bytes.
+	txJSON, err := os.ReadFile(txPath)
+	require.NoError(t, err)
+	_, err = MultisignHandler(&MultisignCfg{
+		RootCfg:       baseCfg,
+		ChainID:       "dev",
+		AccountNumber: 1,
+		Sequence:      3,
+		Signatures:    commands.StringArr{sig1, sig3},
+		NameOrBech32:  "multi",
+		TxJSON:        txJSON,
+	})
+	assert.ErrorContains(t, err, "invalid signature")
+}
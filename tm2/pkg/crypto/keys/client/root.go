@@ -48,6 +48,7 @@ func NewRootCmdWithBaseConfig(io commands.IO, base BaseOptions) *commands.Comman
 		NewImportCmd(cfg, io),
 		NewListCmd(cfg, io),
 		NewSignCmd(cfg, io),
+		NewMultisignCmd(cfg, io),
 		NewVerifyCmd(cfg, io),
 		NewQueryCmd(cfg, io),
 		NewBroadcastCmd(cfg, io),
@@ -0,0 +1,95 @@
+package client
+
+import (
+	"context"
+	"flag"
+
+	"github.com/gnolang/gno/tm2/pkg/commands"
+	"github.com/gnolang/gno/tm2/pkg/crypto/keys"
+	"github.com/gnolang/gno/tm2/pkg/errors"
+	"github.com/gnolang/gno/tm2/pkg/sdk/params"
+	"github.com/gnolang/gno/tm2/pkg/std"
+)
+
+type MakeSetParamCfg struct {
+	RootCfg *MakeTxCfg
+
+	Key   string
+	Value string
+}
+
+func NewMakeSetParamCmd(rootCfg *MakeTxCfg, io commands.IO) *commands.Command {
+	cfg := &MakeSetParamCfg{
+		RootCfg: rootCfg,
+	}
+
+	return commands.NewCommand(
+		commands.Metadata{
+			Name:       "setparam",
+			ShortUsage: "setparam [flags] <key-name or address>",
+			ShortHelp:  "updates an on-chain param, as the params authority",
+		},
+		cfg,
+		func(_ context.Context, args []string) error {
+			return execMakeSetParam(cfg, args, io)
+		},
+	)
+}
+
+func (c *MakeSetParamCfg) RegisterFlags(fs *flag.FlagSet) {
+	fs.StringVar(
+		&c.Key,
+		"key",
+		"",
+		"param key (e.g. auth.params)",
+	)
+
+	fs.StringVar(
+		&c.Value,
+		"value",
+		"",
+		"param value, JSON encoded",
+	)
+}
+
+func execMakeSetParam(cfg *MakeSetParamCfg, args []string, io commands.IO) error {
+	if len(args) != 1 {
+		return flag.ErrHelp
+	}
+
+	if cfg.Key == "" {
+		return errors.New("key must be specified")
+	}
+	if cfg.Value == "" {
+		return errors.New("value must be specified")
+	}
+
+	// read account pubkey.
+	nameOrBech32 := args[0]
+	kb, err := keys.NewKeyBaseFromDir(cfg.RootCfg.RootCfg.Home)
+	if err != nil {
+		return err
+	}
+	info, err := kb.GetByNameOrAddress(nameOrBech32)
+	if err != nil {
+		return err
+	}
+	caller := info.GetAddress()
+
+	// parse gas wanted & fee.
+	fee, err := cfg.RootCfg.ParseFee()
+	if err != nil {
+		return err
+	}
+
+	// construct msg & tx and marshal.
+	msg := params.NewMsgSetParam(caller, cfg.Key, cfg.Value)
+	tx := std.Tx{
+		Msgs:       []std.Msg{msg},
+		Fee:        fee,
+		Signatures: nil,
+		Memo:       cfg.RootCfg.Memo,
+	}
+
+	return ExecMakeTx(cfg.RootCfg, args, tx, io)
+}
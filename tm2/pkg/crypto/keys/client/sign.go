@@ -5,10 +5,12 @@ import (
 	"flag"
 	"fmt"
 	"os"
+	"slices"
 
 	"github.com/gnolang/gno/tm2/pkg/amino"
 	"github.com/gnolang/gno/tm2/pkg/commands"
 	"github.com/gnolang/gno/tm2/pkg/crypto/keys"
+	"github.com/gnolang/gno/tm2/pkg/crypto/multisig"
 	"github.com/gnolang/gno/tm2/pkg/errors"
 	"github.com/gnolang/gno/tm2/pkg/std"
 )
@@ -21,6 +23,7 @@ type SignCfg struct {
 	AccountNumber uint64
 	Sequence      uint64
 	ShowSignBytes bool
+	Multisig      string
 	NameOrBech32  string
 	TxJSON        []byte
 	Pass          string
@@ -79,6 +82,13 @@ func (c *SignCfg) RegisterFlags(fs *flag.FlagSet) {
 		false,
 		"show sign bytes and quit",
 	)
+
+	fs.StringVar(
+		&c.Multisig,
+		"multisig",
+		"",
+		"name or address of a multisig key; output a partial signature of the tx for it instead of the signed tx",
+	)
 }
 
 func execSign(cfg *SignCfg, args []string, io commands.IO) error {
@@ -122,12 +132,17 @@ func execSign(cfg *SignCfg, args []string, io commands.IO) error {
 		return err
 	}
 
-	signedTx, err := SignHandler(cfg)
+	var signed any
+	if cfg.Multisig != "" {
+		signed, err = MultisigSignHandler(cfg)
+	} else {
+		signed, err = SignHandler(cfg)
+	}
 	if err != nil {
 		return err
 	}
 
-	signedJSON, err := amino.MarshalJSON(signedTx)
+	signedJSON, err := amino.MarshalJSON(signed)
 	if err != nil {
 		return err
 	}
@@ -137,39 +152,16 @@ func execSign(cfg *SignCfg, args []string, io commands.IO) error {
 }
 
 func SignHandler(cfg *SignCfg) (*std.Tx, error) {
-	var err error
-	var tx std.Tx
-
-	if cfg.TxJSON == nil {
-		return nil, errors.New("invalid tx content")
-	}
-
 	kb, err := keys.NewKeyBaseFromDir(cfg.RootCfg.Home)
 	if err != nil {
 		return nil, err
 	}
 
-	err = amino.UnmarshalJSON(cfg.TxJSON, &tx)
+	tx, err := decodeTxToSign(cfg.TxJSON)
 	if err != nil {
 		return nil, err
 	}
-
-	// fill tx signatures.
 	signers := tx.GetSigners()
-	if tx.Signatures == nil {
-		for range signers {
-			tx.Signatures = append(tx.Signatures, std.Signature{
-				PubKey:    nil, // zero signature
-				Signature: nil, // zero signature
-			})
-		}
-	}
-
-	// validate document to sign.
-	err = tx.ValidateBasic()
-	if err != nil {
-		return nil, err
-	}
 
 	// derive sign doc bytes.
 	chainID := cfg.ChainID
@@ -203,5 +195,85 @@ func SignHandler(cfg *SignCfg) (*std.Tx, error) {
 		)
 	}
 
+	return tx, nil
+}
+
+// MultisigSignHandler signs the tx with a member key of the multisig key
+// cfg.Multisig, and returns the partial signature, to be combined with the
+// ones of the other members by the multisign command.
+func MultisigSignHandler(cfg *SignCfg) (*std.Signature, error) {
+	kb, err := keys.NewKeyBaseFromDir(cfg.RootCfg.Home)
+	if err != nil {
+		return nil, err
+	}
+
+	multiInfo, err := kb.GetByNameOrAddress(cfg.Multisig)
+	if err != nil {
+		return nil, err
+	}
+	multiPub, ok := multiInfo.GetPubKey().(multisig.PubKeyMultisigThreshold)
+	if !ok {
+		return nil, fmt.Errorf("%s is not a multisig key", cfg.Multisig)
+	}
+
+	tx, err := decodeTxToSign(cfg.TxJSON)
+	if err != nil {
+		return nil, err
+	}
+	if !slices.Contains(tx.GetSigners(), multiPub.Address()) {
+		return nil, fmt.Errorf("addr %v (%s) not in signer set", multiPub.Address(), cfg.Multisig)
+	}
+
+	signbz := tx.GetSignBytes(cfg.ChainID, cfg.AccountNumber, cfg.Sequence)
+	if cfg.ShowSignBytes {
+		fmt.Printf("sign bytes: %X\n", signbz)
+		return nil, nil
+	}
+
+	sig, pub, err := kb.Sign(cfg.NameOrBech32, cfg.Pass, signbz)
+	if err != nil {
+		return nil, err
+	}
+	if !slices.ContainsFunc(multiPub.PubKeys, pub.Equals) {
+		return nil, fmt.Errorf("%s is not a member of the multisig key %s", cfg.NameOrBech32, cfg.Multisig)
+	}
+
+	return &std.Signature{
+		PubKey:    pub,
+		Signature: sig,
+	}, nil
+}
+
+// decodeTxToSign decodes and validates the tx to sign, with an empty
+// signature for each of its signers if it has none.
+func decodeTxToSign(txJSON []byte) (*std.Tx, error) {
+	var tx std.Tx
+
+	if txJSON == nil {
+		return nil, errors.New("invalid tx content")
+	}
+
+	err := amino.UnmarshalJSON(txJSON, &tx)
+	if err != nil {
+		return nil, err
+	}
+
+	// fill tx signatures.
+	signers := tx.GetSigners()
+	if tx.Signatures == nil {
+		for range signers {
+			tx.Signatures = append(tx.Signatures, std.Signature{
+				PubKey:    nil, // zero signature
+				Signature: nil, // zero signature
+			})
+		}
+	}
+
+	// validate document to sign.
+	err = tx.ValidateBasic()
+	if err != nil {
+		return nil, err
+	}
+
 	return &tx, nil
 }
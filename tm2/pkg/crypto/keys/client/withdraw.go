@@ -0,0 +1,90 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+
+	"github.com/gnolang/gno/tm2/pkg/commands"
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+	"github.com/gnolang/gno/tm2/pkg/crypto/keys"
+	"github.com/gnolang/gno/tm2/pkg/sdk/distribution"
+	"github.com/gnolang/gno/tm2/pkg/std"
+)
+
+type MakeWithdrawCfg struct {
+	RootCfg *MakeTxCfg
+
+	Validator string
+}
+
+func NewMakeWithdrawCmd(rootCfg *MakeTxCfg, io commands.IO) *commands.Command {
+	cfg := &MakeWithdrawCfg{
+		RootCfg: rootCfg,
+	}
+
+	return commands.NewCommand(
+		commands.Metadata{
+			Name:       "withdraw",
+			ShortUsage: "withdraw [flags] <key-name or address>",
+			ShortHelp:  "withdraws the pending rewards of a validator to its withdraw address",
+		},
+		cfg,
+		func(_ context.Context, args []string) error {
+			return execMakeWithdraw(cfg, args, io)
+		},
+	)
+}
+
+func (c *MakeWithdrawCfg) RegisterFlags(fs *flag.FlagSet) {
+	fs.StringVar(
+		&c.Validator,
+		"validator",
+		"",
+		"address of the validator",
+	)
+}
+
+func execMakeWithdraw(cfg *MakeWithdrawCfg, args []string, io commands.IO) error {
+	if len(args) != 1 {
+		return flag.ErrHelp
+	}
+
+	if cfg.Validator == "" {
+		return errors.New("validator must be specified")
+	}
+	validator, err := crypto.AddressFromBech32(cfg.Validator)
+	if err != nil {
+		return fmt.Errorf("invalid validator address: %w", err)
+	}
+
+	// read account pubkey.
+	nameOrBech32 := args[0]
+	kb, err := keys.NewKeyBaseFromDir(cfg.RootCfg.RootCfg.Home)
+	if err != nil {
+		return err
+	}
+	info, err := kb.GetByNameOrAddress(nameOrBech32)
+	if err != nil {
+		return err
+	}
+	withdrawAddr := info.GetAddress()
+
+	// parse gas wanted & fee.
+	fee, err := cfg.RootCfg.ParseFee()
+	if err != nil {
+		return err
+	}
+
+	// construct msg & tx and marshal.
+	msg := distribution.NewMsgWithdrawRewards(validator, withdrawAddr)
+	tx := std.Tx{
+		Msgs:       []std.Msg{msg},
+		Fee:        fee,
+		Signatures: nil,
+		Memo:       cfg.RootCfg.Memo,
+	}
+
+	return ExecMakeTx(cfg.RootCfg, args, tx, io)
+}
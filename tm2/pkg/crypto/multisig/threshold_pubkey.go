@@ -31,7 +31,7 @@ func NewPubKeyMultisigThreshold(k int, pubkeys []crypto.PubKey) crypto.PubKey {
 }
 
 func (pk PubKeyMultisigThreshold) String() string {
-	panic("not yet implemented")
+	return crypto.PubKeyToBech32(pk)
 }
 
 // VerifyBytes expects sig to be an amino encoded version of a MultiSignature.
@@ -16,3 +16,12 @@ type BeginBlocker func(ctx Context, req abci.RequestBeginBlock) abci.ResponseBeg
 // Note: applications which set create_empty_blocks=false will not have regular block timing and should use
 // e.g. BFT timestamps rather than block height for any periodic EndBlock logic
 type EndBlocker func(ctx Context, req abci.RequestEndBlock) abci.ResponseEndBlock
+
+// BeginTxHook runs before the messages of a transaction, and returns the
+// context they run with, e.g. to hold transaction-scoped state
+type BeginTxHook func(ctx Context) Context
+
+// EndTxHook runs after the messages of a transaction, even if they panic.
+// committed is true if the state changes of the messages are written, which
+// only happens for successful transactions in DeliverTx
+type EndTxHook func(ctx Context, committed bool)
@@ -36,8 +36,17 @@ type AnteOptions struct {
 	// This is useful for development, and maybe production chains.
 	// Always check your settings and inspect genesis transactions.
 	VerifyGenesisSignatures bool
+	// If QueueFutureSequences is true, CheckTx reports a tx signed ahead of the
+	// sequence of its fee payer with an InvalidSequenceError, for the mempool
+	// to queue it. It should only be set if the mempool sequence queue is on.
+	QueueFutureSequences bool
 }
 
+// MaxSequenceGap is how far ahead of its account sequence a tx may be signed
+// for CheckTx to report it with an InvalidSequenceError, rather than as
+// unauthorized, so that the mempool can hold it until the gap is filled.
+const MaxSequenceGap = 16
+
 // NewAnteHandler returns an AnteHandler that checks and increments sequence
 // numbers, checks signatures & account numbers, and deducts fees from the first
 // signer.
@@ -70,6 +79,12 @@ func NewAnteHandler(ak AccountKeeper, bank BankKeeperI, sigGasConsumer Signature
 			}
 		}
 
+		// Simulated txs may come without signatures, in which case they are
+		// simulated as if each signer had provided one without its pubkey.
+		if simulate && len(tx.Signatures) == 0 {
+			tx.Signatures = make([]std.Signature, len(tx.GetSigners()))
+		}
+
 		newCtx = SetGasMeter(simulate, ctx, tx.Fee.GasWanted)
 
 		// AnteHandlers must have their own defer/recover in order for the BaseApp
@@ -156,6 +171,15 @@ func NewAnteHandler(ak AccountKeeper, bank BankKeeperI, sigGasConsumer Signature
 				signBytes := GetSignBytes(newCtx.ChainID(), tx, sacc, isGenesis)
 				signerAccs[i], res = processSig(newCtx, sacc, stdSigs[i], signBytes, simulate, params, sigGasConsumer)
 				if !res.IsOK() {
+					// Let the mempool hold a tx of the fee payer that was
+					// signed ahead of its account sequence.
+					if i == 0 && opts.QueueFutureSequences && !simulate && ctx.Mode() == sdk.RunTxModeCheck {
+						if seq, ok := futureSequence(newCtx, tx, sacc, stdSigs[i], params, sigGasConsumer); ok {
+							return newCtx, abciResult(std.ErrInvalidSequence(fmt.Sprintf(
+								"sequence %d is ahead of account sequence %d", seq, sacc.GetSequence(),
+							))), true
+						}
+					}
 					return newCtx, res, true
 				}
 			}
@@ -272,16 +296,12 @@ func consumeSimSigGas(gasmeter store.GasMeter, pubkey crypto.PubKey, sig std.Sig
 func ProcessPubKey(acc std.Account, sig std.Signature, simulate bool) (crypto.PubKey, sdk.Result) {
 	// If pubkey is not known for account, set it from the std.Signature.
 	pubKey := acc.GetPubKey()
-	if simulate {
-		// In simulate mode the transaction comes with no signatures, thus if the
-		// account's pubkey is nil, both signature verification and gasKVStore.Set()
-		// shall consume the largest amount, i.e. it takes more gas to verify
-		// secp256k1 keys than ed25519 ones.
-		if pubKey == nil {
-			return simSecp256k1Pubkey, sdk.Result{}
-		}
-
-		return pubKey, sdk.Result{}
+	if simulate && pubKey == nil && sig.PubKey == nil {
+		// In simulate mode the transaction may come with no signatures, thus if
+		// neither the account nor the signature has a pubkey, both signature
+		// verification and gasKVStore.Set() shall consume the largest amount,
+		// i.e. it takes more gas to verify secp256k1 keys than ed25519 ones.
+		return simSecp256k1Pubkey, sdk.Result{}
 	}
 
 	if pubKey == nil {
@@ -346,7 +366,7 @@ func consumeMultisignatureVerificationGas(meter store.GasMeter,
 // NOTE: We could use the CoinKeeper (in addition to the AccountKeeper, because
 // the CoinKeeper doesn't give us accounts), but it seems easier to do this.
 func DeductFees(bank BankKeeperI, ctx sdk.Context, acc std.Account, fees std.Coins) sdk.Result {
-	coins := acc.GetCoins()
+	coins := SpendableCoins(acc, ctx.BlockTime())
 
 	if !fees.IsValid() {
 		return abciResult(std.ErrInsufficientFee(fmt.Sprintf("invalid fee amount: %s", fees)))
@@ -424,6 +444,31 @@ func SetGasMeter(simulate bool, ctx sdk.Context, gasLimit int64) sdk.Context {
 	return ctx.WithGasMeter(store.NewGasMeter(gasLimit))
 }
 
+// futureSequence returns the sequence the tx was signed with by acc, if it is
+// ahead of the account sequence by at most MaxSequenceGap. The gas of every
+// signature verification is consumed.
+func futureSequence(
+	ctx sdk.Context, tx std.Tx, acc std.Account, sig std.Signature, params Params,
+	sigGasConsumer SignatureVerificationGasConsumer,
+) (uint64, bool) {
+	pubKey, res := ProcessPubKey(acc, sig, false)
+	if !res.IsOK() {
+		return 0, false
+	}
+	for seq := acc.GetSequence() + 1; seq <= acc.GetSequence()+MaxSequenceGap; seq++ {
+		if res := sigGasConsumer(ctx.GasMeter(), sig.Signature, pubKey, params); !res.IsOK() {
+			return 0, false
+		}
+		signBytes := std.SignBytes(
+			ctx.ChainID(), acc.GetAccountNumber(), seq, tx.Fee, tx.Msgs, tx.Memo,
+		)
+		if pubKey.VerifyBytes(signBytes, sig.Signature) {
+			return seq, true
+		}
+	}
+	return 0, false
+}
+
 // GetSignBytes returns a slice of bytes to sign over for a given transaction
 // and an account.
 func GetSignBytes(chainID string, tx std.Tx, acc std.Account, genesis bool) []byte {
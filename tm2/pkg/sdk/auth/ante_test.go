@@ -107,6 +107,39 @@ func TestAnteHandlerSigErrors(t *testing.T) {
 	checkInvalidTx(t, anteHandler, ctx, tx, false, std.UnknownAddressError{})
 }
 
+// Test that txs can be simulated without signatures, or with pubkeys only.
+func TestAnteHandlerSimulate(t *testing.T) {
+	t.Parallel()
+
+	// setup
+	env := setupTestEnv()
+	ctx := env.ctx
+	anteHandler := NewAnteHandler(env.acck, env.bank, DefaultSigVerificationGasConsumer, defaultAnteOptions())
+
+	// keys and addresses
+	priv1, _, addr1 := tu.KeyTestPubAddr()
+
+	acc1 := env.acck.NewAccountWithAddress(ctx, addr1)
+	acc1.SetCoins(tu.NewTestCoins())
+	env.acck.SetAccount(ctx, acc1)
+
+	msgs := []std.Msg{tu.NewTestMsg(addr1)}
+	fee := tu.NewTestFee()
+
+	// no signatures
+	tx := std.NewTx(msgs, fee, nil, "")
+	checkInvalidTx(t, anteHandler, ctx, tx, false, std.NoSignaturesError{})
+	simCtx, _ := ctx.CacheContext()
+	checkValidTx(t, anteHandler, simCtx, tx, true)
+
+	// pubkey only
+	tx = std.NewTx(msgs, fee, []std.Signature{{PubKey: priv1.PubKey()}}, "")
+	checkInvalidTx(t, anteHandler, ctx, tx, false, std.UnauthorizedError{})
+	simCtx, _ = ctx.CacheContext()
+	checkValidTx(t, anteHandler, simCtx, tx, true)
+	require.Equal(t, priv1.PubKey(), env.acck.GetAccount(simCtx, addr1).GetPubKey())
+}
+
 // Test logic around account number checking with one signer and many signers.
 func TestAnteHandlerAccountNumbers(t *testing.T) {
 	t.Parallel()
@@ -306,6 +339,62 @@ func TestAnteHandlerSequences(t *testing.T) {
 	checkValidTx(t, anteHandler, ctx, tx, false)
 }
 
+// Test that txs signed ahead of the account sequence are reported in CheckTx.
+func TestAnteHandlerFutureSequences(t *testing.T) {
+	t.Parallel()
+
+	// setup
+	env := setupTestEnv()
+	opts := defaultAnteOptions()
+	opts.QueueFutureSequences = true
+	sigVerifications := 0
+	sigGasConsumer := func(meter store.GasMeter, sig []byte, pubkey crypto.PubKey, params Params) sdk.Result {
+		sigVerifications++
+		return DefaultSigVerificationGasConsumer(meter, sig, pubkey, params)
+	}
+	anteHandler := NewAnteHandler(env.acck, env.bank, sigGasConsumer, opts)
+	ctx := env.ctx.WithMode(sdk.RunTxModeCheck)
+
+	// keys and addresses
+	priv1, _, addr1 := tu.KeyTestPubAddr()
+
+	// set the accounts
+	acc1 := env.acck.NewAccountWithAddress(ctx, addr1)
+	acc1.SetCoins(tu.NewTestCoins())
+	env.acck.SetAccount(ctx, acc1)
+
+	msgs := []std.Msg{tu.NewTestMsg(addr1)}
+	fee := tu.NewTestFee()
+	privs, accnums := []crypto.PrivKey{priv1}, []uint64{0}
+
+	// a tx ahead of the account sequence is reported as such
+	tx := tu.NewTestTx(ctx.ChainID(), msgs, privs, accnums, []uint64{1}, fee)
+	checkInvalidTx(t, anteHandler, ctx, tx, false, std.InvalidSequenceError{})
+	tx = tu.NewTestTx(ctx.ChainID(), msgs, privs, accnums, []uint64{MaxSequenceGap}, fee)
+	checkInvalidTx(t, anteHandler, ctx, tx, false, std.InvalidSequenceError{})
+
+	// unless too far ahead, every sequence tried being charged
+	sigVerifications = 0
+	tx = tu.NewTestTx(ctx.ChainID(), msgs, privs, accnums, []uint64{MaxSequenceGap + 1}, fee)
+	checkInvalidTx(t, anteHandler, ctx, tx, false, std.UnauthorizedError{})
+	require.Equal(t, 1+MaxSequenceGap, sigVerifications)
+
+	// or if the mempool doesn't queue them
+	noQueueAnteHandler := NewAnteHandler(env.acck, env.bank, DefaultSigVerificationGasConsumer, defaultAnteOptions())
+	tx = tu.NewTestTx(ctx.ChainID(), msgs, privs, accnums, []uint64{1}, fee)
+	checkInvalidTx(t, noQueueAnteHandler, ctx, tx, false, std.UnauthorizedError{})
+
+	// or not in CheckTx
+	tx = tu.NewTestTx(ctx.ChainID(), msgs, privs, accnums, []uint64{1}, fee)
+	checkInvalidTx(t, anteHandler, ctx.WithMode(sdk.RunTxModeDeliver), tx, false, std.UnauthorizedError{})
+
+	// it passes once the gap is filled
+	tx = tu.NewTestTx(ctx.ChainID(), msgs, privs, accnums, []uint64{0}, fee)
+	checkValidTx(t, anteHandler, ctx, tx, false)
+	tx = tu.NewTestTx(ctx.ChainID(), msgs, privs, accnums, []uint64{1}, fee)
+	checkValidTx(t, anteHandler, ctx, tx, false)
+}
+
 // Test logic around fee deduction.
 func TestAnteHandlerFees(t *testing.T) {
 	t.Parallel()
@@ -605,7 +694,8 @@ func TestProcessPubKey(t *testing.T) {
 		{"no sigs, simulate on", args{acc1, std.Signature{}, true}, false},
 		{"no sigs, account with pub, simulate on", args{acc2, std.Signature{}, true}, false},
 		{"pubkey doesn't match addr, simulate off", args{acc1, std.Signature{PubKey: priv2.PubKey()}, false}, true},
-		{"pubkey doesn't match addr, simulate on", args{acc1, std.Signature{PubKey: priv2.PubKey()}, true}, false},
+		{"pubkey doesn't match addr, simulate on", args{acc1, std.Signature{PubKey: priv2.PubKey()}, true}, true},
+		{"pubkey only, simulate on", args{acc2, std.Signature{PubKey: priv2.PubKey()}, true}, false},
 	}
 	for _, tt := range tests {
 		tt := tt
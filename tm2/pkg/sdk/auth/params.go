@@ -5,10 +5,17 @@ import (
 	"strings"
 
 	"github.com/gnolang/gno/tm2/pkg/amino"
+	"github.com/gnolang/gno/tm2/pkg/std"
 )
 
 type AuthParamsContextKey struct{}
 
+// Keys of the auth params in the params keeper.
+const (
+	ParamsKey       = "auth.params"
+	MinGasPricesKey = "auth.min_gas_prices"
+)
+
 // Default parameter values
 const (
 	DefaultMaxMemoBytes           int64 = 65536
@@ -45,6 +52,26 @@ func (p Params) Equals(p2 Params) bool {
 	return amino.DeepEqual(p, p2)
 }
 
+// Validate implements params.Validator.
+func (p Params) Validate() error {
+	if p.MaxMemoBytes <= 0 {
+		return fmt.Errorf("invalid max memo bytes: %d", p.MaxMemoBytes)
+	}
+	if p.TxSigLimit <= 0 {
+		return fmt.Errorf("invalid tx signature limit: %d", p.TxSigLimit)
+	}
+	if p.TxSizeCostPerByte < 0 {
+		return fmt.Errorf("invalid tx size cost per byte: %d", p.TxSizeCostPerByte)
+	}
+	if p.SigVerifyCostED25519 < 0 {
+		return fmt.Errorf("invalid ED25519 signature verification cost: %d", p.SigVerifyCostED25519)
+	}
+	if p.SigVerifyCostSecp256k1 < 0 {
+		return fmt.Errorf("invalid SECP256k1 signature verification cost: %d", p.SigVerifyCostSecp256k1)
+	}
+	return nil
+}
+
 // DefaultParams returns a default set of parameters.
 func DefaultParams() Params {
 	return Params{
@@ -67,3 +94,25 @@ func (p Params) String() string {
 	sb.WriteString(fmt.Sprintf("SigVerifyCostSecp256k1: %d\n", p.SigVerifyCostSecp256k1))
 	return sb.String()
 }
+
+// MinGasPrices are the minimum gas prices of the chain, set with the
+// MinGasPricesKey param. A tx may pay its fee in any of their denominations.
+type MinGasPrices []std.GasPrice
+
+// Validate implements params.Validator.
+func (mgp MinGasPrices) Validate() error {
+	denoms := make(map[string]bool, len(mgp))
+	for _, gp := range mgp {
+		if gp.Gas <= 0 {
+			return fmt.Errorf("invalid gas of min gas price: %d", gp.Gas)
+		}
+		if !gp.Price.IsValid() {
+			return fmt.Errorf("invalid price of min gas price: %s", gp.Price)
+		}
+		if denoms[gp.Price.Denom] {
+			return fmt.Errorf("duplicate min gas price denom: %s", gp.Price.Denom)
+		}
+		denoms[gp.Price.Denom] = true
+	}
+	return nil
+}
@@ -0,0 +1,26 @@
+package auth
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/gnolang/gno/tm2/pkg/std"
+)
+
+func TestMinGasPricesValidate(t *testing.T) {
+	t.Parallel()
+
+	gp := func(gas int64, price string) std.GasPrice {
+		return std.GasPrice{Gas: gas, Price: std.MustParseCoin(price)}
+	}
+
+	require.NoError(t, MinGasPrices{}.Validate())
+	require.NoError(t, MinGasPrices{gp(1000, "1ugnot"), gp(10, "1foo")}.Validate())
+
+	require.Error(t, MinGasPrices{gp(0, "1ugnot")}.Validate())
+	require.Error(t, MinGasPrices{gp(-1, "1ugnot")}.Validate())
+	require.Error(t, MinGasPrices{{Gas: 1000, Price: std.Coin{Denom: "ugnot", Amount: -1}}}.Validate())
+	require.Error(t, MinGasPrices{{Gas: 1000, Price: std.Coin{Denom: "", Amount: 1}}}.Validate())
+	require.Error(t, MinGasPrices{gp(1000, "1ugnot"), gp(10, "2ugnot")}.Validate())
+}
@@ -0,0 +1,205 @@
+package auth
+
+import (
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/gnolang/gno/tm2/pkg/std"
+)
+
+// VestingAccount is an account whose original vesting coins are locked, and
+// cannot be spent, until they vest following a schedule.
+//
+// The vesting accounts embed a std.BaseAccount, so that clients decoding the
+// BaseAccount of any account keep working with them.
+type VestingAccount interface {
+	std.Account
+
+	GetOriginalVesting() std.Coins
+	GetStartTime() int64 // unix time, in seconds.
+	GetEndTime() int64   // unix time, in seconds.
+
+	// GetVestedCoins returns the original vesting coins vested at blockTime.
+	GetVestedCoins(blockTime time.Time) std.Coins
+	// GetVestingCoins returns the original vesting coins still locked at
+	// blockTime.
+	GetVestingCoins(blockTime time.Time) std.Coins
+}
+
+var (
+	_ VestingAccount = &ContinuousVestingAccount{}
+	_ VestingAccount = &DelayedVestingAccount{}
+)
+
+// SpendableCoins returns the coins of the account which can be spent at
+// blockTime: the coins of a vesting account still locked are excluded.
+func SpendableCoins(acc std.Account, blockTime time.Time) std.Coins {
+	coins := acc.GetCoins()
+	vacc, ok := acc.(VestingAccount)
+	if !ok {
+		return coins
+	}
+
+	locked := vacc.GetVestingCoins(blockTime)
+	spendable := std.Coins{}
+	for _, coin := range coins {
+		if amount := coin.Amount - locked.AmountOf(coin.Denom); amount > 0 {
+			spendable = append(spendable, std.NewCoin(coin.Denom, amount))
+		}
+	}
+	return spendable
+}
+
+// ValidateVestingSchedule returns an error if the original vesting coins, or
+// the vesting period ending at endTime and starting at startTime (0 for a
+// delayed vesting) are invalid.
+func ValidateVestingSchedule(originalVesting std.Coins, startTime, endTime int64) error {
+	if !originalVesting.IsValid() || originalVesting.IsZero() {
+		return fmt.Errorf("invalid original vesting coins %q", originalVesting)
+	}
+	if endTime <= 0 {
+		return fmt.Errorf("invalid vesting end time %d", endTime)
+	}
+	if startTime < 0 || startTime >= endTime {
+		return fmt.Errorf("vesting start time %d must be before end time %d", startTime, endTime)
+	}
+	return nil
+}
+
+//----------------------------------------
+// ContinuousVestingAccount
+
+// ContinuousVestingAccount is a vesting account whose original vesting coins
+// vest linearly between StartTime and EndTime.
+type ContinuousVestingAccount struct {
+	std.BaseAccount
+
+	OriginalVesting std.Coins `json:"original_vesting" yaml:"original_vesting"`
+	StartTime       int64     `json:"start_time" yaml:"start_time"`
+	EndTime         int64     `json:"end_time" yaml:"end_time"`
+}
+
+// NewContinuousVestingAccount returns a ContinuousVestingAccount of base,
+// vesting originalVesting linearly between startTime and endTime.
+func NewContinuousVestingAccount(base std.BaseAccount, originalVesting std.Coins, startTime, endTime int64) *ContinuousVestingAccount {
+	return &ContinuousVestingAccount{
+		BaseAccount:     base,
+		OriginalVesting: originalVesting,
+		StartTime:       startTime,
+		EndTime:         endTime,
+	}
+}
+
+// GetOriginalVesting - Implements VestingAccount.
+func (cva ContinuousVestingAccount) GetOriginalVesting() std.Coins {
+	return cva.OriginalVesting
+}
+
+// GetStartTime - Implements VestingAccount.
+func (cva ContinuousVestingAccount) GetStartTime() int64 {
+	return cva.StartTime
+}
+
+// GetEndTime - Implements VestingAccount.
+func (cva ContinuousVestingAccount) GetEndTime() int64 {
+	return cva.EndTime
+}
+
+// GetVestedCoins - Implements VestingAccount.
+func (cva ContinuousVestingAccount) GetVestedCoins(blockTime time.Time) std.Coins {
+	now := blockTime.Unix()
+	switch {
+	case now <= cva.StartTime:
+		return std.Coins{}
+	case now >= cva.EndTime:
+		return cva.OriginalVesting
+	}
+
+	// vested = original * elapsed / duration, computed without overflow.
+	elapsed := big.NewInt(now - cva.StartTime)
+	duration := big.NewInt(cva.EndTime - cva.StartTime)
+	vested := std.Coins{}
+	for _, coin := range cva.OriginalVesting {
+		amount := new(big.Int).Mul(big.NewInt(coin.Amount), elapsed)
+		amount.Quo(amount, duration)
+		if amount.Sign() > 0 {
+			vested = append(vested, std.NewCoin(coin.Denom, amount.Int64()))
+		}
+	}
+	return vested
+}
+
+// GetVestingCoins - Implements VestingAccount.
+func (cva ContinuousVestingAccount) GetVestingCoins(blockTime time.Time) std.Coins {
+	return cva.OriginalVesting.Sub(cva.GetVestedCoins(blockTime))
+}
+
+// String implements fmt.Stringer
+func (cva ContinuousVestingAccount) String() string {
+	return fmt.Sprintf(`%s
+  OriginalVesting: %s
+  StartTime:       %d
+  EndTime:         %d`,
+		cva.BaseAccount.String(), cva.OriginalVesting, cva.StartTime, cva.EndTime,
+	)
+}
+
+//----------------------------------------
+// DelayedVestingAccount
+
+// DelayedVestingAccount is a vesting account whose original vesting coins
+// all vest at EndTime.
+type DelayedVestingAccount struct {
+	std.BaseAccount
+
+	OriginalVesting std.Coins `json:"original_vesting" yaml:"original_vesting"`
+	EndTime         int64     `json:"end_time" yaml:"end_time"`
+}
+
+// NewDelayedVestingAccount returns a DelayedVestingAccount of base, vesting
+// originalVesting at endTime.
+func NewDelayedVestingAccount(base std.BaseAccount, originalVesting std.Coins, endTime int64) *DelayedVestingAccount {
+	return &DelayedVestingAccount{
+		BaseAccount:     base,
+		OriginalVesting: originalVesting,
+		EndTime:         endTime,
+	}
+}
+
+// GetOriginalVesting - Implements VestingAccount.
+func (dva DelayedVestingAccount) GetOriginalVesting() std.Coins {
+	return dva.OriginalVesting
+}
+
+// GetStartTime - Implements VestingAccount.
+func (dva DelayedVestingAccount) GetStartTime() int64 {
+	return 0
+}
+
+// GetEndTime - Implements VestingAccount.
+func (dva DelayedVestingAccount) GetEndTime() int64 {
+	return dva.EndTime
+}
+
+// GetVestedCoins - Implements VestingAccount.
+func (dva DelayedVestingAccount) GetVestedCoins(blockTime time.Time) std.Coins {
+	if blockTime.Unix() >= dva.EndTime {
+		return dva.OriginalVesting
+	}
+	return std.Coins{}
+}
+
+// GetVestingCoins - Implements VestingAccount.
+func (dva DelayedVestingAccount) GetVestingCoins(blockTime time.Time) std.Coins {
+	return dva.OriginalVesting.Sub(dva.GetVestedCoins(blockTime))
+}
+
+// String implements fmt.Stringer
+func (dva DelayedVestingAccount) String() string {
+	return fmt.Sprintf(`%s
+  OriginalVesting: %s
+  EndTime:         %d`,
+		dva.BaseAccount.String(), dva.OriginalVesting, dva.EndTime,
+	)
+}
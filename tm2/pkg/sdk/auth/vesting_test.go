@@ -0,0 +1,104 @@
+package auth
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gnolang/gno/tm2/pkg/amino"
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+	"github.com/gnolang/gno/tm2/pkg/std"
+)
+
+func TestContinuousVestingAccount(t *testing.T) {
+	t.Parallel()
+
+	addr := crypto.AddressFromPreimage([]byte("vesting"))
+	original := std.NewCoins(std.NewCoin("foo", 100), std.NewCoin("bar", 10))
+	coins := original.Add(std.NewCoins(std.NewCoin("foo", 5)))
+	start := time.Unix(1000, 0)
+	end := time.Unix(2000, 0)
+	acc := NewContinuousVestingAccount(
+		std.NewBaseAccountWithAddress(addr), original, start.Unix(), end.Unix(),
+	)
+	acc.SetCoins(coins)
+
+	// nothing vested before the start.
+	assert.True(t, acc.GetVestedCoins(start).IsZero())
+	assert.Equal(t, original, acc.GetVestingCoins(start))
+	assert.Equal(t, std.NewCoins(std.NewCoin("foo", 5)), SpendableCoins(acc, start))
+
+	// vests linearly.
+	half := start.Add(500 * time.Second)
+	assert.Equal(t, std.NewCoins(std.NewCoin("foo", 50), std.NewCoin("bar", 5)), acc.GetVestedCoins(half))
+	assert.Equal(t, std.NewCoins(std.NewCoin("foo", 50), std.NewCoin("bar", 5)), acc.GetVestingCoins(half))
+	assert.Equal(t, std.NewCoins(std.NewCoin("foo", 55), std.NewCoin("bar", 5)), SpendableCoins(acc, half))
+
+	// everything vested at the end.
+	assert.Equal(t, original, acc.GetVestedCoins(end))
+	assert.True(t, acc.GetVestingCoins(end).IsZero())
+	assert.Equal(t, coins, SpendableCoins(acc, end))
+}
+
+func TestDelayedVestingAccount(t *testing.T) {
+	t.Parallel()
+
+	addr := crypto.AddressFromPreimage([]byte("vesting"))
+	original := std.NewCoins(std.NewCoin("foo", 100))
+	end := time.Unix(2000, 0)
+	acc := NewDelayedVestingAccount(std.NewBaseAccountWithAddress(addr), original, end.Unix())
+	acc.SetCoins(original)
+
+	assert.True(t, SpendableCoins(acc, end.Add(-time.Second)).IsZero())
+	assert.Equal(t, original, acc.GetVestingCoins(end.Add(-time.Second)))
+	assert.Equal(t, original, SpendableCoins(acc, end))
+	assert.True(t, acc.GetVestingCoins(end).IsZero())
+}
+
+func TestSpendableCoinsSpentVesting(t *testing.T) {
+	t.Parallel()
+
+	// the locked coins of a vesting account that spent its vested coins
+	// exceed its balance.
+	addr := crypto.AddressFromPreimage([]byte("vesting"))
+	acc := NewDelayedVestingAccount(
+		std.NewBaseAccountWithAddress(addr), std.NewCoins(std.NewCoin("foo", 100)), 2000,
+	)
+	acc.SetCoins(std.NewCoins(std.NewCoin("bar", 1), std.NewCoin("foo", 60)))
+
+	assert.Equal(t, std.NewCoins(std.NewCoin("bar", 1)), SpendableCoins(acc, time.Unix(1000, 0)))
+}
+
+func TestValidateVestingSchedule(t *testing.T) {
+	t.Parallel()
+
+	coins := std.NewCoins(std.NewCoin("foo", 100))
+	assert.NoError(t, ValidateVestingSchedule(coins, 0, 2000))
+	assert.NoError(t, ValidateVestingSchedule(coins, 1000, 2000))
+	assert.Error(t, ValidateVestingSchedule(std.Coins{}, 0, 2000))
+	assert.Error(t, ValidateVestingSchedule(coins, 0, 0))
+	assert.Error(t, ValidateVestingSchedule(coins, 2000, 2000))
+	assert.Error(t, ValidateVestingSchedule(coins, -1, 2000))
+}
+
+func TestVestingAccountStore(t *testing.T) {
+	t.Parallel()
+
+	env := setupTestEnv()
+	addr := crypto.AddressFromPreimage([]byte("vesting"))
+	base := std.NewBaseAccountWithAddress(addr)
+	base.Coins = std.NewCoins(std.NewCoin("foo", 100))
+	acc := NewContinuousVestingAccount(base, base.Coins, 1000, 2000)
+	env.acck.SetAccount(env.ctx, acc)
+
+	got := env.acck.GetAccount(env.ctx, addr)
+	require.IsType(t, &ContinuousVestingAccount{}, got)
+	assert.Equal(t, acc, got)
+
+	// clients decoding the BaseAccount of the account keep working.
+	var qret struct{ BaseAccount std.BaseAccount }
+	require.NoError(t, amino.UnmarshalJSON(amino.MustMarshalJSON(got), &qret))
+	assert.Equal(t, base, qret.BaseAccount)
+}
@@ -115,25 +115,27 @@ func (bank BankKeeper) SendCoins(ctx sdk.Context, fromAddr crypto.Address, toAdd
 
 // SubtractCoins subtracts amt from the coins at the addr.
 //
-// CONTRACT: If the account is a vesting account, the amount has to be spendable.
+// If the account is a vesting account, the amount has to be spendable.
 func (bank BankKeeper) SubtractCoins(ctx sdk.Context, addr crypto.Address, amt std.Coins) (std.Coins, error) {
 	if !amt.IsValid() {
 		return nil, std.ErrInvalidCoins(amt.String())
 	}
 
 	oldCoins := std.NewCoins()
+	spendable := std.NewCoins()
 	acc := bank.acck.GetAccount(ctx, addr)
 	if acc != nil {
 		oldCoins = acc.GetCoins()
+		spendable = auth.SpendableCoins(acc, ctx.BlockTime())
 	}
 
-	newCoins := oldCoins.SubUnsafe(amt)
-	if !newCoins.IsValid() {
+	if !spendable.SubUnsafe(amt).IsValid() {
 		err := std.ErrInsufficientCoins(
-			fmt.Sprintf("insufficient account funds; %s < %s", oldCoins, amt),
+			fmt.Sprintf("insufficient account funds; %s < %s", spendable, amt),
 		)
 		return nil, err
 	}
+	newCoins := oldCoins.Sub(amt)
 	err := bank.SetCoins(ctx, addr, newCoins)
 
 	return newCoins, err
@@ -185,6 +187,7 @@ func (bank BankKeeper) SetCoins(ctx sdk.Context, addr crypto.Address, amt std.Co
 // account balances.
 type ViewKeeperI interface {
 	GetCoins(ctx sdk.Context, addr crypto.Address) std.Coins
+	SpendableCoins(ctx sdk.Context, addr crypto.Address) std.Coins
 	HasCoins(ctx sdk.Context, addr crypto.Address, amt std.Coins) bool
 }
 
@@ -214,6 +217,16 @@ func (view ViewKeeper) GetCoins(ctx sdk.Context, addr crypto.Address) std.Coins
 	return acc.GetCoins()
 }
 
+// SpendableCoins returns the coins at the addr which can be spent, excluding
+// the coins of a vesting account that are still locked.
+func (view ViewKeeper) SpendableCoins(ctx sdk.Context, addr crypto.Address) std.Coins {
+	acc := view.acck.GetAccount(ctx, addr)
+	if acc == nil {
+		return std.NewCoins()
+	}
+	return auth.SpendableCoins(acc, ctx.BlockTime())
+}
+
 // HasCoins returns whether or not an account has at least amt coins.
 func (view ViewKeeper) HasCoins(ctx sdk.Context, addr crypto.Address, amt std.Coins) bool {
 	return view.GetCoins(ctx, addr).IsAllGTE(amt)
@@ -2,11 +2,14 @@ package bank
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
+	bft "github.com/gnolang/gno/tm2/pkg/bft/types"
 	"github.com/gnolang/gno/tm2/pkg/crypto"
 	"github.com/gnolang/gno/tm2/pkg/sdk"
+	"github.com/gnolang/gno/tm2/pkg/sdk/auth"
 	"github.com/gnolang/gno/tm2/pkg/std"
 )
 
@@ -160,3 +163,34 @@ func TestViewKeeper(t *testing.T) {
 	require.False(t, view.HasCoins(ctx, addr, std.NewCoins(std.NewCoin("foocoin", 15))))
 	require.False(t, view.HasCoins(ctx, addr, std.NewCoins(std.NewCoin("barcoin", 5))))
 }
+
+func TestKeeperVestingAccount(t *testing.T) {
+	t.Parallel()
+
+	env := setupTestEnv()
+	ctx := env.ctx.WithBlockHeader(&bft.Header{ChainID: "test-chain-id", Time: time.Unix(1000, 0)})
+
+	addr := crypto.AddressFromPreimage([]byte("addr1"))
+	addr2 := crypto.AddressFromPreimage([]byte("addr2"))
+	base := std.NewBaseAccountWithAddress(addr)
+	base.Coins = std.NewCoins(std.NewCoin("foocoin", 100))
+	env.acck.SetAccount(ctx, auth.NewDelayedVestingAccount(base, std.NewCoins(std.NewCoin("foocoin", 80)), 2000))
+
+	require.True(t, env.bank.SpendableCoins(ctx, addr).IsEqual(std.NewCoins(std.NewCoin("foocoin", 20))))
+
+	// locked coins can't be spent.
+	err := env.bank.SendCoins(ctx, addr, addr2, std.NewCoins(std.NewCoin("foocoin", 21)))
+	require.Error(t, err)
+	require.NoError(t, env.bank.SendCoins(ctx, addr, addr2, std.NewCoins(std.NewCoin("foocoin", 20))))
+	require.True(t, env.bank.GetCoins(ctx, addr).IsEqual(std.NewCoins(std.NewCoin("foocoin", 80))))
+
+	// received coins can be spent.
+	_, err = env.bank.AddCoins(ctx, addr, std.NewCoins(std.NewCoin("foocoin", 5)))
+	require.NoError(t, err)
+	require.True(t, env.bank.SpendableCoins(ctx, addr).IsEqual(std.NewCoins(std.NewCoin("foocoin", 5))))
+
+	// vested coins can be spent.
+	ctx = ctx.WithBlockHeader(&bft.Header{ChainID: "test-chain-id", Time: time.Unix(2000, 0)})
+	require.NoError(t, env.bank.SendCoins(ctx, addr, addr2, std.NewCoins(std.NewCoin("foocoin", 85))))
+	require.True(t, env.bank.GetCoins(ctx, addr).IsZero())
+}
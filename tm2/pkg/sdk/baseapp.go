@@ -1,8 +1,11 @@
 package sdk
 
 import (
+	"context"
 	"fmt"
 	"log/slog"
+	"math"
+	"math/big"
 	"os"
 	"runtime/debug"
 	"sort"
@@ -41,6 +44,8 @@ type BaseApp struct {
 	initChainer  InitChainer  // initialize state with validators and state blob
 	beginBlocker BeginBlocker // logic to run before any txs
 	endBlocker   EndBlocker   // logic to run after all txs, and to determine valset changes
+	beginTxHook  BeginTxHook  // logic to run before the messages of a tx
+	endTxHook    EndTxHook    // logic to run after the messages of a tx
 
 	// --------------------
 	// Volatile state
@@ -337,12 +342,18 @@ func (app *BaseApp) InitChain(req abci.RequestInitChain) (res abci.ResponseInitC
 		return
 	}
 
-	// add block gas meter for any genesis transactions (allow infinite gas)
+	// add block gas meter for any genesis transactions (allow infinite gas),
+	// and mark their context as the genesis context.
+	stdCtx := app.deliverState.ctx.Context()
 	app.deliverState.ctx = app.deliverState.ctx.
-		WithBlockGasMeter(store.NewInfiniteGasMeter())
+		WithBlockGasMeter(store.NewInfiniteGasMeter()).
+		WithContext(context.WithValue(stdCtx, genesisKey{}, true))
 
 	res = app.initChainer(app.deliverState.ctx, req)
 
+	// block 1 is not part of the genesis.
+	app.deliverState.ctx = app.deliverState.ctx.WithContext(stdCtx)
+
 	// sanity check
 	if len(req.Validators) > 0 {
 		if len(req.Validators) != len(res.Validators) {
@@ -570,10 +581,54 @@ func (app *BaseApp) CheckTx(req abci.RequestCheckTx) (res abci.ResponseCheckTx)
 		res.ResponseBase = result.ResponseBase
 		res.GasWanted = result.GasWanted
 		res.GasUsed = result.GasUsed
+		if result.IsOK() {
+			res.Priority = result.priority
+			if signers := tx.GetSigners(); len(signers) > 0 {
+				res.Sender = signers[0]
+			}
+		} else if _, ok := result.Error.(std.InvalidSequenceError); ok {
+			// The tx was signed ahead of the sequence of its sender.
+			res.Pending = true
+			if signers := tx.GetSigners(); len(signers) > 0 {
+				res.Sender = signers[0]
+			}
+		}
 		return
 	}
 }
 
+// minPriority is the priority of a tx paying exactly the min gas price.
+const minPriority = 1_000_000
+
+// txPriority returns the priority of a tx in a priority mempool, which is its
+// gas price relative to the min gas price of its fee denomination, scaled by
+// minPriority.
+// NOTE: fees of different denominations can only be compared through their
+// min gas prices, so a tx paying in a denomination without a positive min gas
+// price has no priority, as does every tx if no min gas prices are set.
+func txPriority(fee std.Fee, minGasPrices []GasPrice) int64 {
+	if fee.GasWanted <= 0 {
+		return 0
+	}
+	for _, gp := range minGasPrices {
+		if gp.Price.Denom != fee.GasFee.Denom {
+			continue
+		}
+		if gp.Gas <= 0 || gp.Price.Amount <= 0 {
+			return 0
+		}
+		// fee amount * price gas * minPriority / (fee gas * price amount)
+		price := new(big.Int).Mul(big.NewInt(fee.GasFee.Amount), big.NewInt(gp.Gas))
+		price.Mul(price, big.NewInt(minPriority))
+		price.Quo(price, new(big.Int).Mul(big.NewInt(fee.GasWanted), big.NewInt(gp.Price.Amount)))
+		if !price.IsInt64() {
+			return math.MaxInt64
+		}
+		return price.Int64()
+	}
+	return 0
+}
+
 // DeliverTx implements the ABCI interface.
 func (app *BaseApp) DeliverTx(req abci.RequestDeliverTx) (res abci.ResponseDeliverTx) {
 	var tx Tx
@@ -825,8 +880,23 @@ func (app *BaseApp) runTx(mode RunTxMode, txBytes []byte, tx Tx) (result Result)
 	// Create a new context based off of the existing context with a cache wrapped
 	// multi-store in case message processing fails.
 	runMsgCtx, msCache := app.cacheTxContext(ctx, txBytes)
+	if app.beginTxHook != nil {
+		runMsgCtx = app.beginTxHook(runMsgCtx)
+	}
+	committed := false
+	if app.endTxHook != nil {
+		// deferred, as the messages may panic, e.g. when out of gas.
+		defer func() {
+			app.endTxHook(runMsgCtx, committed)
+		}()
+	}
 	result = app.runMsgs(runMsgCtx, msgs, mode)
 	result.GasWanted = gasWanted
+	if mode == RunTxModeCheck {
+		// The fee was checked against the min gas prices of the
+		// ante handler context, which may differ from the app's.
+		result.priority = txPriority(tx.Fee, ctx.MinGasPrices())
+	}
 
 	// Safety check: don't write the cache state unless we're in DeliverTx.
 	if mode != RunTxModeDeliver {
@@ -836,6 +906,7 @@ func (app *BaseApp) runTx(mode RunTxMode, txBytes []byte, tx Tx) (result Result)
 	// only update state if all messages pass
 	if result.IsOK() {
 		msCache.MultiWrite()
+		committed = true
 	}
 
 	return result
@@ -844,7 +915,10 @@ func (app *BaseApp) runTx(mode RunTxMode, txBytes []byte, tx Tx) (result Result)
 // EndBlock implements the ABCI interface.
 func (app *BaseApp) EndBlock(req abci.RequestEndBlock) (res abci.ResponseEndBlock) {
 	if app.endBlocker != nil {
-		res = app.endBlocker(app.deliverState.ctx, req)
+		ctx := app.deliverState.ctx.
+			WithVoteInfos(app.voteInfos).
+			WithConsensusParams(app.consensusParams)
+		res = app.endBlocker(ctx, req)
 	}
 
 	return
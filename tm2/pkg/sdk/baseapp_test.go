@@ -5,6 +5,7 @@ import (
 	"encoding/binary"
 	"fmt"
 	"log/slog"
+	"math"
 	"os"
 	"reflect"
 	"testing"
@@ -14,9 +15,13 @@ import (
 
 	"github.com/gnolang/gno/tm2/pkg/amino"
 	abci "github.com/gnolang/gno/tm2/pkg/bft/abci/types"
+	"github.com/gnolang/gno/tm2/pkg/bft/mempool"
+	mempoolcfg "github.com/gnolang/gno/tm2/pkg/bft/mempool/config"
+	"github.com/gnolang/gno/tm2/pkg/bft/proxy"
 	bft "github.com/gnolang/gno/tm2/pkg/bft/types"
 	dbm "github.com/gnolang/gno/tm2/pkg/db"
 	"github.com/gnolang/gno/tm2/pkg/db/memdb"
+	"github.com/gnolang/gno/tm2/pkg/log"
 	"github.com/gnolang/gno/tm2/pkg/sdk/testutils"
 	"github.com/gnolang/gno/tm2/pkg/std"
 	"github.com/gnolang/gno/tm2/pkg/store/dbadapter"
@@ -272,6 +277,12 @@ func TestBaseAppOptionSeal(t *testing.T) {
 	require.Panics(t, func() {
 		app.SetAnteHandler(nil)
 	})
+	require.Panics(t, func() {
+		app.SetBeginTxHook(nil)
+	})
+	require.Panics(t, func() {
+		app.SetEndTxHook(nil)
+	})
 }
 
 func TestSetMinGasPrices(t *testing.T) {
@@ -296,6 +307,7 @@ func TestInitChainer(t *testing.T) {
 	// set a value in the store on init chain
 	key, value := []byte("hello"), []byte("goodbye")
 	var initChainer InitChainer = func(ctx Context, req abci.RequestInitChain) abci.ResponseInitChain {
+		require.True(t, ctx.IsGenesis())
 		store := ctx.Store(mainKey)
 		store.Set(key, value)
 		return abci.ResponseInitChain{}
@@ -328,6 +340,9 @@ func TestInitChainer(t *testing.T) {
 	chainID = app.checkState.ctx.ChainID()
 	require.Equal(t, "test-chain-id", chainID, "ChainID in checkState not set correctly in InitChain")
 
+	// block 1 starts from the deliver state, but is not part of the genesis
+	require.False(t, app.deliverState.ctx.IsGenesis())
+
 	app.Commit()
 	res = app.Query(query)
 	require.Equal(t, int64(1), app.LastBlockHeight())
@@ -546,6 +561,105 @@ func TestCheckTx(t *testing.T) {
 	require.Nil(t, storedBytes)
 }
 
+func TestTxPriority(t *testing.T) {
+	t.Parallel()
+
+	minGasPrices, err := ParseGasPrices("1ugnot/1000gas;0free/1gas")
+	require.NoError(t, err)
+
+	for _, tc := range []struct {
+		name     string
+		fee      std.Fee
+		expected int64
+	}{
+		{"no gas", std.NewFee(0, std.NewCoin("ugnot", 10)), 0},
+		{"no fee", std.NewFee(100, std.Coin{}), 0},
+		{"min price", std.NewFee(3_000, std.NewCoin("ugnot", 3)), 1_000_000},
+		{"price", std.NewFee(1_000, std.NewCoin("ugnot", 3)), 3_000_000},
+		{"below min price", std.NewFee(2_000_000, std.NewCoin("ugnot", 3)), 1_500},
+		{"overflow", std.NewFee(1, std.NewCoin("ugnot", math.MaxInt64)), math.MaxInt64},
+		{"free denom", std.NewFee(1, std.NewCoin("free", 1_000_000)), 0},
+		{"unknown denom", std.NewFee(1, std.NewCoin("cheap", 1_000_000)), 0},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tc.expected, txPriority(tc.fee, minGasPrices))
+		})
+	}
+
+	// Without min gas prices, fees can't be compared.
+	assert.Zero(t, txPriority(std.NewFee(1_000, std.NewCoin("ugnot", 3)), nil))
+}
+
+// Test that the txs of a priority mempool are ranked by their gas price
+// relative to the min gas price of their denomination, so that txs paying
+// large amounts of a cheap denomination can't evict the others.
+func TestCheckTxPriorityMempool(t *testing.T) {
+	t.Parallel()
+
+	anteOpt := func(bapp *BaseApp) {
+		bapp.SetAnteHandler(func(ctx Context, tx std.Tx, simulate bool) (Context, Result, bool) {
+			return ctx, Result{}, false
+		})
+	}
+	routerOpt := func(bapp *BaseApp) {
+		bapp.Router().AddRoute(routeMsgCounter, newTestHandler(func(ctx Context, msg Msg) Result { return Result{} }))
+	}
+	minGasPricesOpt := SetMinGasPrices("1ugnot/1000gas;1000cheap/1gas")
+
+	app := setupBaseApp(t, anteOpt, routerOpt, minGasPricesOpt)
+	app.InitChain(abci.RequestInitChain{ChainID: "test-chain"})
+
+	appConn, err := proxy.NewLocalClientCreator(app).NewABCIClient()
+	require.NoError(t, err)
+	appConn.SetLogger(log.NewNoopLogger())
+	require.NoError(t, appConn.Start())
+	defer appConn.Stop()
+
+	config := mempoolcfg.TestMempoolConfig()
+	config.Type = mempoolcfg.TypePriority
+	config.Size = 2
+	mp := mempool.NewCListMempool(config, appConn, 0, 1<<20)
+	mp.SetLogger(log.NewNoopLogger())
+
+	checkTx := func(counter int64, fee std.Fee) error {
+		t.Helper()
+
+		tx := newTxCounter(counter, 0)
+		tx.Fee = fee
+		txBytes, err := amino.Marshal(tx)
+		require.NoError(t, err)
+
+		var res abci.ResponseCheckTx
+		require.NoError(t, mp.CheckTx(txBytes, func(r abci.Response) {
+			res = r.(abci.ResponseCheckTx)
+		}))
+		return res.Error
+	}
+
+	// Fill the mempool with ugnot txs, paying the min gas price and twice it.
+	require.NoError(t, checkTx(0, std.NewFee(1_000, std.NewCoin("ugnot", 1))))
+	require.NoError(t, checkTx(1, std.NewFee(1_000, std.NewCoin("ugnot", 2))))
+
+	// A large amount of a cheap denomination only pays its min gas price,
+	// and a denomination without a min gas price has no priority.
+	assert.Error(t, checkTx(2, std.NewFee(1_000, std.NewCoin("cheap", 1_000_000))))
+	assert.Error(t, checkTx(3, std.NewFee(1_000, std.NewCoin("unknown", math.MaxInt64))))
+	assert.Equal(t, 2, mp.Size())
+
+	// Paying more than the min gas price of a denomination evicts the
+	// lowest priced tx.
+	require.NoError(t, checkTx(4, std.NewFee(1_000, std.NewCoin("cheap", 3_000_000))))
+	assert.Equal(t, 2, mp.Size())
+	for _, tx := range mp.ReapMaxTxs(-1) {
+		var stdTx std.Tx
+		require.NoError(t, amino.Unmarshal(tx, &stdTx))
+		assert.NotEqual(t, int64(1), stdTx.Fee.GasFee.Amount)
+	}
+}
+
 // Test that successive DeliverTx can see each others' effects
 // on the store, both within and across blocks.
 func TestDeliverTx(t *testing.T) {
@@ -1030,6 +1144,55 @@ func TestBaseAppAnteHandler(t *testing.T) {
 	app.Commit()
 }
 
+func TestTxHooks(t *testing.T) {
+	t.Parallel()
+
+	type hookKey struct{}
+	var committed []bool
+	hooksOpt := func(bapp *BaseApp) {
+		bapp.SetBeginTxHook(func(ctx Context) Context {
+			return ctx.WithValue(hookKey{}, true)
+		})
+		bapp.SetEndTxHook(func(ctx Context, ok bool) {
+			require.Equal(t, true, ctx.Value(hookKey{}))
+			committed = append(committed, ok)
+		})
+	}
+
+	deliverKey := []byte("deliver-key")
+	routerOpt := func(bapp *BaseApp) {
+		bapp.Router().AddRoute(routeMsgCounter, newTestHandler(func(ctx Context, msg Msg) Result {
+			// the messages run with the context of the begin hook.
+			require.Equal(t, true, ctx.Value(hookKey{}))
+			if msg.(msgCounter).FailOnHandler {
+				panic("message handler panic")
+			}
+			return newMsgCounterHandler(t, mainKey, deliverKey).Process(ctx, msg)
+		}))
+	}
+
+	app := setupBaseApp(t, hooksOpt, routerOpt)
+	app.InitChain(abci.RequestInitChain{ChainID: "test-chain"})
+
+	header := &bft.Header{ChainID: "test-chain", Height: app.LastBlockHeight() + 1}
+	app.BeginBlock(abci.RequestBeginBlock{Header: header})
+
+	// A successful tx is committed, unless it is simulated.
+	tx := newTxCounter(0, 0)
+	res := app.Simulate(nil, tx)
+	require.True(t, res.IsOK(), fmt.Sprintf("%v", res))
+	res = app.Deliver(tx)
+	require.True(t, res.IsOK(), fmt.Sprintf("%v", res))
+
+	// A panicking tx is not.
+	tx = newTxCounter(1, 1)
+	setFailOnHandler(&tx, true)
+	res = app.Deliver(tx)
+	require.False(t, res.IsOK(), fmt.Sprintf("%v", res))
+
+	require.Equal(t, []bool{false, true, false}, committed)
+}
+
 func TestGasConsumptionBadTx(t *testing.T) {
 	t.Parallel()
 
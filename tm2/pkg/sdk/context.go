@@ -54,6 +54,17 @@ func (c Context) IsCheckTx() bool               { return c.mode == RunTxModeChec
 func (c Context) MinGasPrices() []GasPrice      { return c.minGasPrices }
 func (c Context) EventLogger() *EventLogger     { return c.eventLogger }
 
+// genesisKey is the context key marking the context of InitChain, and of the
+// genesis transactions.
+type genesisKey struct{}
+
+// IsGenesis returns true during InitChain, including for the genesis
+// transactions.
+func (c Context) IsGenesis() bool {
+	isGenesis, _ := c.ctx.Value(genesisKey{}).(bool)
+	return isGenesis
+}
+
 // clone the header before returning
 func (c Context) BlockHeader() abci.Header {
 	msg := amino.DeepCopy(&c.header).(*abci.Header)
@@ -0,0 +1,48 @@
+package distribution
+
+import (
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+)
+
+const (
+	// module name
+	ModuleName = "distribution"
+
+	// RewardsPoolName is the name of the account holding the rewards which
+	// have not been withdrawn yet.
+	RewardsPoolName = "distribution_rewards"
+
+	// CommunityPoolName is the name of the account receiving the community
+	// tax.
+	CommunityPoolName = "community_pool"
+
+	// StoreKeyPrefix is the prefix of pending rewards in the store.
+	StoreKeyPrefix = "/dr/"
+
+	// WithdrawAddressKeyPrefix is the prefix of the withdraw addresses of
+	// the validators in the store.
+	WithdrawAddressKeyPrefix = "/dw/"
+)
+
+// NOTE: do not modify.
+var (
+	rewardsPool   crypto.Address
+	communityPool crypto.Address
+)
+
+// RewardsPoolAddress returns the address of the account holding the rewards
+// which have not been withdrawn yet.
+func RewardsPoolAddress() crypto.Address {
+	if rewardsPool.IsZero() {
+		rewardsPool = crypto.AddressFromPreimage([]byte(RewardsPoolName))
+	}
+	return rewardsPool
+}
+
+// CommunityPoolAddress returns the address of the community pool.
+func CommunityPoolAddress() crypto.Address {
+	if communityPool.IsZero() {
+		communityPool = crypto.AddressFromPreimage([]byte(CommunityPoolName))
+	}
+	return communityPool
+}
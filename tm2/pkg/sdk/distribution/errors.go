@@ -0,0 +1,28 @@
+package distribution
+
+import (
+	"github.com/gnolang/gno/tm2/pkg/errors"
+)
+
+// for convenience:
+type abciError struct{}
+
+func (abciError) AssertABCIError() {}
+
+// declare all distribution errors.
+// NOTE: these are meant to be used in conjunction with pkgs/errors.
+type NoRewardsError struct{ abciError }
+
+func (e NoRewardsError) Error() string { return "no rewards to withdraw" }
+
+func ErrNoRewards(msg string) error {
+	return errors.Wrap(NoRewardsError{}, msg)
+}
+
+type NoWithdrawAddressError struct{ abciError }
+
+func (e NoWithdrawAddressError) Error() string { return "no withdraw address" }
+
+func ErrNoWithdrawAddress(msg string) error {
+	return errors.Wrap(NoWithdrawAddressError{}, msg)
+}
@@ -0,0 +1,128 @@
+package distribution
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gnolang/gno/tm2/pkg/amino"
+	abci "github.com/gnolang/gno/tm2/pkg/bft/abci/types"
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+	"github.com/gnolang/gno/tm2/pkg/sdk"
+	"github.com/gnolang/gno/tm2/pkg/std"
+)
+
+type distributionHandler struct {
+	distr DistributionKeeper
+}
+
+// NewHandler returns a handler for "distribution" type messages.
+func NewHandler(distr DistributionKeeper) distributionHandler {
+	return distributionHandler{
+		distr: distr,
+	}
+}
+
+func (dh distributionHandler) Process(ctx sdk.Context, msg std.Msg) sdk.Result {
+	switch msg := msg.(type) {
+	case MsgWithdrawRewards:
+		return dh.handleMsgWithdrawRewards(ctx, msg)
+
+	default:
+		errMsg := fmt.Sprintf("unrecognized distribution message type: %T", msg)
+		return abciResult(std.ErrUnknownRequest(errMsg))
+	}
+}
+
+// Handle MsgWithdrawRewards.
+func (dh distributionHandler) handleMsgWithdrawRewards(ctx sdk.Context, msg MsgWithdrawRewards) sdk.Result {
+	if withdrawAddr := dh.distr.GetWithdrawAddress(ctx, msg.Validator); withdrawAddr != msg.WithdrawAddress {
+		return abciResult(std.ErrUnauthorized(fmt.Sprintf(
+			"%s is not the withdraw address of validator %s", msg.WithdrawAddress, msg.Validator)))
+	}
+	rewards, err := dh.distr.WithdrawRewards(ctx, msg.Validator)
+	if err != nil {
+		return abciResult(err)
+	}
+
+	dh.distr.Logger(ctx).Info("rewards withdrawn", "validator", msg.Validator, "rewards", rewards)
+	return sdk.Result{}
+}
+
+//----------------------------------------
+// Query
+
+// query paths.
+const (
+	QueryRewards       = "rewards"
+	QueryCommunityPool = "community_pool"
+)
+
+// Query returns the pending rewards of a validator for path
+// "distribution/rewards/<address>", or the balance of the community pool for
+// path "distribution/community_pool".
+func (dh distributionHandler) Query(ctx sdk.Context, req abci.RequestQuery) (res abci.ResponseQuery) {
+	switch secondPart(req.Path) {
+	case QueryRewards:
+		return dh.queryRewards(ctx, req)
+	case QueryCommunityPool:
+		return dh.queryCoins(dh.distr.bank.GetCoins(ctx, CommunityPoolAddress()))
+	default:
+		res = sdk.ABCIResponseQueryFromError(
+			std.ErrUnknownRequest("unknown distribution query endpoint"))
+		return
+	}
+}
+
+// queryRewards fetch the pending rewards of a validator.
+// Validator address is passed as path component.
+func (dh distributionHandler) queryRewards(ctx sdk.Context, req abci.RequestQuery) (res abci.ResponseQuery) {
+	// parse addr from path.
+	b32addr := thirdPart(req.Path)
+	addr, err := crypto.AddressFromBech32(b32addr)
+	if err != nil {
+		res = sdk.ABCIResponseQueryFromError(
+			std.ErrInvalidAddress("invalid query address " + b32addr))
+		return
+	}
+
+	return dh.queryCoins(dh.distr.GetRewards(ctx, addr))
+}
+
+func (dh distributionHandler) queryCoins(coins std.Coins) (res abci.ResponseQuery) {
+	bz, err := amino.MarshalJSONIndent(coins, "", "  ")
+	if err != nil {
+		res = sdk.ABCIResponseQueryFromError(
+			std.ErrInternal(fmt.Sprintf("could not marshal result to JSON: %s", err.Error())))
+		return
+	}
+
+	res.Data = bz
+	return
+}
+
+//----------------------------------------
+// misc
+
+func abciResult(err error) sdk.Result {
+	return sdk.ABCIResultFromError(err)
+}
+
+// returns the second component of a path.
+func secondPart(path string) string {
+	parts := strings.Split(path, "/")
+	if len(parts) < 2 {
+		return ""
+	} else {
+		return parts[1]
+	}
+}
+
+// returns the third component of a path.
+func thirdPart(path string) string {
+	parts := strings.Split(path, "/")
+	if len(parts) < 3 {
+		return ""
+	} else {
+		return parts[2]
+	}
+}
@@ -0,0 +1,89 @@
+package distribution
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gnolang/gno/tm2/pkg/amino"
+	abci "github.com/gnolang/gno/tm2/pkg/bft/abci/types"
+	bft "github.com/gnolang/gno/tm2/pkg/bft/types"
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+	"github.com/gnolang/gno/tm2/pkg/sdk"
+	"github.com/gnolang/gno/tm2/pkg/sdk/auth"
+	tu "github.com/gnolang/gno/tm2/pkg/sdk/testutils"
+	"github.com/gnolang/gno/tm2/pkg/std"
+)
+
+func TestInvalidMsg(t *testing.T) {
+	t.Parallel()
+
+	h := NewHandler(DistributionKeeper{})
+	res := h.Process(sdk.NewContext(sdk.RunTxModeDeliver, nil, &bft.Header{ChainID: "test-chain"}, nil), tu.NewTestMsg())
+	require.False(t, res.IsOK())
+	require.True(t, strings.Contains(res.Log, "unrecognized distribution message type"))
+}
+
+func TestWithdrawRewardsMsg(t *testing.T) {
+	t.Parallel()
+
+	env := setupTestEnv()
+	h := NewHandler(env.distr)
+	_, _, val := tu.KeyTestPubAddr()
+	_, _, operator := tu.KeyTestPubAddr()
+	msg := NewMsgWithdrawRewards(val, operator)
+	require.Equal(t, []crypto.Address{operator}, msg.GetSigners())
+
+	ctx := env.ctx.WithVoteInfos([]abci.VoteInfo{{Address: val, Power: 1, SignedLastBlock: true}})
+	require.NoError(t, env.bank.SetCoins(ctx, auth.FeeCollectorAddress(), std.MustParseCoins("100foo")))
+	env.distr.DistributeFees(ctx)
+
+	// Only the withdraw address of the validator may withdraw its rewards.
+	res := h.Process(ctx, msg)
+	require.False(t, res.IsOK())
+	require.True(t, strings.Contains(res.Log, "is not the withdraw address"), res.Log)
+
+	env.distr.SetWithdrawAddress(ctx, val, operator)
+	res = h.Process(ctx, msg)
+	require.True(t, res.IsOK(), res.Log)
+	assert.Equal(t, std.MustParseCoins("98foo"), env.bank.GetCoins(ctx, operator))
+
+	res = h.Process(ctx, msg)
+	require.False(t, res.IsOK())
+}
+
+func TestQueryRewards(t *testing.T) {
+	t.Parallel()
+
+	env := setupTestEnv()
+	h := NewHandler(env.distr)
+	_, _, addr := tu.KeyTestPubAddr()
+
+	query := func(path string) std.Coins {
+		res := h.Query(env.ctx, abci.RequestQuery{Path: path})
+		require.Nil(t, res.Error)
+		var coins std.Coins
+		require.NoError(t, amino.UnmarshalJSON(res.Data, &coins))
+		return coins
+	}
+	rewardsPath := fmt.Sprintf("distribution/%s/%s", QueryRewards, addr)
+	poolPath := fmt.Sprintf("distribution/%s", QueryCommunityPool)
+
+	assert.True(t, query(rewardsPath).IsZero())
+	assert.True(t, query(poolPath).IsZero())
+
+	env.ctx = env.ctx.WithVoteInfos([]abci.VoteInfo{{Address: addr, Power: 1, SignedLastBlock: true}})
+	require.NoError(t, env.bank.SetCoins(env.ctx, auth.FeeCollectorAddress(), std.MustParseCoins("100foo")))
+	env.distr.DistributeFees(env.ctx)
+
+	assert.Equal(t, std.MustParseCoins("98foo"), query(rewardsPath))
+	assert.Equal(t, std.MustParseCoins("2foo"), query(poolPath))
+
+	res := h.Query(env.ctx, abci.RequestQuery{Path: "distribution/rewards/invalid"})
+	assert.NotNil(t, res.Error)
+	res = h.Query(env.ctx, abci.RequestQuery{Path: "distribution/unknown"})
+	assert.NotNil(t, res.Error)
+}
@@ -0,0 +1,223 @@
+package distribution
+
+import (
+	"fmt"
+	"log/slog"
+	"math/big"
+
+	"github.com/gnolang/gno/tm2/pkg/amino"
+	bft "github.com/gnolang/gno/tm2/pkg/bft/types"
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+	"github.com/gnolang/gno/tm2/pkg/sdk"
+	"github.com/gnolang/gno/tm2/pkg/sdk/auth"
+	"github.com/gnolang/gno/tm2/pkg/sdk/bank"
+	"github.com/gnolang/gno/tm2/pkg/sdk/params"
+	"github.com/gnolang/gno/tm2/pkg/std"
+	"github.com/gnolang/gno/tm2/pkg/store"
+)
+
+// DistributionKeeperI is the interface of the distribution keeper.
+type DistributionKeeperI interface {
+	GetParams(ctx sdk.Context) Params
+	GetRewards(ctx sdk.Context, addr crypto.Address) std.Coins
+	GetWithdrawAddress(ctx sdk.Context, addr crypto.Address) crypto.Address
+	SetWithdrawAddress(ctx sdk.Context, addr, withdrawAddr crypto.Address)
+	WithdrawRewards(ctx sdk.Context, addr crypto.Address) (std.Coins, error)
+	DistributeFees(ctx sdk.Context)
+}
+
+var _ DistributionKeeperI = DistributionKeeper{}
+
+// DistributionKeeper distributes the fees collected by the fee collector to
+// the validators and the community pool. The rewards of the validators are
+// held by the rewards pool until they are withdrawn to the account registered
+// as their withdraw address.
+type DistributionKeeper struct {
+	// The (unexposed) key used to access the store from the Context.
+	key  store.StoreKey
+	bank bank.BankKeeperI
+	prmk params.ParamsKeeperI
+}
+
+// NewDistributionKeeper returns a new DistributionKeeper. If prmk is nil, the
+// default params are used.
+func NewDistributionKeeper(key store.StoreKey, bank bank.BankKeeperI, prmk params.ParamsKeeperI) DistributionKeeper {
+	return DistributionKeeper{
+		key:  key,
+		bank: bank,
+		prmk: prmk,
+	}
+}
+
+// Logger returns a module-specific logger.
+func (dk DistributionKeeper) Logger(ctx sdk.Context) *slog.Logger {
+	return ctx.Logger().With("module", ModuleName)
+}
+
+// GetParams returns the distribution params set on chain, or the defaults.
+func (dk DistributionKeeper) GetParams(ctx sdk.Context) Params {
+	params := DefaultParams()
+	if dk.prmk != nil {
+		dk.prmk.GetParam(ctx, ParamsKey, &params)
+	}
+	return params
+}
+
+// GetRewards returns the pending rewards of the validator addr.
+func (dk DistributionKeeper) GetRewards(ctx sdk.Context, addr crypto.Address) std.Coins {
+	stor := ctx.Store(dk.key)
+	bz := stor.Get(rewardsKey(addr))
+	if bz == nil {
+		return nil
+	}
+	var rewards std.Coins
+	amino.MustUnmarshal(bz, &rewards)
+	return rewards
+}
+
+// IterateRewards calls process on the pending rewards of each validator,
+// until process returns true.
+func (dk DistributionKeeper) IterateRewards(ctx sdk.Context, process func(crypto.Address, std.Coins) bool) {
+	stor := ctx.Store(dk.key)
+	iter := store.PrefixIterator(stor, []byte(StoreKeyPrefix))
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		addr := crypto.AddressFromBytes(iter.Key()[len(StoreKeyPrefix):])
+		var rewards std.Coins
+		amino.MustUnmarshal(iter.Value(), &rewards)
+		if process(addr, rewards) {
+			return
+		}
+	}
+}
+
+// GetWithdrawAddress returns the account receiving the rewards of the
+// validator addr, or the zero address if none is registered.
+func (dk DistributionKeeper) GetWithdrawAddress(ctx sdk.Context, addr crypto.Address) crypto.Address {
+	stor := ctx.Store(dk.key)
+	bz := stor.Get(withdrawAddressKey(addr))
+	if bz == nil {
+		return crypto.Address{}
+	}
+	return crypto.AddressFromBytes(bz)
+}
+
+// SetWithdrawAddress registers withdrawAddr as the account receiving the
+// rewards of the validator addr. The consensus address of a validator has no
+// account, and can't sign the withdrawal of its rewards.
+func (dk DistributionKeeper) SetWithdrawAddress(ctx sdk.Context, addr, withdrawAddr crypto.Address) {
+	ctx.Store(dk.key).Set(withdrawAddressKey(addr), withdrawAddr.Bytes())
+}
+
+// WithdrawRewards sends the pending rewards of the validator addr from the
+// rewards pool to its withdraw address, and returns them.
+func (dk DistributionKeeper) WithdrawRewards(ctx sdk.Context, addr crypto.Address) (std.Coins, error) {
+	withdrawAddr := dk.GetWithdrawAddress(ctx, addr)
+	if withdrawAddr.IsZero() {
+		return nil, ErrNoWithdrawAddress(addr.String())
+	}
+	rewards := dk.GetRewards(ctx, addr)
+	if rewards.IsZero() {
+		return nil, ErrNoRewards(addr.String())
+	}
+	if err := dk.bank.SendCoins(ctx, RewardsPoolAddress(), withdrawAddr, rewards); err != nil {
+		return nil, err
+	}
+	ctx.Store(dk.key).Delete(rewardsKey(addr))
+	return rewards, nil
+}
+
+// DistributeFees distributes the fees held by the fee collector, and is
+// called at the end of each block. The block proposer receives the
+// ProposerReward share, the community pool the CommunityTax share, and the
+// rest is split between the validators which signed the last block,
+// proportionally to their voting power. The amounts lost to rounding go to
+// the community pool, as do the validators' share if none signed.
+func (dk DistributionKeeper) DistributeFees(ctx sdk.Context) {
+	fees := dk.bank.GetCoins(ctx, auth.FeeCollectorAddress())
+	if fees.IsZero() {
+		return
+	}
+	params := dk.GetParams(ctx)
+
+	community := mulDiv(fees, params.CommunityTax, 100)
+	var proposerReward std.Coins
+	if proposer := proposerAddress(ctx); !proposer.IsZero() {
+		proposerReward = mulDiv(fees, params.ProposerReward, 100)
+		dk.addRewards(ctx, proposer, proposerReward)
+	}
+
+	var totalPower int64
+	for _, vote := range ctx.VoteInfos() {
+		if vote.SignedLastBlock && vote.Power > 0 {
+			totalPower += vote.Power
+		}
+	}
+	validatorsReward := fees.Sub(community).Sub(proposerReward)
+	var distributed std.Coins
+	if totalPower > 0 {
+		for _, vote := range ctx.VoteInfos() {
+			if !vote.SignedLastBlock || vote.Power <= 0 {
+				continue
+			}
+			reward := mulDiv(validatorsReward, vote.Power, totalPower)
+			dk.addRewards(ctx, vote.Address, reward)
+			distributed = distributed.Add(reward)
+		}
+	}
+	community = community.Add(validatorsReward.Sub(distributed))
+
+	// Move the fees out of the fee collector.
+	dk.sendFees(ctx, CommunityPoolAddress(), community)
+	dk.sendFees(ctx, RewardsPoolAddress(), fees.Sub(community))
+}
+
+// sendFees sends amt from the fee collector to addr.
+func (dk DistributionKeeper) sendFees(ctx sdk.Context, addr crypto.Address, amt std.Coins) {
+	if amt.IsZero() {
+		return
+	}
+	if err := dk.bank.SendCoins(ctx, auth.FeeCollectorAddress(), addr, amt); err != nil {
+		// the fee collector holds at least the fees.
+		panic(fmt.Sprintf("unable to send fees to %s: %v", addr, err))
+	}
+}
+
+// addRewards adds amt to the pending rewards of the validator addr.
+func (dk DistributionKeeper) addRewards(ctx sdk.Context, addr crypto.Address, amt std.Coins) {
+	if amt.IsZero() {
+		return
+	}
+	rewards := dk.GetRewards(ctx, addr).Add(amt)
+	ctx.Store(dk.key).Set(rewardsKey(addr), amino.MustMarshal(rewards))
+}
+
+func rewardsKey(addr crypto.Address) []byte {
+	return append([]byte(StoreKeyPrefix), addr.Bytes()...)
+}
+
+func withdrawAddressKey(addr crypto.Address) []byte {
+	return append([]byte(WithdrawAddressKeyPrefix), addr.Bytes()...)
+}
+
+// proposerAddress returns the proposer of the current block, or the zero
+// address if the header does not have one.
+func proposerAddress(ctx sdk.Context) crypto.Address {
+	if header, ok := ctx.BlockHeader().(*bft.Header); ok {
+		return header.ProposerAddress
+	}
+	return crypto.Address{}
+}
+
+// mulDiv returns coins * num / den, rounded down, without overflowing.
+func mulDiv(coins std.Coins, num, den int64) std.Coins {
+	var res std.Coins
+	for _, coin := range coins {
+		amt := new(big.Int).Mul(big.NewInt(coin.Amount), big.NewInt(num))
+		amt.Quo(amt, big.NewInt(den))
+		if amt.Sign() > 0 {
+			res = append(res, std.NewCoin(coin.Denom, amt.Int64()))
+		}
+	}
+	return res
+}
@@ -0,0 +1,137 @@
+package distribution
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	abci "github.com/gnolang/gno/tm2/pkg/bft/abci/types"
+	bft "github.com/gnolang/gno/tm2/pkg/bft/types"
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+	"github.com/gnolang/gno/tm2/pkg/sdk/auth"
+	"github.com/gnolang/gno/tm2/pkg/std"
+)
+
+func TestDistributeFees(t *testing.T) {
+	t.Parallel()
+
+	var (
+		val1 = crypto.AddressFromPreimage([]byte("val1"))
+		val2 = crypto.AddressFromPreimage([]byte("val2"))
+		val3 = crypto.AddressFromPreimage([]byte("val3"))
+	)
+
+	env := setupTestEnv()
+	ctx := env.ctx.
+		WithBlockHeader(&bft.Header{ChainID: "test-chain-id", Height: 2, ProposerAddress: val1}).
+		WithVoteInfos([]abci.VoteInfo{
+			{Address: val1, Power: 1, SignedLastBlock: true},
+			{Address: val2, Power: 2, SignedLastBlock: true},
+			{Address: val3, Power: 3, SignedLastBlock: false},
+		})
+	require.NoError(t, env.bank.SetCoins(ctx, auth.FeeCollectorAddress(), std.MustParseCoins("1000foo")))
+
+	env.distr.DistributeFees(ctx)
+
+	// 2% to the community pool, 5% to the proposer, and the 930 left split
+	// 1:2 between the signers.
+	assert.Equal(t, std.MustParseCoins("360foo"), env.distr.GetRewards(ctx, val1))
+	assert.Equal(t, std.MustParseCoins("620foo"), env.distr.GetRewards(ctx, val2))
+	assert.True(t, env.distr.GetRewards(ctx, val3).IsZero())
+	assert.Equal(t, std.MustParseCoins("20foo"), env.bank.GetCoins(ctx, CommunityPoolAddress()))
+	assert.Equal(t, std.MustParseCoins("980foo"), env.bank.GetCoins(ctx, RewardsPoolAddress()))
+	assert.True(t, env.bank.GetCoins(ctx, auth.FeeCollectorAddress()).IsZero())
+
+	// Rewards accrue over blocks.
+	require.NoError(t, env.bank.SetCoins(ctx, auth.FeeCollectorAddress(), std.MustParseCoins("100foo")))
+	env.distr.DistributeFees(ctx)
+	assert.Equal(t, std.MustParseCoins("396foo"), env.distr.GetRewards(ctx, val1))
+	assert.Equal(t, std.MustParseCoins("682foo"), env.distr.GetRewards(ctx, val2))
+	assert.Equal(t, std.MustParseCoins("22foo"), env.bank.GetCoins(ctx, CommunityPoolAddress()))
+
+	var total std.Coins
+	env.distr.IterateRewards(ctx, func(_ crypto.Address, rewards std.Coins) bool {
+		total = total.Add(rewards)
+		return false
+	})
+	assert.Equal(t, env.bank.GetCoins(ctx, RewardsPoolAddress()), total)
+}
+
+func TestDistributeFees_Remainder(t *testing.T) {
+	t.Parallel()
+
+	var (
+		val1 = crypto.AddressFromPreimage([]byte("val1"))
+		val2 = crypto.AddressFromPreimage([]byte("val2"))
+	)
+
+	env := setupTestEnv()
+	env.params.SetParam(env.ctx, ParamsKey, Params{ProposerReward: 0, CommunityTax: 0})
+	ctx := env.ctx.WithVoteInfos([]abci.VoteInfo{
+		{Address: val1, Power: 1, SignedLastBlock: true},
+		{Address: val2, Power: 1, SignedLastBlock: true},
+	})
+	require.NoError(t, env.bank.SetCoins(ctx, auth.FeeCollectorAddress(), std.MustParseCoins("11foo")))
+
+	env.distr.DistributeFees(ctx)
+
+	// The amount lost to rounding goes to the community pool.
+	assert.Equal(t, std.MustParseCoins("5foo"), env.distr.GetRewards(ctx, val1))
+	assert.Equal(t, std.MustParseCoins("5foo"), env.distr.GetRewards(ctx, val2))
+	assert.Equal(t, std.MustParseCoins("1foo"), env.bank.GetCoins(ctx, CommunityPoolAddress()))
+}
+
+func TestDistributeFees_NoSigners(t *testing.T) {
+	t.Parallel()
+
+	env := setupTestEnv()
+	require.NoError(t, env.bank.SetCoins(env.ctx, auth.FeeCollectorAddress(), std.MustParseCoins("100foo")))
+
+	env.distr.DistributeFees(env.ctx)
+
+	assert.Equal(t, std.MustParseCoins("100foo"), env.bank.GetCoins(env.ctx, CommunityPoolAddress()))
+	assert.True(t, env.bank.GetCoins(env.ctx, RewardsPoolAddress()).IsZero())
+}
+
+func TestWithdrawRewards(t *testing.T) {
+	t.Parallel()
+
+	var (
+		val      = crypto.AddressFromPreimage([]byte("val"))
+		operator = crypto.AddressFromPreimage([]byte("operator"))
+	)
+
+	env := setupTestEnv()
+	ctx := env.ctx.WithVoteInfos([]abci.VoteInfo{{Address: val, Power: 1, SignedLastBlock: true}})
+	require.NoError(t, env.bank.SetCoins(ctx, auth.FeeCollectorAddress(), std.MustParseCoins("100foo")))
+	env.distr.DistributeFees(ctx)
+
+	// The rewards can't be withdrawn without a withdraw address.
+	_, err := env.distr.WithdrawRewards(ctx, val)
+	assert.ErrorIs(t, err, NoWithdrawAddressError{})
+
+	env.distr.SetWithdrawAddress(ctx, val, operator)
+	assert.Equal(t, operator, env.distr.GetWithdrawAddress(ctx, val))
+
+	rewards, err := env.distr.WithdrawRewards(ctx, val)
+	require.NoError(t, err)
+	assert.Equal(t, std.MustParseCoins("98foo"), rewards)
+	assert.Equal(t, rewards, env.bank.GetCoins(ctx, operator))
+	assert.True(t, env.bank.GetCoins(ctx, val).IsZero())
+	assert.True(t, env.distr.GetRewards(ctx, val).IsZero())
+	assert.True(t, env.bank.GetCoins(ctx, RewardsPoolAddress()).IsZero())
+
+	_, err = env.distr.WithdrawRewards(ctx, val)
+	assert.ErrorIs(t, err, NoRewardsError{})
+}
+
+func TestParamsValidate(t *testing.T) {
+	t.Parallel()
+
+	assert.NoError(t, DefaultParams().Validate())
+	assert.NoError(t, Params{ProposerReward: 60, CommunityTax: 40}.Validate())
+	assert.Error(t, Params{ProposerReward: -1}.Validate())
+	assert.Error(t, Params{CommunityTax: 101}.Validate())
+	assert.Error(t, Params{ProposerReward: 60, CommunityTax: 41}.Validate())
+}
@@ -0,0 +1,52 @@
+package distribution
+
+import (
+	"github.com/gnolang/gno/tm2/pkg/amino"
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+	"github.com/gnolang/gno/tm2/pkg/std"
+)
+
+// RouterKey is the name of the distribution module
+const RouterKey = ModuleName
+
+// MsgWithdrawRewards - withdraw the pending rewards of a validator to its
+// withdraw address, which signs the msg.
+type MsgWithdrawRewards struct {
+	Validator       crypto.Address `json:"validator" yaml:"validator"`
+	WithdrawAddress crypto.Address `json:"withdraw_address" yaml:"withdraw_address"`
+}
+
+var _ std.Msg = MsgWithdrawRewards{}
+
+// NewMsgWithdrawRewards - construct a msg withdrawing the rewards of validator
+// to withdrawAddr.
+func NewMsgWithdrawRewards(validator, withdrawAddr crypto.Address) MsgWithdrawRewards {
+	return MsgWithdrawRewards{Validator: validator, WithdrawAddress: withdrawAddr}
+}
+
+// Route Implements Msg.
+func (msg MsgWithdrawRewards) Route() string { return RouterKey }
+
+// Type Implements Msg.
+func (msg MsgWithdrawRewards) Type() string { return "withdraw_rewards" }
+
+// ValidateBasic Implements Msg.
+func (msg MsgWithdrawRewards) ValidateBasic() error {
+	if msg.Validator.IsZero() {
+		return std.ErrInvalidAddress("missing validator address")
+	}
+	if msg.WithdrawAddress.IsZero() {
+		return std.ErrInvalidAddress("missing withdraw address")
+	}
+	return nil
+}
+
+// GetSignBytes Implements Msg.
+func (msg MsgWithdrawRewards) GetSignBytes() []byte {
+	return std.MustSortJSON(amino.MustMarshalJSON(msg))
+}
+
+// GetSigners Implements Msg.
+func (msg MsgWithdrawRewards) GetSigners() []crypto.Address {
+	return []crypto.Address{msg.WithdrawAddress}
+}
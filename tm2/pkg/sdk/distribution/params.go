@@ -0,0 +1,55 @@
+package distribution
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gnolang/gno/tm2/pkg/amino"
+)
+
+// ParamsKey is the key of the distribution params in the params keeper.
+const ParamsKey = "distribution.params"
+
+// Params defines the parameters for the distribution module. Rates are
+// percentages of the fees collected during a block.
+type Params struct {
+	ProposerReward int64 `json:"proposer_reward" yaml:"proposer_reward"` // bonus of the block proposer.
+	CommunityTax   int64 `json:"community_tax" yaml:"community_tax"`     // sent to the community pool.
+}
+
+// DefaultParams returns the default distribution params. The fees left after
+// the proposer reward and the community tax go to the validators.
+func DefaultParams() Params {
+	return Params{
+		ProposerReward: 5,
+		CommunityTax:   2,
+	}
+}
+
+// Equals returns a boolean determining if two Params types are identical.
+func (p Params) Equals(p2 Params) bool {
+	return amino.DeepEqual(p, p2)
+}
+
+// Validate implements params.Validator.
+func (p Params) Validate() error {
+	if p.ProposerReward < 0 || p.ProposerReward > 100 {
+		return fmt.Errorf("invalid proposer reward: %d", p.ProposerReward)
+	}
+	if p.CommunityTax < 0 || p.CommunityTax > 100 {
+		return fmt.Errorf("invalid community tax: %d", p.CommunityTax)
+	}
+	if p.ProposerReward+p.CommunityTax > 100 {
+		return fmt.Errorf("proposer reward and community tax exceed 100%%: %d", p.ProposerReward+p.CommunityTax)
+	}
+	return nil
+}
+
+// String implements the stringer interface.
+func (p Params) String() string {
+	var sb strings.Builder
+	sb.WriteString("Params: \n")
+	sb.WriteString(fmt.Sprintf("ProposerReward: %d\n", p.ProposerReward))
+	sb.WriteString(fmt.Sprintf("CommunityTax: %d\n", p.CommunityTax))
+	return sb.String()
+}
@@ -100,3 +100,17 @@ func (app *BaseApp) SetAnteHandler(ah AnteHandler) {
 	}
 	app.anteHandler = ah
 }
+
+func (app *BaseApp) SetBeginTxHook(beginTxHook BeginTxHook) {
+	if app.sealed {
+		panic("SetBeginTxHook() on sealed BaseApp")
+	}
+	app.beginTxHook = beginTxHook
+}
+
+func (app *BaseApp) SetEndTxHook(endTxHook EndTxHook) {
+	if app.sealed {
+		panic("SetEndTxHook() on sealed BaseApp")
+	}
+	app.endTxHook = endTxHook
+}
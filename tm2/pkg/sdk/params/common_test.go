@@ -0,0 +1,48 @@
+package params
+
+// DONTCOVER
+
+import (
+	bft "github.com/gnolang/gno/tm2/pkg/bft/types"
+	"github.com/gnolang/gno/tm2/pkg/db/memdb"
+	"github.com/gnolang/gno/tm2/pkg/log"
+
+	"github.com/gnolang/gno/tm2/pkg/sdk"
+	"github.com/gnolang/gno/tm2/pkg/store"
+	"github.com/gnolang/gno/tm2/pkg/store/iavl"
+)
+
+type testEnv struct {
+	ctx    sdk.Context
+	params ParamsKeeper
+}
+
+// testParams is a registered param type validating its values.
+type testParams struct {
+	Limit int64  `json:"limit"`
+	Name  string `json:"name"`
+}
+
+func (p testParams) Validate() error {
+	if p.Limit < 0 {
+		return ErrInvalidParamValue("negative limit")
+	}
+	return nil
+}
+
+func setupTestEnv() testEnv {
+	db := memdb.NewMemDB()
+
+	paramsCapKey := store.NewStoreKey("paramsCapKey")
+
+	ms := store.NewCommitMultiStore(db)
+	ms.MountStoreWithDB(paramsCapKey, iavl.StoreConstructor, db)
+	ms.LoadLatestVersion()
+
+	ctx := sdk.NewContext(sdk.RunTxModeDeliver, ms, &bft.Header{ChainID: "test-chain-id"}, log.NewNoopLogger())
+	params := NewParamsKeeper(paramsCapKey, StoreKeyPrefix)
+	params.Register("test.params", testParams{})
+	params.Register("test.name", "")
+
+	return testEnv{ctx: ctx, params: params}
+}
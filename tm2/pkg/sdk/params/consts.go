@@ -0,0 +1,12 @@
+package params
+
+const (
+	// module name
+	ModuleName = "params"
+
+	// StoreKeyPrefix is the default prefix of param keys in the store.
+	StoreKeyPrefix = "/pv/"
+
+	// AuthorityKey is the param holding the address allowed to update params.
+	AuthorityKey = "params.authority"
+)
@@ -0,0 +1,28 @@
+package params
+
+import (
+	"github.com/gnolang/gno/tm2/pkg/errors"
+)
+
+// for convenience:
+type abciError struct{}
+
+func (abciError) AssertABCIError() {}
+
+// declare all params errors.
+// NOTE: these are meant to be used in conjunction with pkgs/errors.
+type (
+	UnknownParamError      struct{ abciError }
+	InvalidParamValueError struct{ abciError }
+)
+
+func (e UnknownParamError) Error() string      { return "unknown param" }
+func (e InvalidParamValueError) Error() string { return "invalid param value" }
+
+func ErrUnknownParam(msg string) error {
+	return errors.Wrap(UnknownParamError{}, msg)
+}
+
+func ErrInvalidParamValue(msg string) error {
+	return errors.Wrap(InvalidParamValueError{}, msg)
+}
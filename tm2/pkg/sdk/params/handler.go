@@ -0,0 +1,102 @@
+package params
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gnolang/gno/tm2/pkg/amino"
+	abci "github.com/gnolang/gno/tm2/pkg/bft/abci/types"
+	"github.com/gnolang/gno/tm2/pkg/sdk"
+	"github.com/gnolang/gno/tm2/pkg/std"
+)
+
+type paramsHandler struct {
+	params ParamsKeeper
+}
+
+// NewHandler returns a handler for "params" type messages.
+func NewHandler(params ParamsKeeper) paramsHandler {
+	return paramsHandler{
+		params: params,
+	}
+}
+
+func (ph paramsHandler) Process(ctx sdk.Context, msg std.Msg) sdk.Result {
+	switch msg := msg.(type) {
+	case MsgSetParam:
+		return ph.handleMsgSetParam(ctx, msg)
+
+	default:
+		errMsg := fmt.Sprintf("unrecognized params message type: %T", msg)
+		return abciResult(std.ErrUnknownRequest(errMsg))
+	}
+}
+
+// Handle MsgSetParam.
+func (ph paramsHandler) handleMsgSetParam(ctx sdk.Context, msg MsgSetParam) sdk.Result {
+	authority := ph.params.GetAuthority(ctx)
+	if authority.IsZero() || authority != msg.Caller {
+		return abciResult(std.ErrUnauthorized(
+			fmt.Sprintf("%s is not the params authority", msg.Caller)))
+	}
+
+	err := ph.params.SetParamJSON(ctx, msg.Key, []byte(msg.Value))
+	if err != nil {
+		return abciResult(err)
+	}
+
+	ph.params.Logger(ctx).Info("param updated", "key", msg.Key, "value", msg.Value)
+	return sdk.Result{}
+}
+
+//----------------------------------------
+// Query
+
+// query the registered param keys.
+const QueryKeys = "keys"
+
+// Query returns the amino JSON encoded param for path "params/<key>",
+// or the list of registered keys for path "params/keys".
+func (ph paramsHandler) Query(ctx sdk.Context, req abci.RequestQuery) (res abci.ResponseQuery) {
+	key := secondPart(req.Path)
+	switch key {
+	case "":
+		res = sdk.ABCIResponseQueryFromError(
+			std.ErrUnknownRequest("missing param key"))
+		return
+	case QueryKeys:
+		bz, err := amino.MarshalJSONIndent(ph.params.Keys(), "", "  ")
+		if err != nil {
+			res = sdk.ABCIResponseQueryFromError(
+				std.ErrInternal(fmt.Sprintf("could not marshal result to JSON: %s", err.Error())))
+			return
+		}
+		res.Data = bz
+		return
+	}
+
+	if _, ok := ph.params.types[key]; !ok {
+		res = sdk.ABCIResponseQueryFromError(ErrUnknownParam(key))
+		return
+	}
+	// an unset param returns no data.
+	res.Data = ph.params.GetParamJSON(ctx, key)
+	return
+}
+
+//----------------------------------------
+// misc
+
+func abciResult(err error) sdk.Result {
+	return sdk.ABCIResultFromError(err)
+}
+
+// returns the second component of a path.
+func secondPart(path string) string {
+	parts := strings.Split(path, "/")
+	if len(parts) < 2 {
+		return ""
+	} else {
+		return parts[1]
+	}
+}
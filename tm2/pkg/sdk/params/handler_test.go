@@ -0,0 +1,87 @@
+package params
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/gnolang/gno/tm2/pkg/amino"
+	abci "github.com/gnolang/gno/tm2/pkg/bft/abci/types"
+	bft "github.com/gnolang/gno/tm2/pkg/bft/types"
+	"github.com/gnolang/gno/tm2/pkg/sdk"
+	tu "github.com/gnolang/gno/tm2/pkg/sdk/testutils"
+	"github.com/gnolang/gno/tm2/pkg/std"
+)
+
+func TestInvalidMsg(t *testing.T) {
+	t.Parallel()
+
+	h := NewHandler(ParamsKeeper{})
+	res := h.Process(sdk.NewContext(sdk.RunTxModeDeliver, nil, &bft.Header{ChainID: "test-chain"}, nil), tu.NewTestMsg())
+	require.False(t, res.IsOK())
+	require.True(t, strings.Contains(res.Log, "unrecognized params message type"))
+}
+
+func TestSetParam(t *testing.T) {
+	t.Parallel()
+
+	env := setupTestEnv()
+	h := NewHandler(env.params)
+	_, _, authority := tu.KeyTestPubAddr()
+	_, _, other := tu.KeyTestPubAddr()
+	msg := NewMsgSetParam(authority, "test.name", `"foo"`)
+
+	// No authority: params can't be updated.
+	res := h.Process(env.ctx, msg)
+	require.False(t, res.IsOK())
+	_, ok := res.Error.(std.UnauthorizedError)
+	require.True(t, ok)
+
+	env.params.SetParam(env.ctx, AuthorityKey, authority)
+
+	res = h.Process(env.ctx, NewMsgSetParam(other, "test.name", `"foo"`))
+	require.False(t, res.IsOK())
+
+	res = h.Process(env.ctx, msg)
+	require.True(t, res.IsOK(), res.Log)
+	var name string
+	require.True(t, env.params.GetParam(env.ctx, "test.name", &name))
+	require.Equal(t, "foo", name)
+
+	res = h.Process(env.ctx, NewMsgSetParam(authority, "test.params", `{"limit":"-1"}`))
+	require.False(t, res.IsOK())
+	_, ok = res.Error.(InvalidParamValueError)
+	require.True(t, ok)
+}
+
+func TestQueryParam(t *testing.T) {
+	t.Parallel()
+
+	env := setupTestEnv()
+	h := NewHandler(env.params)
+	env.params.SetParam(env.ctx, "test.params", testParams{Limit: 3})
+
+	res := h.Query(env.ctx, abci.RequestQuery{Path: "params/test.params"})
+	require.Nil(t, res.Error)
+	var tp testParams
+	require.NoError(t, amino.UnmarshalJSON(res.Data, &tp))
+	require.Equal(t, int64(3), tp.Limit)
+
+	// Registered but unset params return no data.
+	res = h.Query(env.ctx, abci.RequestQuery{Path: "params/test.name"})
+	require.Nil(t, res.Error)
+	require.Nil(t, res.Data)
+
+	res = h.Query(env.ctx, abci.RequestQuery{Path: fmt.Sprintf("params/%s", QueryKeys)})
+	require.Nil(t, res.Error)
+	var keys []string
+	require.NoError(t, amino.UnmarshalJSON(res.Data, &keys))
+	require.Equal(t, env.params.Keys(), keys)
+
+	res = h.Query(env.ctx, abci.RequestQuery{Path: "params/test.unknown"})
+	require.Error(t, res.Error)
+	res = h.Query(env.ctx, abci.RequestQuery{Path: "params"})
+	require.Error(t, res.Error)
+}
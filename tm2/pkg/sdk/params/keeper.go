@@ -0,0 +1,173 @@
+package params
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"reflect"
+	"sort"
+
+	"github.com/gnolang/gno/tm2/pkg/amino"
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+	"github.com/gnolang/gno/tm2/pkg/sdk"
+	"github.com/gnolang/gno/tm2/pkg/store"
+)
+
+// ParamsKeeperI is the interface used by modules to read and write their
+// parameters.
+type ParamsKeeperI interface {
+	GetParam(ctx sdk.Context, key string, ptr interface{}) bool
+	SetParam(ctx sdk.Context, key string, value interface{})
+	Has(ctx sdk.Context, key string) bool
+}
+
+var _ ParamsKeeperI = ParamsKeeper{}
+
+// Validator is implemented by param values which must be checked before
+// being set from genesis or from a MsgSetParam.
+type Validator interface {
+	Validate() error
+}
+
+// ParamsKeeper stores module parameters in the multistore.
+// Params are keyed by "<module>.<name>" (e.g. "auth.params") and amino JSON
+// encoded. The type of each param must be registered with Register.
+type ParamsKeeper struct {
+	// The (unexposed) key used to access the store from the Context.
+	key    store.StoreKey
+	prefix string
+
+	// registered param types, by param key.
+	types map[string]reflect.Type
+}
+
+// NewParamsKeeper returns a new ParamsKeeper storing params under the given
+// prefix (see StoreKeyPrefix) of the store for key.
+func NewParamsKeeper(key store.StoreKey, prefix string) ParamsKeeper {
+	pk := ParamsKeeper{
+		key:    key,
+		prefix: prefix,
+		types:  make(map[string]reflect.Type),
+	}
+	pk.Register(AuthorityKey, crypto.Address{})
+	return pk
+}
+
+// Logger returns a module-specific logger.
+func (pk ParamsKeeper) Logger(ctx sdk.Context) *slog.Logger {
+	return ctx.Logger().With("module", ModuleName)
+}
+
+// Register declares the param with the given key, whose values will be of
+// the same type as proto. It must be called before the app is started.
+func (pk ParamsKeeper) Register(key string, proto interface{}) {
+	if _, exists := pk.types[key]; exists {
+		panic(fmt.Sprintf("param %q already registered", key))
+	}
+	pk.types[key] = reflect.TypeOf(proto)
+}
+
+// Keys returns the sorted keys of all registered params.
+func (pk ParamsKeeper) Keys() []string {
+	keys := make([]string, 0, len(pk.types))
+	for key := range pk.types {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// GetParam decodes the param for key into ptr and returns true if it is set.
+// If the param is not set, ptr is left untouched, so that it may hold the
+// default value.
+func (pk ParamsKeeper) GetParam(ctx sdk.Context, key string, ptr interface{}) bool {
+	bz := pk.GetParamJSON(ctx, key)
+	if bz == nil {
+		return false
+	}
+	amino.MustUnmarshalJSON(bz, ptr)
+	return true
+}
+
+// GetParamJSON returns the amino JSON encoded param for key, or nil if not
+// set.
+func (pk ParamsKeeper) GetParamJSON(ctx sdk.Context, key string) []byte {
+	stor := ctx.Store(pk.key)
+	return stor.Get(pk.storeKey(key))
+}
+
+// SetParam sets the param for key, which must have been registered with the
+// type of value.
+func (pk ParamsKeeper) SetParam(ctx sdk.Context, key string, value interface{}) {
+	rt, ok := pk.types[key]
+	if !ok {
+		panic(fmt.Sprintf("param %q not registered", key))
+	}
+	if vt := reflect.TypeOf(value); vt != rt {
+		panic(fmt.Sprintf("param %q must be of type %v, got %v", key, rt, vt))
+	}
+	stor := ctx.Store(pk.key)
+	stor.Set(pk.storeKey(key), amino.MustMarshalJSON(value))
+}
+
+// SetParamJSON decodes the amino JSON encoded value according to the
+// registered type of key, validates it and sets it.
+// If both the value and the current param are JSON objects, the value is
+// merged into the current param, so that fields left out are not reset.
+func (pk ParamsKeeper) SetParamJSON(ctx sdk.Context, key string, bz []byte) error {
+	rt, ok := pk.types[key]
+	if !ok {
+		return ErrUnknownParam(key)
+	}
+	if current := pk.GetParamJSON(ctx, key); current != nil {
+		bz = mergeJSONObjects(current, bz)
+	}
+	rv := reflect.New(rt)
+	if err := amino.UnmarshalJSON(bz, rv.Interface()); err != nil {
+		return ErrInvalidParamValue(fmt.Sprintf("%s: %v", key, err))
+	}
+	value := rv.Elem().Interface()
+	if v, ok := value.(Validator); ok {
+		if err := v.Validate(); err != nil {
+			return ErrInvalidParamValue(fmt.Sprintf("%s: %v", key, err))
+		}
+	}
+	pk.SetParam(ctx, key, value)
+	return nil
+}
+
+// Has returns true if the param for key is set.
+func (pk ParamsKeeper) Has(ctx sdk.Context, key string) bool {
+	stor := ctx.Store(pk.key)
+	return stor.Has(pk.storeKey(key))
+}
+
+// GetAuthority returns the address allowed to update params with
+// MsgSetParam, or the zero address if params can't be updated.
+func (pk ParamsKeeper) GetAuthority(ctx sdk.Context) crypto.Address {
+	var authority crypto.Address
+	pk.GetParam(ctx, AuthorityKey, &authority)
+	return authority
+}
+
+func (pk ParamsKeeper) storeKey(key string) []byte {
+	return []byte(pk.prefix + key)
+}
+
+// mergeJSONObjects returns the fields of current overwritten by those of
+// update, or update as is if either of them is not a JSON object.
+func mergeJSONObjects(current, update []byte) []byte {
+	var cm, um map[string]json.RawMessage
+	if json.Unmarshal(current, &cm) != nil || json.Unmarshal(update, &um) != nil ||
+		cm == nil || um == nil {
+		return update
+	}
+	for k, v := range um {
+		cm[k] = v
+	}
+	bz, err := json.Marshal(cm)
+	if err != nil {
+		return update
+	}
+	return bz
+}
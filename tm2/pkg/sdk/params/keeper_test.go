@@ -0,0 +1,75 @@
+package params
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParamsKeeper(t *testing.T) {
+	t.Parallel()
+
+	env := setupTestEnv()
+	ctx, params := env.ctx, env.params
+
+	// Unset params leave the default untouched.
+	tp := testParams{Limit: 42}
+	require.False(t, params.Has(ctx, "test.params"))
+	require.False(t, params.GetParam(ctx, "test.params", &tp))
+	require.Equal(t, int64(42), tp.Limit)
+
+	params.SetParam(ctx, "test.params", testParams{Limit: 10})
+	require.True(t, params.Has(ctx, "test.params"))
+	require.True(t, params.GetParam(ctx, "test.params", &tp))
+	require.Equal(t, int64(10), tp.Limit)
+
+	params.SetParam(ctx, "test.name", "foo")
+	var name string
+	require.True(t, params.GetParam(ctx, "test.name", &name))
+	require.Equal(t, "foo", name)
+
+	// Unregistered keys and mismatched types are programming errors.
+	require.Panics(t, func() { params.SetParam(ctx, "test.unknown", "foo") })
+	require.Panics(t, func() { params.SetParam(ctx, "test.name", 42) })
+	require.Panics(t, func() { params.Register("test.name", "") })
+
+	require.Equal(t, []string{AuthorityKey, "test.name", "test.params"}, params.Keys())
+}
+
+func TestParamsKeeperSetParamJSON(t *testing.T) {
+	t.Parallel()
+
+	env := setupTestEnv()
+	ctx, params := env.ctx, env.params
+
+	require.NoError(t, params.SetParamJSON(ctx, "test.params", []byte(`{"limit":"7"}`)))
+	var tp testParams
+	require.True(t, params.GetParam(ctx, "test.params", &tp))
+	require.Equal(t, int64(7), tp.Limit)
+	require.Equal(t, `{"limit":"7","name":""}`, string(params.GetParamJSON(ctx, "test.params")))
+
+	err := params.SetParamJSON(ctx, "test.unknown", []byte(`"foo"`))
+	require.True(t, errors.Is(err, UnknownParamError{}))
+
+	err = params.SetParamJSON(ctx, "test.params", []byte(`{"limit":"-1"}`))
+	require.True(t, errors.Is(err, InvalidParamValueError{}))
+	err = params.SetParamJSON(ctx, "test.params", []byte(`not json`))
+	require.True(t, errors.Is(err, InvalidParamValueError{}))
+
+	// Failed updates keep the previous value.
+	require.True(t, params.GetParam(ctx, "test.params", &tp))
+	require.Equal(t, int64(7), tp.Limit)
+
+	// Partial updates keep the other fields.
+	require.NoError(t, params.SetParamJSON(ctx, "test.params", []byte(`{"name":"foo"}`)))
+	require.True(t, params.GetParam(ctx, "test.params", &tp))
+	require.Equal(t, testParams{Limit: 7, Name: "foo"}, tp)
+
+	// Non-object params are replaced.
+	require.NoError(t, params.SetParamJSON(ctx, "test.name", []byte(`"foo"`)))
+	require.NoError(t, params.SetParamJSON(ctx, "test.name", []byte(`"bar"`)))
+	var name string
+	require.True(t, params.GetParam(ctx, "test.name", &name))
+	require.Equal(t, "bar", name)
+}
@@ -0,0 +1,54 @@
+package params
+
+import (
+	"github.com/gnolang/gno/tm2/pkg/amino"
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+	"github.com/gnolang/gno/tm2/pkg/std"
+)
+
+// RouterKey is the name of the params module
+const RouterKey = ModuleName
+
+// MsgSetParam - update a param; only the params authority may send it.
+type MsgSetParam struct {
+	Caller crypto.Address `json:"caller" yaml:"caller"`
+	Key    string         `json:"key" yaml:"key"`
+	Value  string         `json:"value" yaml:"value"` // amino JSON encoded.
+}
+
+var _ std.Msg = MsgSetParam{}
+
+// NewMsgSetParam - set the param for key to the amino JSON encoded value.
+func NewMsgSetParam(caller crypto.Address, key string, value string) MsgSetParam {
+	return MsgSetParam{Caller: caller, Key: key, Value: value}
+}
+
+// Route Implements Msg.
+func (msg MsgSetParam) Route() string { return RouterKey }
+
+// Type Implements Msg.
+func (msg MsgSetParam) Type() string { return "set_param" }
+
+// ValidateBasic Implements Msg.
+func (msg MsgSetParam) ValidateBasic() error {
+	if msg.Caller.IsZero() {
+		return std.ErrInvalidAddress("missing caller address")
+	}
+	if msg.Key == "" {
+		return ErrUnknownParam("missing param key")
+	}
+	if msg.Value == "" {
+		return ErrInvalidParamValue("missing param value")
+	}
+	return nil
+}
+
+// GetSignBytes Implements Msg.
+func (msg MsgSetParam) GetSignBytes() []byte {
+	return std.MustSortJSON(amino.MustMarshalJSON(msg))
+}
+
+// GetSigners Implements Msg.
+func (msg MsgSetParam) GetSigners() []crypto.Address {
+	return []crypto.Address{msg.Caller}
+}
@@ -0,0 +1,26 @@
+package params
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Param is a key and amino JSON encoded value pair, as found in genesis.
+type Param struct {
+	Key   string `json:"key" yaml:"key"`
+	Value string `json:"value" yaml:"value"`
+}
+
+// Parse parses a "<key>=<value>" entry.
+func (p *Param) Parse(entry string) error {
+	parts := strings.SplitN(strings.TrimSpace(entry), "=", 2) // <key>=<value>
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return fmt.Errorf("malformed entry: %q", entry)
+	}
+	p.Key, p.Value = parts[0], parts[1]
+	return nil
+}
+
+func (p Param) String() string {
+	return fmt.Sprintf("%s=%s", p.Key, p.Value)
+}
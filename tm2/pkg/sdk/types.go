@@ -25,6 +25,8 @@ type Result struct {
 	abci.ResponseBase
 	GasWanted int64
 	GasUsed   int64
+
+	priority int64 // of the tx in a priority mempool, set in check mode.
 }
 
 // AnteHandler authenticates transactions, before their internal messages are handled.
@@ -1,6 +1,7 @@
 package std
 
 import (
+	"math/big"
 	"strings"
 
 	"github.com/gnolang/gno/tm2/pkg/errors"
@@ -48,3 +49,15 @@ func ParseGasPrices(gasprices string) (res []GasPrice, err error) {
 	}
 	return res, nil
 }
+
+// Fee returns the smallest fee paying for gas at this gas price, as required
+// by the mempool: gas * Price / Gas, rounded up.
+func (gp GasPrice) Fee(gas int64) Coin {
+	if gp.Gas <= 0 {
+		return NewCoin(gp.Price.Denom, 0)
+	}
+	amt := new(big.Int).Mul(big.NewInt(gas), big.NewInt(gp.Price.Amount))
+	amt.Add(amt, big.NewInt(gp.Gas-1))
+	amt.Quo(amt, big.NewInt(gp.Gas))
+	return NewCoin(gp.Price.Denom, amt.Int64())
+}
@@ -0,0 +1,29 @@
+package std
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGasPriceFee(t *testing.T) {
+	t.Parallel()
+
+	gp, err := ParseGasPrice("1ugnot/1000gas")
+	require.NoError(t, err)
+
+	tests := []struct {
+		gas      int64
+		expected int64
+	}{
+		{0, 0},
+		{1, 1},
+		{1000, 1},
+		{1001, 2},
+		{2500000, 2500},
+	}
+	for _, tc := range tests {
+		assert.Equal(t, NewCoin("ugnot", tc.expected), gp.Fee(tc.gas), "gas: %d", tc.gas)
+	}
+}